@@ -0,0 +1,328 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// AuthProvider is a pluggable authentication mechanism for Client,
+// installed with WithAuthProvider in place of the built-in X-APIKeys
+// header WithAPIKeys sets. Apply attaches credentials to an outgoing
+// request and runs on every attempt, including retries. Refresh renews
+// the credentials (re-authenticating, or exchanging a refresh token)
+// and is called both by implementations that proactively renew a
+// near-expiry token from within Apply, and by Client itself when a
+// request fails with a 401 carrying an expired-token error: if Refresh
+// succeeds, Client retries the request, which re-applies the refreshed
+// credentials.
+type AuthProvider interface {
+	Apply(req *resty.Request) error
+	Refresh(ctx context.Context) error
+}
+
+// isExpiredTokenError reports whether apiErr represents a 401 caused by
+// an expired or otherwise invalid credential, as opposed to one correct
+// credentials simply aren't authorized for (which re-authenticating
+// wouldn't fix).
+func isExpiredTokenError(apiErr *APIError) bool {
+	switch apiErr.Code {
+	case ErrCodeTokenExpired, ErrCodeInvalidCredentials:
+		return true
+	default:
+		return false
+	}
+}
+
+// BearerTokenProvider attaches a static bearer token, such as a
+// pre-issued JWT, to every request's Authorization header. Refresh is a
+// no-op, since a BearerTokenProvider has nothing of its own to renew;
+// use JWTProvider or OAuth2ClientCredentialsProvider for a token that
+// needs to be minted or renewed automatically.
+type BearerTokenProvider struct {
+	Token string
+}
+
+// NewBearerTokenProvider returns a BearerTokenProvider for token.
+func NewBearerTokenProvider(token string) *BearerTokenProvider {
+	return &BearerTokenProvider{Token: token}
+}
+
+// Apply implements AuthProvider.
+func (p *BearerTokenProvider) Apply(req *resty.Request) error {
+	req.SetHeader("Authorization", "Bearer "+p.Token)
+	return nil
+}
+
+// Refresh implements AuthProvider; it does nothing.
+func (p *BearerTokenProvider) Refresh(ctx context.Context) error {
+	return nil
+}
+
+// JWTProvider attaches a bearer token produced by Sign, for JWTs the
+// caller mints locally (e.g. a signed service-account assertion) rather
+// than retrieves from a token endpoint. The signed token is cached and
+// reused until RefreshBefore of its reported expiry, then re-signed,
+// either proactively from Apply or on demand when Client calls Refresh
+// after an expired-token 401.
+type JWTProvider struct {
+	// Sign mints a new JWT, returning it and its expiry.
+	Sign func(ctx context.Context) (token string, expiresAt time.Time, err error)
+	// RefreshBefore re-signs the token this long before it expires.
+	// Defaults to 30 seconds.
+	RefreshBefore time.Duration
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewJWTProvider returns a JWTProvider that signs tokens with sign.
+func NewJWTProvider(sign func(ctx context.Context) (string, time.Time, error)) *JWTProvider {
+	return &JWTProvider{Sign: sign}
+}
+
+// Apply implements AuthProvider, re-signing the token first if it's
+// missing or within RefreshBefore of expiry.
+func (p *JWTProvider) Apply(req *resty.Request) error {
+	p.mu.Lock()
+	stale := p.token == "" || (!p.expiresAt.IsZero() && !time.Now().Before(p.expiresAt.Add(-p.refreshBefore())))
+	p.mu.Unlock()
+
+	if stale {
+		if err := p.Refresh(req.Context()); err != nil {
+			return fmt.Errorf("jwt auth: %w", err)
+		}
+	}
+
+	p.mu.Lock()
+	token := p.token
+	p.mu.Unlock()
+
+	req.SetHeader("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Refresh implements AuthProvider by calling Sign for a new token.
+func (p *JWTProvider) Refresh(ctx context.Context) error {
+	token, expiresAt, err := p.Sign(ctx)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.token, p.expiresAt = token, expiresAt
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *JWTProvider) refreshBefore() time.Duration {
+	if p.RefreshBefore > 0 {
+		return p.RefreshBefore
+	}
+	return 30 * time.Second
+}
+
+// OAuth2ClientCredentialsProvider implements the OAuth2
+// client-credentials grant: it exchanges ClientID/ClientSecret for an
+// access token at TokenURL and attaches it as a bearer token, renewing
+// it once it's expired, either proactively from Apply or when Client
+// calls Refresh after an expired-token 401.
+type OAuth2ClientCredentialsProvider struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// HTTPClient issues the token request. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// NewOAuth2ClientCredentialsProvider returns an
+// OAuth2ClientCredentialsProvider for the given token endpoint and
+// client credentials.
+func NewOAuth2ClientCredentialsProvider(tokenURL, clientID, clientSecret string, scopes ...string) *OAuth2ClientCredentialsProvider {
+	return &OAuth2ClientCredentialsProvider{TokenURL: tokenURL, ClientID: clientID, ClientSecret: clientSecret, Scopes: scopes}
+}
+
+// Apply implements AuthProvider, fetching an access token first if none
+// is cached or the cached one has expired.
+func (p *OAuth2ClientCredentialsProvider) Apply(req *resty.Request) error {
+	p.mu.Lock()
+	expired := p.accessToken == "" || (!p.expiresAt.IsZero() && !time.Now().Before(p.expiresAt))
+	p.mu.Unlock()
+
+	if expired {
+		if err := p.Refresh(req.Context()); err != nil {
+			return fmt.Errorf("oauth2 auth: %w", err)
+		}
+	}
+
+	p.mu.Lock()
+	token := p.accessToken
+	p.mu.Unlock()
+
+	req.SetHeader("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Refresh implements AuthProvider by running the client-credentials
+// grant against TokenURL.
+func (p *OAuth2ClientCredentialsProvider) Refresh(ctx context.Context) error {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {p.ClientID},
+		"client_secret": {p.ClientSecret},
+	}
+	if len(p.Scopes) > 0 {
+		form.Set("scope", strings.Join(p.Scopes, " "))
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("build token request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpClient := p.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read token response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("token endpoint returned %d: %s", resp.StatusCode, body)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return fmt.Errorf("decode token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return fmt.Errorf("token response has no access_token")
+	}
+
+	p.mu.Lock()
+	p.accessToken = token.AccessToken
+	if token.ExpiresIn > 0 {
+		p.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	} else {
+		p.expiresAt = time.Time{}
+	}
+	p.mu.Unlock()
+	return nil
+}
+
+// SessionCookieProvider implements Tenable's username/password
+// session-login flow (POST {BaseURL}/session), attaching the token it
+// returns as the X-Cookie header every later request needs, the
+// mechanism Tenable.sc and Nessus Manager expect in place of API keys.
+// It logs in lazily on the first Apply and logs in again whenever
+// Refresh is called.
+type SessionCookieProvider struct {
+	BaseURL  string
+	Username string
+	Password string
+	// HTTPClient issues the login request. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	mu    sync.Mutex
+	token string
+}
+
+// NewSessionCookieProvider returns a SessionCookieProvider that logs
+// into baseURL with username/password.
+func NewSessionCookieProvider(baseURL, username, password string) *SessionCookieProvider {
+	return &SessionCookieProvider{BaseURL: baseURL, Username: username, Password: password}
+}
+
+// Apply implements AuthProvider, logging in first if no session token
+// is cached yet.
+func (p *SessionCookieProvider) Apply(req *resty.Request) error {
+	p.mu.Lock()
+	token := p.token
+	p.mu.Unlock()
+
+	if token == "" {
+		if err := p.Refresh(req.Context()); err != nil {
+			return fmt.Errorf("session cookie auth: %w", err)
+		}
+		p.mu.Lock()
+		token = p.token
+		p.mu.Unlock()
+	}
+
+	req.SetHeader("X-Cookie", "token="+token)
+	return nil
+}
+
+// Refresh implements AuthProvider by logging in again.
+func (p *SessionCookieProvider) Refresh(ctx context.Context) error {
+	payload, err := json.Marshal(map[string]string{
+		"username": p.Username,
+		"password": p.Password,
+	})
+	if err != nil {
+		return fmt.Errorf("encode login request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(p.BaseURL, "/")+"/session", strings.NewReader(string(payload)))
+	if err != nil {
+		return fmt.Errorf("build login request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpClient := p.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("login request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read login response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("session login returned %d: %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("decode login response: %w", err)
+	}
+	if result.Token == "" {
+		return fmt.Errorf("login response has no token")
+	}
+
+	p.mu.Lock()
+	p.token = result.Token
+	p.mu.Unlock()
+	return nil
+}
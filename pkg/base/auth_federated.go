@@ -0,0 +1,180 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// OIDCToken is a bearer token minted by an external OIDC identity
+// provider, together with its expiry.
+type OIDCToken struct {
+	AccessToken string
+	ExpiresAt   time.Time
+}
+
+// OIDCTokenSource mints an OIDC access token, the same role
+// oauth2.TokenSource plays for callers already wired into an OIDC
+// flow (e.g. a workload-identity exchange or a cached browser login)
+// — expressed as a plain func here rather than depending on
+// golang.org/x/oauth2, matching how JWTProvider.Sign takes its token
+// minting logic as a func rather than a library interface.
+type OIDCTokenSource func(ctx context.Context) (OIDCToken, error)
+
+// OIDCTokenProvider attaches a bearer token minted by TokenSource to
+// every request's Authorization header, re-minting it once it's
+// within RefreshBefore of expiry, either proactively from Apply or
+// when Client calls Refresh after an expired-token 401.
+type OIDCTokenProvider struct {
+	TokenSource OIDCTokenSource
+	// RefreshBefore re-mints the token this long before it expires.
+	// Defaults to 30 seconds.
+	RefreshBefore time.Duration
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewOIDCTokenProvider returns an OIDCTokenProvider that mints tokens
+// with tokenSource.
+func NewOIDCTokenProvider(tokenSource OIDCTokenSource) *OIDCTokenProvider {
+	return &OIDCTokenProvider{TokenSource: tokenSource}
+}
+
+// Apply implements AuthProvider, re-minting the token first if it's
+// missing or within RefreshBefore of expiry.
+func (p *OIDCTokenProvider) Apply(req *resty.Request) error {
+	p.mu.Lock()
+	stale := p.token == "" || (!p.expiresAt.IsZero() && !time.Now().Before(p.expiresAt.Add(-p.refreshBefore())))
+	p.mu.Unlock()
+
+	if stale {
+		if err := p.Refresh(req.Context()); err != nil {
+			return fmt.Errorf("oidc auth: %w", err)
+		}
+	}
+
+	p.mu.Lock()
+	token := p.token
+	p.mu.Unlock()
+
+	req.SetHeader("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Refresh implements AuthProvider by calling TokenSource for a new
+// token.
+func (p *OIDCTokenProvider) Refresh(ctx context.Context) error {
+	token, err := p.TokenSource(ctx)
+	if err != nil {
+		return err
+	}
+	if token.AccessToken == "" {
+		return fmt.Errorf("oidc token source returned an empty access token")
+	}
+	p.mu.Lock()
+	p.token, p.expiresAt = token.AccessToken, token.ExpiresAt
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *OIDCTokenProvider) refreshBefore() time.Duration {
+	if p.RefreshBefore > 0 {
+		return p.RefreshBefore
+	}
+	return 30 * time.Second
+}
+
+// SAMLAssertionProvider exchanges a SAML assertion for a Tenable
+// session cookie via Exchange, attaching it as the X-Cookie header the
+// same way SessionCookieProvider does, and re-exchanging the assertion
+// once the session it returned has expired — either proactively from
+// Apply or when Client calls Refresh after an expired-token 401. This
+// suits an IdP-initiated SSO flow, where the caller already holds a
+// SAML assertion and needs it turned into a Tenable session rather
+// than logging in with a username/password.
+type SAMLAssertionProvider struct {
+	// Assertion is the SAML assertion (typically base64-encoded XML)
+	// to exchange.
+	Assertion string
+	// Exchange trades Assertion for a Tenable session token and its
+	// expiry.
+	Exchange func(ctx context.Context, assertion string) (session string, expiresAt time.Time, err error)
+
+	mu        sync.Mutex
+	session   string
+	expiresAt time.Time
+}
+
+// NewSAMLAssertionProvider returns a SAMLAssertionProvider that
+// exchanges assertion for a session token via exchange.
+func NewSAMLAssertionProvider(assertion string, exchange func(ctx context.Context, assertion string) (string, time.Time, error)) *SAMLAssertionProvider {
+	return &SAMLAssertionProvider{Assertion: assertion, Exchange: exchange}
+}
+
+// Apply implements AuthProvider, exchanging the assertion first if no
+// session is cached yet or the cached one has expired.
+func (p *SAMLAssertionProvider) Apply(req *resty.Request) error {
+	p.mu.Lock()
+	stale := p.session == "" || (!p.expiresAt.IsZero() && !time.Now().Before(p.expiresAt))
+	p.mu.Unlock()
+
+	if stale {
+		if err := p.Refresh(req.Context()); err != nil {
+			return fmt.Errorf("saml auth: %w", err)
+		}
+	}
+
+	p.mu.Lock()
+	session := p.session
+	p.mu.Unlock()
+
+	req.SetHeader("X-Cookie", "token="+session)
+	return nil
+}
+
+// Refresh implements AuthProvider by calling Exchange again.
+func (p *SAMLAssertionProvider) Refresh(ctx context.Context) error {
+	session, expiresAt, err := p.Exchange(ctx, p.Assertion)
+	if err != nil {
+		return err
+	}
+	if session == "" {
+		return fmt.Errorf("saml assertion exchange returned an empty session")
+	}
+	p.mu.Lock()
+	p.session, p.expiresAt = session, expiresAt
+	p.mu.Unlock()
+	return nil
+}
+
+// StaticSessionCookieProvider attaches a pre-established Tenable
+// session cookie (e.g. one a caller already obtained through
+// SessionAPI's own login call) to every request's X-Cookie header.
+// Refresh is a no-op, since a StaticSessionCookieProvider has nothing
+// of its own to renew — use SessionCookieProvider if Client should log
+// in, and log in again, on its own.
+type StaticSessionCookieProvider struct {
+	Cookie string
+}
+
+// NewStaticSessionCookieProvider returns a StaticSessionCookieProvider
+// for cookie.
+func NewStaticSessionCookieProvider(cookie string) *StaticSessionCookieProvider {
+	return &StaticSessionCookieProvider{Cookie: cookie}
+}
+
+// Apply implements AuthProvider.
+func (p *StaticSessionCookieProvider) Apply(req *resty.Request) error {
+	req.SetHeader("X-Cookie", "token="+p.Cookie)
+	return nil
+}
+
+// Refresh implements AuthProvider; it does nothing.
+func (p *StaticSessionCookieProvider) Refresh(ctx context.Context) error {
+	return nil
+}
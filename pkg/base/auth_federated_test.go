@@ -0,0 +1,178 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOIDCTokenProviderMintsAndCachesToken(t *testing.T) {
+	var mints int32
+	provider := NewOIDCTokenProvider(func(ctx context.Context) (OIDCToken, error) {
+		atomic.AddInt32(&mints, 1)
+		return OIDCToken{AccessToken: "oidc-tok", ExpiresAt: time.Now().Add(time.Hour)}, nil
+	})
+
+	var receivedAuth []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedAuth = append(receivedAuth, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient("TEST", server.URL, WithAuthProvider(provider))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var result interface{}
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get(context.Background(), "/test", &result); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+	}
+
+	if mints != 1 {
+		t.Errorf("mints = %d, want 1 (cached token reused)", mints)
+	}
+	for _, auth := range receivedAuth {
+		if auth != "Bearer oidc-tok" {
+			t.Errorf("Authorization header = %q, want %q", auth, "Bearer oidc-tok")
+		}
+	}
+}
+
+func TestOIDCTokenProviderRefreshesNearExpiry(t *testing.T) {
+	var mints int32
+	provider := NewOIDCTokenProvider(func(ctx context.Context) (OIDCToken, error) {
+		n := atomic.AddInt32(&mints, 1)
+		if n == 1 {
+			return OIDCToken{AccessToken: "tok-1", ExpiresAt: time.Now().Add(10 * time.Millisecond)}, nil
+		}
+		return OIDCToken{AccessToken: "tok-2", ExpiresAt: time.Now().Add(time.Hour)}, nil
+	})
+	provider.RefreshBefore = time.Hour // first token is always "near expiry"
+
+	if err := provider.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if provider.token != "tok-1" {
+		t.Fatalf("token = %q, want tok-1", provider.token)
+	}
+
+	var receivedAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient("TEST", server.URL, WithAuthProvider(provider))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	var result interface{}
+	if _, err := client.Get(context.Background(), "/test", &result); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if receivedAuth != "Bearer tok-2" {
+		t.Errorf("Authorization header = %q, want %q (near-expiry token re-minted)", receivedAuth, "Bearer tok-2")
+	}
+}
+
+func TestSAMLAssertionProviderExchangesAssertion(t *testing.T) {
+	var receivedAssertion string
+	var receivedCookie string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedCookie = r.Header.Get("X-Cookie")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	provider := NewSAMLAssertionProvider("assertion-xml", func(ctx context.Context, assertion string) (string, time.Time, error) {
+		receivedAssertion = assertion
+		return "saml-session", time.Now().Add(time.Hour), nil
+	})
+	client, err := NewClient("TEST", server.URL, WithAuthProvider(provider))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var result interface{}
+	if _, err := client.Get(context.Background(), "/test", &result); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if receivedAssertion != "assertion-xml" {
+		t.Errorf("Exchange assertion = %q, want %q", receivedAssertion, "assertion-xml")
+	}
+	if receivedCookie != "token=saml-session" {
+		t.Errorf("X-Cookie header = %q, want %q", receivedCookie, "token=saml-session")
+	}
+}
+
+func TestSAMLAssertionProviderReExchangesAfterExpiry(t *testing.T) {
+	var exchanges int32
+	provider := NewSAMLAssertionProvider("assertion-xml", func(ctx context.Context, assertion string) (string, time.Time, error) {
+		n := atomic.AddInt32(&exchanges, 1)
+		if n == 1 {
+			return "sess-1", time.Now().Add(-time.Second), nil
+		}
+		return "sess-2", time.Now().Add(time.Hour), nil
+	})
+
+	if err := provider.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if provider.session != "sess-1" {
+		t.Fatalf("session = %q, want sess-1", provider.session)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient("TEST", server.URL, WithAuthProvider(provider))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	var result interface{}
+	if _, err := client.Get(context.Background(), "/test", &result); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if exchanges != 2 {
+		t.Errorf("exchanges = %d, want 2 (expired session triggered a re-exchange)", exchanges)
+	}
+}
+
+func TestStaticSessionCookieProviderAttachesCookie(t *testing.T) {
+	var receivedCookie string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedCookie = r.Header.Get("X-Cookie")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	provider := NewStaticSessionCookieProvider("pre-established")
+	client, err := NewClient("TEST", server.URL, WithAuthProvider(provider))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var result interface{}
+	if _, err := client.Get(context.Background(), "/test", &result); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if receivedCookie != "token=pre-established" {
+		t.Errorf("X-Cookie header = %q, want %q", receivedCookie, "token=pre-established")
+	}
+}
@@ -0,0 +1,212 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func TestWithAuthProviderAppliesHeader(t *testing.T) {
+	var receivedAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient("TEST", server.URL, WithAuthProvider(NewBearerTokenProvider("abc123")))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if !client.IsAuthenticated() {
+		t.Error("IsAuthenticated() = false, want true with an AuthProvider installed")
+	}
+
+	var result interface{}
+	if _, err := client.Get(context.Background(), "/test", &result); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if receivedAuth != "Bearer abc123" {
+		t.Errorf("Authorization header = %q, want %q", receivedAuth, "Bearer abc123")
+	}
+}
+
+func TestWithAuthProviderRefreshesOnExpiredTokenResponse(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		if n == 1 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error_code": "token_expired", "error": "token expired"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	provider := &countingRefreshProvider{token: "stale"}
+	client, err := NewClient("TEST", server.URL, WithAuthProvider(provider), WithRetries(1))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var result interface{}
+	if _, err := client.Get(context.Background(), "/test", &result); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if provider.refreshes != 1 {
+		t.Errorf("refreshes = %d, want 1", provider.refreshes)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2 (original + retry after refresh)", requests)
+	}
+}
+
+type countingRefreshProvider struct {
+	token     string
+	refreshes int
+}
+
+func (p *countingRefreshProvider) Apply(req *resty.Request) error {
+	req.SetHeader("Authorization", "Bearer "+p.token)
+	return nil
+}
+
+func (p *countingRefreshProvider) Refresh(ctx context.Context) error {
+	p.refreshes++
+	p.token = "fresh"
+	return nil
+}
+
+func TestOAuth2ClientCredentialsProviderFetchesAndCachesToken(t *testing.T) {
+	var tokenRequests int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokenRequests, 1)
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("ParseForm() error = %v", err)
+		}
+		if r.FormValue("grant_type") != "client_credentials" {
+			t.Errorf("grant_type = %q, want client_credentials", r.FormValue("grant_type"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "tok-1", "expires_in": 3600})
+	}))
+	defer tokenServer.Close()
+
+	var receivedAuth []string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedAuth = append(receivedAuth, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer apiServer.Close()
+
+	provider := NewOAuth2ClientCredentialsProvider(tokenServer.URL, "id", "secret", "scan:read")
+	client, err := NewClient("TEST", apiServer.URL, WithAuthProvider(provider))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var result interface{}
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get(context.Background(), "/test", &result); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+	}
+
+	if tokenRequests != 1 {
+		t.Errorf("tokenRequests = %d, want 1 (cached access token reused)", tokenRequests)
+	}
+	for _, auth := range receivedAuth {
+		if auth != "Bearer tok-1" {
+			t.Errorf("Authorization header = %q, want %q", auth, "Bearer tok-1")
+		}
+	}
+}
+
+func TestSessionCookieProviderLogsInLazily(t *testing.T) {
+	var receivedCookie string
+	var loginRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/session" {
+			atomic.AddInt32(&loginRequests, 1)
+			var payload map[string]string
+			json.NewDecoder(r.Body).Decode(&payload)
+			if payload["username"] != "bob" || payload["password"] != "hunter2" {
+				t.Errorf("login payload = %+v, want bob/hunter2", payload)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]string{"token": "sess-token"})
+			return
+		}
+		receivedCookie = r.Header.Get("X-Cookie")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	provider := NewSessionCookieProvider(server.URL, "bob", "hunter2")
+	client, err := NewClient("TEST", server.URL, WithAuthProvider(provider))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var result interface{}
+	if _, err := client.Get(context.Background(), "/test", &result); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if receivedCookie != "token=sess-token" {
+		t.Errorf("X-Cookie header = %q, want %q", receivedCookie, "token=sess-token")
+	}
+	if loginRequests != 1 {
+		t.Errorf("loginRequests = %d, want 1", loginRequests)
+	}
+}
+
+func TestJWTProviderRefreshesNearExpiry(t *testing.T) {
+	var signs int32
+	provider := NewJWTProvider(func(ctx context.Context) (string, time.Time, error) {
+		n := atomic.AddInt32(&signs, 1)
+		if n == 1 {
+			return "tok-1", time.Now().Add(10 * time.Millisecond), nil
+		}
+		return "tok-2", time.Now().Add(time.Hour), nil
+	})
+	provider.RefreshBefore = time.Hour // first token is always "near expiry"
+
+	if err := provider.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if provider.token != "tok-1" {
+		t.Fatalf("token = %q, want tok-1", provider.token)
+	}
+
+	var receivedAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient("TEST", server.URL, WithAuthProvider(provider))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	var result interface{}
+	if _, err := client.Get(context.Background(), "/test", &result); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if receivedAuth != "Bearer tok-2" {
+		t.Errorf("Authorization header = %q, want %q (near-expiry token re-signed)", receivedAuth, "Bearer tok-2")
+	}
+}
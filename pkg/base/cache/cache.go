@@ -0,0 +1,168 @@
+// Package cache provides a small, generic LRU+TTL cache, used to avoid
+// re-fetching slow-changing API resources (tag categories, tag values,
+// assets) on every lookup. LRU is the only implementation today; Cache is
+// still exported as an interface so callers (and tests) can substitute
+// their own.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/base"
+)
+
+// Cache is a small key/value store with bounded size and, depending on
+// the implementation, entry expiration.
+type Cache[K comparable, V any] interface {
+	// Get returns the value stored under key, or ok == false if there is
+	// none (or it has expired).
+	Get(key K) (value V, ok bool)
+	// Put stores value under key, overwriting any previous value and
+	// resetting its TTL.
+	Put(key K, value V)
+	// Delete removes the entry stored under key, if any.
+	Delete(key K)
+}
+
+// entry is one LRU node: its key (kept alongside the value so an
+// eviction can remove it from the index map without a second lookup),
+// value, and absolute expiration time (zero if the cache has no TTL).
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// LRU is a Cache bounded to a maximum size, evicting the least recently
+// used entry when full, with an optional per-entry TTL. The zero value is
+// not usable; create one with NewLRU. Safe for concurrent use.
+type LRU[K comparable, V any] struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	items map[K]*list.Element
+	order *list.List // front = most recently used, back = least
+
+	name    string
+	metrics base.Metrics
+}
+
+// Option configures an LRU.
+type Option[K comparable, V any] func(*LRU[K, V])
+
+// WithCacheMetrics reports hit/miss/eviction counts through m (the same
+// telemetry surface base.WithMetrics installs on a Client), tagged with
+// name so multiple caches sharing one Metrics can be told apart.
+func WithCacheMetrics[K comparable, V any](name string, m base.Metrics) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.name = name
+		c.metrics = m
+	}
+}
+
+// NewLRU creates an LRU holding at most size entries (size <= 0 means
+// unbounded) with the given per-entry TTL (ttl <= 0 means entries never
+// expire on their own).
+func NewLRU[K comparable, V any](size int, ttl time.Duration, opts ...Option[K, V]) *LRU[K, V] {
+	c := &LRU[K, V]{
+		size:  size,
+		ttl:   ttl,
+		items: make(map[K]*list.Element),
+		order: list.New(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Get implements Cache.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		c.reportMiss()
+		var zero V
+		return zero, false
+	}
+
+	e := el.Value.(*entry[K, V])
+	if c.ttl > 0 && time.Now().After(e.expiresAt) {
+		c.removeElement(el)
+		c.reportMiss()
+		var zero V
+		return zero, false
+	}
+
+	c.order.MoveToFront(el)
+	c.reportHit()
+	return e.value, true
+}
+
+// Put implements Cache.
+func (c *LRU[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if c.ttl > 0 {
+		expiresAt = time.Now().Add(c.ttl)
+	}
+
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry[K, V])
+		e.value = value
+		e.expiresAt = expiresAt
+		c.order.MoveToFront(el)
+		return
+	}
+
+	el := c.order.PushFront(&entry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	c.items[key] = el
+
+	if c.size > 0 && c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.removeElement(oldest)
+		c.reportEvict()
+	}
+}
+
+// Delete implements Cache.
+func (c *LRU[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+	}
+}
+
+// removeElement drops el from both the LRU ordering and the index map.
+// Callers must hold c.mu.
+func (c *LRU[K, V]) removeElement(el *list.Element) {
+	e := el.Value.(*entry[K, V])
+	delete(c.items, e.key)
+	c.order.Remove(el)
+}
+
+func (c *LRU[K, V]) reportHit() {
+	if c.metrics.OnCacheHit != nil {
+		c.metrics.OnCacheHit(c.name)
+	}
+}
+
+func (c *LRU[K, V]) reportMiss() {
+	if c.metrics.OnCacheMiss != nil {
+		c.metrics.OnCacheMiss(c.name)
+	}
+}
+
+func (c *LRU[K, V]) reportEvict() {
+	if c.metrics.OnCacheEvict != nil {
+		c.metrics.OnCacheEvict(c.name)
+	}
+}
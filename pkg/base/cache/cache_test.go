@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/base"
+)
+
+func TestLRUGetPutDelete(t *testing.T) {
+	c := NewLRU[string, int](10, 0)
+
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("Get() on missing key ok = true, want false")
+	}
+
+	c.Put("a", 1)
+	got, ok := c.Get("a")
+	if !ok || got != 1 {
+		t.Fatalf("Get(a) = %d, %v, want 1, true", got, ok)
+	}
+
+	c.Put("a", 2)
+	got, ok = c.Get("a")
+	if !ok || got != 2 {
+		t.Fatalf("Get(a) after overwrite = %d, %v, want 2, true", got, ok)
+	}
+
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get() after Delete() ok = true, want false")
+	}
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	var evicted []string
+	c := NewLRU[string, int](2, 0, WithCacheMetrics[string, int]("test", base.Metrics{
+		OnCacheEvict: func(name string) { evicted = append(evicted, name) },
+	}))
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("a") // a is now most recently used; b is least
+	c.Put("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("Get(b) ok = true, want false (should have been evicted)")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Error("Get(a) ok = false, want true (should have survived eviction)")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Error("Get(c) ok = false, want true")
+	}
+	if len(evicted) != 1 || evicted[0] != "test" {
+		t.Errorf("evicted = %v, want one report tagged %q", evicted, "test")
+	}
+}
+
+func TestLRUExpiresEntriesAfterTTL(t *testing.T) {
+	c := NewLRU[string, int](10, time.Millisecond)
+
+	c.Put("a", 1)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get() after TTL elapsed ok = true, want false")
+	}
+}
+
+func TestLRUReportsHitsAndMisses(t *testing.T) {
+	var hits, misses int
+	c := NewLRU[string, int](10, 0, WithCacheMetrics[string, int]("test", base.Metrics{
+		OnCacheHit:  func(string) { hits++ },
+		OnCacheMiss: func(string) { misses++ },
+	}))
+
+	c.Get("missing")
+	c.Put("a", 1)
+	c.Get("a")
+	c.Get("a")
+
+	if misses != 1 {
+		t.Errorf("misses = %d, want 1", misses)
+	}
+	if hits != 2 {
+		t.Errorf("hits = %d, want 2", hits)
+	}
+}
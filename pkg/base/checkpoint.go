@@ -0,0 +1,124 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrCheckpointNotFound is returned by CheckpointStore.Load when no
+// checkpoint has been saved for the given key.
+var ErrCheckpointNotFound = errors.New("tenable: checkpoint not found")
+
+// CheckpointStore persists small opaque state blobs under a caller-chosen
+// key so a long-running operation (such as a paginated export) can resume
+// after a process restart instead of starting over. Implementations must
+// be safe for concurrent use.
+type CheckpointStore interface {
+	// Save persists state under key, overwriting any previous value.
+	Save(ctx context.Context, key string, state []byte) error
+	// Load returns the state previously saved under key, or
+	// ErrCheckpointNotFound if none exists.
+	Load(ctx context.Context, key string) ([]byte, error)
+	// Delete removes the state saved under key. Deleting a key that was
+	// never saved (or already deleted) is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryCheckpointStore is a CheckpointStore backed by an in-process map.
+// Checkpoints do not survive a process restart; it's useful for tests and
+// for callers that only need resumability within a single run.
+type MemoryCheckpointStore struct {
+	mu    sync.RWMutex
+	state map[string][]byte
+}
+
+// NewMemoryCheckpointStore creates an empty MemoryCheckpointStore.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{state: make(map[string][]byte)}
+}
+
+// Save implements CheckpointStore.
+func (s *MemoryCheckpointStore) Save(ctx context.Context, key string, state []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp := make([]byte, len(state))
+	copy(cp, state)
+	s.state[key] = cp
+	return nil
+}
+
+// Load implements CheckpointStore.
+func (s *MemoryCheckpointStore) Load(ctx context.Context, key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	state, ok := s.state[key]
+	if !ok {
+		return nil, ErrCheckpointNotFound
+	}
+	cp := make([]byte, len(state))
+	copy(cp, state)
+	return cp, nil
+}
+
+// Delete implements CheckpointStore.
+func (s *MemoryCheckpointStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.state, key)
+	return nil
+}
+
+// FileCheckpointStore is a CheckpointStore backed by one file per key
+// inside Dir, which must already exist.
+type FileCheckpointStore struct {
+	Dir string
+}
+
+// NewFileCheckpointStore creates a FileCheckpointStore rooted at dir.
+func NewFileCheckpointStore(dir string) *FileCheckpointStore {
+	return &FileCheckpointStore{Dir: dir}
+}
+
+// Save implements CheckpointStore. It writes to a temporary file first and
+// renames it into place so a crash mid-write can't leave a truncated
+// checkpoint behind.
+func (s *FileCheckpointStore) Save(ctx context.Context, key string, state []byte) error {
+	path := s.path(key)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, state, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Load implements CheckpointStore.
+func (s *FileCheckpointStore) Load(ctx context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrCheckpointNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+// Delete implements CheckpointStore.
+func (s *FileCheckpointStore) Delete(ctx context.Context, key string) error {
+	err := os.Remove(s.path(key))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// path returns the checkpoint file path for key, with path separators
+// replaced so a key can't escape Dir.
+func (s *FileCheckpointStore) path(key string) string {
+	safe := strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(key)
+	return filepath.Join(s.Dir, safe+".checkpoint")
+}
@@ -0,0 +1,85 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMemoryCheckpointStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryCheckpointStore()
+
+	if _, err := store.Load(ctx, "missing"); !errors.Is(err, ErrCheckpointNotFound) {
+		t.Fatalf("Load() on missing key error = %v, want ErrCheckpointNotFound", err)
+	}
+
+	if err := store.Save(ctx, "key", []byte("state-1")); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := store.Load(ctx, "key")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if string(got) != "state-1" {
+		t.Errorf("Load() = %q, want %q", got, "state-1")
+	}
+
+	if err := store.Save(ctx, "key", []byte("state-2")); err != nil {
+		t.Fatalf("Save() overwrite error = %v", err)
+	}
+	got, _ = store.Load(ctx, "key")
+	if string(got) != "state-2" {
+		t.Errorf("Load() after overwrite = %q, want %q", got, "state-2")
+	}
+
+	if err := store.Delete(ctx, "key"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Load(ctx, "key"); !errors.Is(err, ErrCheckpointNotFound) {
+		t.Fatalf("Load() after Delete() error = %v, want ErrCheckpointNotFound", err)
+	}
+
+	if err := store.Delete(ctx, "never-saved"); err != nil {
+		t.Errorf("Delete() on unknown key error = %v, want nil", err)
+	}
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileCheckpointStore(t.TempDir())
+
+	if _, err := store.Load(ctx, "missing"); !errors.Is(err, ErrCheckpointNotFound) {
+		t.Fatalf("Load() on missing key error = %v, want ErrCheckpointNotFound", err)
+	}
+
+	if err := store.Save(ctx, "export/uuid:123", []byte("state-1")); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := store.Load(ctx, "export/uuid:123")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if string(got) != "state-1" {
+		t.Errorf("Load() = %q, want %q", got, "state-1")
+	}
+
+	if err := store.Save(ctx, "export/uuid:123", []byte("state-2")); err != nil {
+		t.Fatalf("Save() overwrite error = %v", err)
+	}
+	got, _ = store.Load(ctx, "export/uuid:123")
+	if string(got) != "state-2" {
+		t.Errorf("Load() after overwrite = %q, want %q", got, "state-2")
+	}
+
+	if err := store.Delete(ctx, "export/uuid:123"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Load(ctx, "export/uuid:123"); !errors.Is(err, ErrCheckpointNotFound) {
+		t.Fatalf("Load() after Delete() error = %v, want ErrCheckpointNotFound", err)
+	}
+
+	if err := store.Delete(ctx, "never-saved"); err != nil {
+		t.Errorf("Delete() on unknown key error = %v, want nil", err)
+	}
+}
@@ -2,11 +2,15 @@ package base
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -40,6 +44,31 @@ type Client struct {
 	authMech    string
 	envBase     string
 	lastReqUUID string
+
+	limiter   RateLimiter
+	sem       chan struct{}
+	metrics   Metrics
+	transport http.RoundTripper
+
+	retryPolicy    RetryPolicy
+	retryPolicySet bool
+
+	tlsConfig *tls.Config
+	certErr   error
+
+	authProvider AuthProvider
+
+	// defaultPageTimeout, if set, is applied by iterator-returning
+	// endpoints as their base.WithPageTimeout[T] unless the caller passed
+	// their own IteratorOption overriding it.
+	defaultPageTimeout time.Duration
+
+	// Events is the bus Client itself (an AuthRefreshedEvent on every
+	// successful credential refresh after an expired-token 401) and
+	// embedding packages (e.g. tio's
+	// ExportsAPI, RemediationScansAPI, PluginsAPI) publish their
+	// strongly-typed domain events to. Always non-nil.
+	Events *EventBus
 }
 
 // ClientOption is a function that configures a Client.
@@ -56,6 +85,7 @@ func NewClient(envBase string, defaultURL string, opts ...ClientOption) (*Client
 		product: "unknown",
 		build:   "unknown",
 		envBase: envBase,
+		Events:  NewEventBus(),
 	}
 
 	// Apply options
@@ -84,40 +114,152 @@ func NewClient(envBase string, defaultURL string, opts ...ClientOption) (*Client
 		return nil, &ConnectionError{URL: "", Message: "no URL specified"}
 	}
 
+	if c.certErr != nil {
+		return nil, c.certErr
+	}
+
 	// Build user agent
 	c.userAgent = c.buildUserAgent()
 
+	// Resolve the retry policy: an explicit WithRetryPolicy wins, else
+	// fall back to the legacy WithRetries/WithBackoff flat options.
+	if !c.retryPolicySet {
+		c.retryPolicy = RetryPolicy{
+			MaxAttempts:    c.retries + 1,
+			InitialBackoff: c.backoff,
+			MaxBackoff:     30 * time.Second,
+			Multiplier:     2,
+			Jitter:         true,
+		}
+	}
+	if c.retryPolicy.RetryOn == nil {
+		c.retryPolicy.RetryOn = func(e *APIError) bool { return e.Retryable() }
+	}
+	retryCount := c.retryPolicy.MaxAttempts - 1
+	if retryCount < 0 {
+		retryCount = 0
+	}
+
 	// Initialize resty client
 	c.resty = resty.New().
 		SetBaseURL(c.baseURL).
 		SetTimeout(c.timeout).
-		SetRetryCount(c.retries).
-		SetRetryWaitTime(c.backoff).
-		SetRetryMaxWaitTime(30*time.Second).
+		SetRetryCount(retryCount).
+		SetRetryWaitTime(c.retryPolicy.InitialBackoff).
+		SetRetryMaxWaitTime(c.retryPolicy.MaxBackoff).
 		SetHeader("User-Agent", c.userAgent).
 		SetHeader("Accept", "application/json").
 		SetHeader("Content-Type", "application/json").
 		AddRetryCondition(func(r *resty.Response, err error) bool {
-			// Retry on 429 (rate limit) and 5xx errors
 			if err != nil {
 				return true
 			}
-			return r.StatusCode() == http.StatusTooManyRequests ||
-				(r.StatusCode() >= 500 && r.StatusCode() < 600)
+			if r.StatusCode() == http.StatusUnauthorized && c.authProvider != nil && isExpiredTokenError(c.classifyResponse(r)) {
+				if err := c.authProvider.Refresh(r.Request.Context()); err != nil {
+					return false
+				}
+				c.Events.Publish(AuthRefreshedEvent{Mechanism: fmt.Sprintf("%T", c.authProvider)})
+				return true
+			}
+			if r != nil && r.Request != nil {
+				method := r.Request.Method
+				if method != "" && !isIdempotentMethod(method) && !isRetryableCtx(r.Request.Context()) {
+					return false
+				}
+			}
+			if r.StatusCode() < 400 {
+				return false
+			}
+			return c.retryPolicy.RetryOn(c.classifyResponse(r))
+		}).
+		SetRetryAfter(func(_ *resty.Client, r *resty.Response) (time.Duration, error) {
+			if r.StatusCode() < 400 {
+				return 0, nil
+			}
+			apiErr := c.classifyResponse(r)
+			wait := apiErr.RetryAfter()
+			if wait > 0 {
+				if c.metrics.OnThrottle != nil {
+					c.metrics.OnThrottle(wait)
+				}
+			} else {
+				attempt := 1
+				if r.Request != nil {
+					attempt = r.Request.Attempt
+				}
+				wait = fullJitterBackoff(c.retryPolicy, attempt)
+			}
+			if r.StatusCode() == http.StatusTooManyRequests && c.metrics.OnRateLimited != nil {
+				c.metrics.OnRateLimited(wait)
+			}
+			return wait, nil
 		}).
-		OnAfterResponse(func(c *resty.Client, r *resty.Response) error {
+		OnBeforeRequest(func(_ *resty.Client, r *resty.Request) error {
+			if c.authProvider != nil {
+				if err := c.authProvider.Apply(r); err != nil {
+					return err
+				}
+			}
+			if r.Attempt > 1 && c.metrics.OnRetry != nil {
+				c.metrics.OnRetry(r.Attempt)
+			}
+			if c.sem != nil {
+				select {
+				case c.sem <- struct{}{}:
+				case <-r.Context().Done():
+					return r.Context().Err()
+				}
+			}
+			if c.limiter != nil {
+				if err := c.limiter.Wait(r.Context()); err != nil {
+					if c.sem != nil {
+						<-c.sem
+					}
+					return err
+				}
+				if tr, ok := c.limiter.(TokenReporter); ok && c.metrics.OnTokensAvailable != nil {
+					c.metrics.OnTokensAvailable(tr.Tokens())
+				}
+			}
+			if c.metrics.OnRequest != nil {
+				c.metrics.OnRequest()
+			}
+			return nil
+		}).
+		OnAfterResponse(func(rc *resty.Client, r *resty.Response) error {
+			if c.sem != nil {
+				<-c.sem
+			}
+			if c.limiter != nil {
+				throttled := r.StatusCode() == http.StatusTooManyRequests || r.StatusCode() == http.StatusServiceUnavailable
+				var retryAfter time.Duration
+				if throttled {
+					retryAfter = c.classifyResponse(r).RetryAfter()
+				}
+				c.limiter.OnResult(throttled, retryAfter)
+			}
 			// Store the request UUID for retry tracking
 			if reqUUID := r.Header().Get("X-Tio-Last-Request-Uuid"); reqUUID != "" {
-				c.SetHeader("X-Tio-Last-Request-Uuid", reqUUID)
+				rc.SetHeader("X-Tio-Last-Request-Uuid", reqUUID)
 			}
 			return nil
 		})
 
-	// Set up authentication if keys are provided
-	if c.accessKey != "" && c.secretKey != "" {
+	// Set up authentication: an explicit WithAuthProvider takes precedence
+	// over API keys, since it replaces the X-APIKeys header entirely.
+	if c.authProvider != nil {
+		c.authMech = "provider"
+	} else if c.accessKey != "" && c.secretKey != "" {
 		c.setAPIKeyAuth()
 	}
 
+	if c.transport == nil && c.tlsConfig != nil {
+		c.transport = &http.Transport{TLSClientConfig: c.tlsConfig}
+	}
+	if c.transport != nil {
+		c.resty.SetTransport(c.transport)
+	}
+
 	return c, nil
 }
 
@@ -151,6 +293,14 @@ func (c *Client) BaseURL() string {
 	return c.baseURL
 }
 
+// Metrics returns the observability hooks installed via WithMetrics (the
+// zero Metrics, with every hook nil, if none were), so other packages
+// built on top of Client (such as pkg/base/cache) can report through the
+// same telemetry surface instead of inventing their own.
+func (c *Client) Metrics() Metrics {
+	return c.metrics
+}
+
 // Request creates a new request with the given method and path.
 func (c *Client) Request(ctx context.Context) *resty.Request {
 	return c.resty.R().SetContext(ctx)
@@ -222,6 +372,23 @@ func (c *Client) Download(ctx context.Context, path string) ([]byte, error) {
 	return resp.Body(), nil
 }
 
+// DownloadStream performs a GET request and returns the response body as
+// an io.ReadCloser instead of buffering it into memory first, so callers
+// parsing large exports (e.g. a streaming CSV/NDJSON decoder) can bound
+// memory use to a single read buffer. The caller must Close the
+// returned ReadCloser.
+func (c *Client) DownloadStream(ctx context.Context, path string) (io.ReadCloser, error) {
+	resp, err := c.Request(ctx).SetDoNotParseResponse(true).Get(c.buildPath(path))
+	if err != nil {
+		return nil, &ConnectionError{URL: c.baseURL, Message: "request failed", Err: err}
+	}
+	if resp.StatusCode() < 200 || resp.StatusCode() >= 300 {
+		defer resp.RawBody().Close()
+		return nil, c.classifyResponse(resp)
+	}
+	return resp.RawBody(), nil
+}
+
 // buildPath constructs the full API path.
 func (c *Client) buildPath(path string) string {
 	if c.basePath != "" {
@@ -230,22 +397,36 @@ func (c *Client) buildPath(path string) string {
 	return path
 }
 
+// CheckResponse checks a raw resty response for errors. It is exported for
+// endpoint packages that issue requests directly via Request() (e.g. for
+// streaming or custom content types) instead of Get/Post/Put/Delete.
+func (c *Client) CheckResponse(resp *resty.Response) error {
+	return c.checkResponse(resp)
+}
+
 // checkResponse checks the response for errors.
 func (c *Client) checkResponse(resp *resty.Response) error {
 	if resp.StatusCode() >= 200 && resp.StatusCode() < 300 {
 		return nil
 	}
+	return c.classifyResponse(resp)
+}
 
+// classifyResponse decodes a non-2xx response into an *APIError, capturing
+// the request UUID, raw body, typed error code, and any Retry-After delay
+// so callers and the retry loop can make decisions without re-parsing.
+func (c *Client) classifyResponse(resp *resty.Response) *APIError {
 	apiErr := &APIError{
 		StatusCode: resp.StatusCode(),
 		RequestID:  resp.Header().Get("X-Request-Uuid"),
 		Response:   resp.Body(),
 	}
 
-	// Try to parse error message from response
+	// Try to parse the JSON error envelope Tenable.io documents.
 	var errResp struct {
-		Error   string `json:"error"`
-		Message string `json:"message"`
+		Error     string `json:"error"`
+		Message   string `json:"message"`
+		ErrorCode string `json:"error_code"`
 	}
 	if err := json.Unmarshal(resp.Body(), &errResp); err == nil {
 		if errResp.Error != "" {
@@ -253,20 +434,94 @@ func (c *Client) checkResponse(resp *resty.Response) error {
 		} else if errResp.Message != "" {
 			apiErr.Message = errResp.Message
 		}
+		if errResp.ErrorCode != "" {
+			apiErr.Code = ErrorCode(errResp.ErrorCode)
+		} else if errResp.Error != "" {
+			apiErr.Code = ErrorCode(errResp.Error)
+		}
 	}
 
 	if apiErr.Message == "" {
 		apiErr.Message = http.StatusText(resp.StatusCode())
 	}
 
+	if resp.StatusCode() == http.StatusTooManyRequests || resp.StatusCode() == http.StatusServiceUnavailable {
+		if retryAfter := resp.Header().Get("Retry-After"); retryAfter != "" {
+			apiErr.retryAfter = parseRetryAfter(retryAfter)
+		} else if resp.Header().Get("X-RateLimit-Remaining") == "0" {
+			apiErr.retryAfter = parseRateLimitReset(resp.Header().Get("X-RateLimit-Reset"))
+		}
+	}
+
 	return apiErr
 }
 
+// parseRetryAfter parses a Retry-After header value, which is either a
+// number of seconds or an HTTP-date.
+func parseRetryAfter(value string) time.Duration {
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// parseRateLimitReset parses an X-RateLimit-Reset header value into the
+// delay until the limit resets. Tenable sends this as a count of seconds
+// until reset; some deployments instead send a Unix timestamp, so a
+// value too large to plausibly be a delta is treated as one.
+func parseRateLimitReset(value string) time.Duration {
+	secs, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || secs <= 0 {
+		return 0
+	}
+	const epochThreshold = 1e9 // ~2001-09-09; any delta this large is implausible
+	if secs > epochThreshold {
+		if d := time.Until(time.Unix(secs, 0)); d > 0 {
+			return d
+		}
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
+
 // SetBasePath sets the base path for API requests.
 func (c *Client) SetBasePath(path string) {
 	c.basePath = path
 }
 
+// SetHeader sets a header sent with every request this Client makes.
+// Combined with Clone, this lets a caller derive a request-scoped
+// sub-client (e.g. tio's UsersAPI.ImpersonateClient) that carries an
+// extra header without mutating the original Client's requests.
+func (c *Client) SetHeader(key, value string) {
+	c.resty.SetHeader(key, value)
+}
+
+// DeleteHeader removes a header previously set with SetHeader.
+func (c *Client) DeleteHeader(key string) {
+	c.resty.Header.Del(key)
+}
+
+// Clone returns a new Client sharing c's transport, retry policy, rate
+// limiter, auth provider, and Events bus, but with its own resty header
+// set, so SetHeader/DeleteHeader on the clone never affect c. Used to
+// build request-scoped sub-clients, such as the one
+// tio.UsersAPI.ImpersonateClient returns.
+func (c *Client) Clone() *Client {
+	cc := *c
+	cc.resty = c.resty.Clone()
+	cc.resty.Header = c.resty.Header.Clone()
+	return &cc
+}
+
 // Resty returns the underlying resty client for advanced usage.
 func (c *Client) Resty() *resty.Client {
 	return c.resty
@@ -335,3 +590,170 @@ func WithBasePath(path string) ClientOption {
 		c.basePath = path
 	}
 }
+
+// WithRateLimiter installs a RateLimiter that every outgoing request
+// (including retries) must pass through before being sent. See
+// tio.WithRateLimit for the token-bucket RateLimiter most callers use.
+func WithRateLimiter(limiter RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// WithDefaultPageTimeout bounds every individual page fetch made by an
+// iterator-returning endpoint (PluginsAPI.List, RemediationScansAPI.List,
+// the Exports iterators, and others) to d, via base.WithPageTimeout[T]
+// applied automatically when the endpoint builds its Iterator. It has no
+// effect on an endpoint that was called with its own WithPageTimeout[T]
+// IteratorOption, which always wins. It's named distinctly from the
+// generic per-iterator WithPageTimeout[T] option (Go doesn't allow a
+// package-level non-generic function and generic function to share a
+// name) but serves the same purpose as a client-wide default instead of
+// a per-call override.
+func WithDefaultPageTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.defaultPageTimeout = d
+	}
+}
+
+// DefaultPageTimeout returns the per-page fetch timeout configured via
+// WithDefaultPageTimeout, or zero if none was set.
+func (c *Client) DefaultPageTimeout() time.Duration {
+	return c.defaultPageTimeout
+}
+
+// WithMaxConcurrency bounds the number of in-flight requests via a
+// weighted semaphore, so pagination fan-out and bulk operations can't
+// overwhelm the API even before the rate limiter kicks in.
+func WithMaxConcurrency(n int) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.sem = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithMetrics installs observability hooks invoked around each request.
+func WithMetrics(m Metrics) ClientOption {
+	return func(c *Client) {
+		c.metrics = m
+	}
+}
+
+// WithRetryPolicy replaces the client's default retry behavior (otherwise
+// derived from WithRetries/WithBackoff) with an explicit RetryPolicy
+// governing max attempts, backoff shape, and which errors are eligible
+// for retry.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+		c.retryPolicySet = true
+	}
+}
+
+// WithHTTPTransport overrides the http.RoundTripper the underlying resty
+// client uses to send requests. This is the seam pkg/testtransport plugs
+// into for hermetic record/replay testing.
+func WithHTTPTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.transport = transport
+	}
+}
+
+// WithAuthProvider installs an AuthProvider in place of WithAPIKeys, for
+// deployments authenticating via OAuth2, a pre-issued JWT, or Tenable's
+// username/password session-cookie flow instead of API keys. If both are
+// given, the AuthProvider wins and WithAPIKeys has no effect.
+func WithAuthProvider(provider AuthProvider) ClientOption {
+	return func(c *Client) {
+		c.authProvider = provider
+	}
+}
+
+// WithClientCertificate configures TLS client certificate (mTLS)
+// authentication from an in-memory PEM-encoded certificate and private
+// key, for Tenable.sc, Nessus Manager, or proxied Tenable.io deployments
+// that authenticate clients via TLS rather than (or in addition to) the
+// X-APIKeys header set by WithAPIKeys.
+func WithClientCertificate(certPEM, keyPEM []byte) ClientOption {
+	return func(c *Client) {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			c.certErr = &ValidationError{Field: "client certificate", Message: err.Error()}
+			return
+		}
+		c.ensureTLSConfig().Certificates = append(c.ensureTLSConfig().Certificates, cert)
+	}
+}
+
+// WithClientCertificateFile is like WithClientCertificate but loads the
+// certificate and private key from files on disk, optionally also loading
+// a custom CA bundle from caPath into the TLS config's RootCAs so the
+// client trusts a private or proxied Tenable deployment. Pass an empty
+// caPath to use the system's default trust store.
+func WithClientCertificateFile(certPath, keyPath, caPath string) ClientOption {
+	return func(c *Client) {
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			c.certErr = &ValidationError{Field: "client certificate", Message: err.Error()}
+			return
+		}
+		tlsConfig := c.ensureTLSConfig()
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+
+		if caPath == "" {
+			return
+		}
+		caPEM, err := os.ReadFile(caPath)
+		if err != nil {
+			c.certErr = &ValidationError{Field: "CA bundle", Message: err.Error()}
+			return
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			c.certErr = &ValidationError{Field: "CA bundle", Message: fmt.Sprintf("no certificates found in %s", caPath)}
+			return
+		}
+		tlsConfig.RootCAs = pool
+	}
+}
+
+// WithRootCAs sets the trust store used to verify the server's TLS
+// certificate, for on-prem Nessus Manager or Tenable.sc deployments behind
+// a private CA. Pass nil to fall back to the system trust store.
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return func(c *Client) {
+		c.ensureTLSConfig().RootCAs = pool
+	}
+}
+
+// WithInsecureSkipVerify disables TLS server certificate verification.
+// Intended only for test fixtures and local development against
+// self-signed deployments; never enable it against production endpoints.
+func WithInsecureSkipVerify(skip bool) ClientOption {
+	return func(c *Client) {
+		c.ensureTLSConfig().InsecureSkipVerify = skip
+	}
+}
+
+// ensureTLSConfig lazily creates the Client's tls.Config so
+// WithClientCertificate and WithClientCertificateFile can be combined and
+// applied in either order.
+func (c *Client) ensureTLSConfig() *tls.Config {
+	if c.tlsConfig == nil {
+		c.tlsConfig = &tls.Config{}
+	}
+	return c.tlsConfig
+}
+
+// ClientCertificate returns the first TLS client certificate configured via
+// WithClientCertificate or WithClientCertificateFile, and whether one was
+// configured at all. Callers use it to cross-check the certificate's
+// subject against server-reported identity, e.g.
+// SessionAPI.LoginWithCertificate.
+func (c *Client) ClientCertificate() (*tls.Certificate, bool) {
+	if c.tlsConfig == nil || len(c.tlsConfig.Certificates) == 0 {
+		return nil, false
+	}
+	return &c.tlsConfig.Certificates[0], true
+}
@@ -2,9 +2,19 @@ package base
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 	"time"
 )
@@ -189,3 +199,151 @@ func TestAPIKeyAuth(t *testing.T) {
 	}
 }
 
+// generateTestCertificate returns a self-signed certificate/key pair, PEM
+// encoded, suitable as both a TLS server certificate and a client
+// certificate in tests.
+func generateTestCertificate(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "gotenable-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	keyBytes, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
+
+	return certPEM, keyPEM
+}
+
+// newMTLSTestServer starts an httptest TLS server that presents cert and
+// requires the client to present a certificate trusted by the same cert,
+// recording whether one was seen.
+func newMTLSTestServer(t *testing.T, cert tls.Certificate, pool *x509.CertPool, presented *bool) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*presented = r.TLS != nil && len(r.TLS.PeerCertificates) > 0
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	}
+	server.StartTLS()
+	return server
+}
+
+func TestWithClientCertificate(t *testing.T) {
+	certPEM, keyPEM := generateTestCertificate(t)
+
+	client, err := NewClient("TEST", "https://example.com", WithClientCertificate(certPEM, keyPEM))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	transport, ok := client.Resty().GetClient().Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected *http.Transport, got %T", client.Resty().GetClient().Transport)
+	}
+	if transport.TLSClientConfig == nil || len(transport.TLSClientConfig.Certificates) != 1 {
+		t.Fatal("Expected the client certificate to be installed on the transport's TLS config")
+	}
+}
+
+func TestWithClientCertificate_invalidPair(t *testing.T) {
+	_, err := NewClient("TEST", "https://example.com",
+		WithClientCertificate([]byte("not a cert"), []byte("not a key")),
+	)
+	if err == nil {
+		t.Fatal("Expected an error for an invalid certificate/key pair")
+	}
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected *ValidationError, got %T", err)
+	}
+}
+
+func TestWithClientCertificateFile(t *testing.T) {
+	certPEM, keyPEM := generateTestCertificate(t)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("failed to parse generated certificate: %v", err)
+	}
+
+	dir := t.TempDir()
+	certPath := dir + "/client.crt"
+	keyPath := dir + "/client.key"
+	caPath := dir + "/ca.crt"
+	if err := os.WriteFile(certPath, certPEM, 0o600); err != nil {
+		t.Fatalf("failed to write cert file: %v", err)
+	}
+	if err := os.WriteFile(keyPath, keyPEM, 0o600); err != nil {
+		t.Fatalf("failed to write key file: %v", err)
+	}
+	if err := os.WriteFile(caPath, certPEM, 0o600); err != nil {
+		t.Fatalf("failed to write CA file: %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(certPEM)
+
+	var presented bool
+	server := newMTLSTestServer(t, cert, pool, &presented)
+	defer server.Close()
+
+	client, err := NewClient("TEST", server.URL,
+		WithClientCertificateFile(certPath, keyPath, caPath),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	var result struct {
+		Status string `json:"status"`
+	}
+	if _, err := client.Get(ctx, "/test", &result); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if !presented {
+		t.Error("Expected server to see a client certificate")
+	}
+}
+
+func TestWithClientCertificateFile_missing(t *testing.T) {
+	_, err := NewClient("TEST", "https://example.com",
+		WithClientCertificateFile("/no/such/cert.pem", "/no/such/key.pem", ""),
+	)
+	if err == nil {
+		t.Fatal("Expected an error for a missing certificate file")
+	}
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected *ValidationError, got %T", err)
+	}
+}
+
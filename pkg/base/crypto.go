@@ -0,0 +1,149 @@
+package base
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+)
+
+const (
+	chunkCipherSaltSize   = 16
+	chunkCipherKeySize    = 32 // AES-256
+	chunkCipherIterations = 100_000
+)
+
+// ChunkCipher encrypts and decrypts small blobs — export chunk bodies, or
+// a checkpointed ExportStatus — before they touch disk.
+type ChunkCipher interface {
+	// Encrypt returns ciphertext that Decrypt can reverse. Two calls with
+	// the same plaintext are not required to produce the same output.
+	Encrypt(plaintext []byte) ([]byte, error)
+	// Decrypt reverses Encrypt, returning an error if ciphertext was
+	// tampered with or wasn't produced by a matching cipher.
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// AESGCMCipher is a ChunkCipher that derives an AES-256 key from a
+// passphrase with PBKDF2-HMAC-SHA256 (100,000 iterations) and a random
+// salt generated fresh on every Encrypt call, then seals the plaintext
+// with AES-256-GCM. Encrypt's output is
+// salt(16) || nonce(12) || ciphertext+tag; Decrypt parses that layout back
+// out to re-derive the matching key and open the seal.
+type AESGCMCipher struct {
+	passphrase []byte
+}
+
+// NewAESGCMCipher creates an AESGCMCipher keyed by passphrase. The
+// passphrase itself never appears in the output; only the per-call salt
+// needed to re-derive its key travels with the ciphertext.
+func NewAESGCMCipher(passphrase string) *AESGCMCipher {
+	return &AESGCMCipher{passphrase: []byte(passphrase)}
+}
+
+// Encrypt implements ChunkCipher.
+func (c *AESGCMCipher) Encrypt(plaintext []byte) ([]byte, error) {
+	salt := make([]byte, chunkCipherSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+
+	gcm, err := c.gcm(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	out := make([]byte, 0, len(salt)+len(nonce)+len(plaintext)+gcm.Overhead())
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, plaintext, nil), nil
+}
+
+// Decrypt implements ChunkCipher.
+func (c *AESGCMCipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < chunkCipherSaltSize {
+		return nil, fmt.Errorf("ciphertext too short to contain a salt")
+	}
+	salt := ciphertext[:chunkCipherSaltSize]
+
+	gcm, err := c.gcm(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	rest := ciphertext[chunkCipherSaltSize:]
+	if len(rest) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short to contain a nonce")
+	}
+	nonce, sealed := rest[:gcm.NonceSize()], rest[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt: %w", err)
+	}
+	return plaintext, nil
+}
+
+// gcm derives the AES-256-GCM AEAD keyed from c.passphrase and salt.
+func (c *AESGCMCipher) gcm(salt []byte) (cipher.AEAD, error) {
+	key := pbkdf2Key(c.passphrase, salt, chunkCipherIterations, chunkCipherKeySize, sha256.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create AES cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// pbkdf2Key derives a keyLen-byte key from password and salt using PBKDF2
+// (RFC 8018) with iter iterations of the HMAC built from h.
+func pbkdf2Key(password, salt []byte, iter, keyLen int, h func() hash.Hash) []byte {
+	prf := hmac.New(h, password)
+	hashLen := prf.Size()
+	numBlocks := (keyLen + hashLen - 1) / hashLen
+
+	var counter [4]byte
+	dk := make([]byte, 0, numBlocks*hashLen)
+	for block := 1; block <= numBlocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(counter[:], uint32(block))
+		prf.Write(counter[:])
+		u := prf.Sum(nil)
+
+		t := make([]byte, len(u))
+		copy(t, u)
+		for i := 1; i < iter; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		dk = append(dk, t...)
+	}
+	return dk[:keyLen]
+}
+
+// EncryptData encrypts data with a key derived from passphrase, using the
+// same scheme as AESGCMCipher. It's the package-level entry point for
+// sealing one-off blobs — such as a checkpointed ExportStatus — that
+// don't warrant constructing a ChunkCipher of their own.
+func EncryptData(passphrase string, data []byte) ([]byte, error) {
+	return NewAESGCMCipher(passphrase).Encrypt(data)
+}
+
+// DecryptData reverses EncryptData.
+func DecryptData(passphrase string, data []byte) ([]byte, error) {
+	return NewAESGCMCipher(passphrase).Decrypt(data)
+}
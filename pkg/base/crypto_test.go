@@ -0,0 +1,82 @@
+package base
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAESGCMCipherRoundTrip(t *testing.T) {
+	c := NewAESGCMCipher("correct horse battery staple")
+	plaintext := []byte("sensitive export chunk data")
+
+	ciphertext, err := c.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if bytes.Contains(ciphertext, plaintext) {
+		t.Fatal("ciphertext contains the plaintext verbatim")
+	}
+
+	got, err := c.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("Decrypt() = %q, want %q", got, plaintext)
+	}
+}
+
+func TestAESGCMCipherDistinctSalts(t *testing.T) {
+	c := NewAESGCMCipher("passphrase")
+	plaintext := []byte("same plaintext every time")
+
+	a, err := c.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	b, err := c.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if bytes.Equal(a, b) {
+		t.Error("two Encrypt() calls on the same plaintext produced identical ciphertext")
+	}
+}
+
+func TestAESGCMCipherWrongPassphrase(t *testing.T) {
+	ciphertext, err := NewAESGCMCipher("correct").Encrypt([]byte("secret"))
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if _, err := NewAESGCMCipher("incorrect").Decrypt(ciphertext); err == nil {
+		t.Fatal("Decrypt() with the wrong passphrase succeeded")
+	}
+}
+
+func TestAESGCMCipherTamperedCiphertext(t *testing.T) {
+	ciphertext, err := NewAESGCMCipher("passphrase").Encrypt([]byte("secret"))
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	ciphertext[len(ciphertext)-1] ^= 0xFF
+
+	if _, err := NewAESGCMCipher("passphrase").Decrypt(ciphertext); err == nil {
+		t.Fatal("Decrypt() of tampered ciphertext succeeded")
+	}
+}
+
+func TestEncryptDecryptData(t *testing.T) {
+	plaintext := []byte(`{"status":"FINISHED","chunks_available":[1,2,3]}`)
+
+	ciphertext, err := EncryptData("passphrase", plaintext)
+	if err != nil {
+		t.Fatalf("EncryptData() error = %v", err)
+	}
+	got, err := DecryptData("passphrase", ciphertext)
+	if err != nil {
+		t.Fatalf("DecryptData() error = %v", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("DecryptData() = %q, want %q", got, plaintext)
+	}
+}
@@ -0,0 +1,17 @@
+package base
+
+import (
+	"context"
+	"time"
+)
+
+// WithDeadline derives ctx with a deadline at t and returns it along with
+// its CancelFunc, as a first-class alternative to the coarse WithTimeout
+// client option. Pass the returned context into Client.Get/Post/... (or
+// ExportsAPI.WaitForExport): once t is reached, or cancel is called, every
+// consumer reading that context's Done() channel — the retry loop's
+// backoff wait and a long poll loop alike — unblocks immediately instead
+// of finishing whatever wait is currently in flight.
+func WithDeadline(ctx context.Context, t time.Time) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(ctx, t)
+}
@@ -0,0 +1,28 @@
+package base
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithDeadline(t *testing.T) {
+	ctx, cancel := WithDeadline(context.Background(), time.Now().Add(10*time.Millisecond))
+	defer cancel()
+
+	select {
+	case <-ctx.Done():
+		t.Fatal("context should not be done before its deadline")
+	default:
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("context was not done after its deadline elapsed")
+	}
+
+	if ctx.Err() != context.DeadlineExceeded {
+		t.Errorf("ctx.Err() = %v, want context.DeadlineExceeded", ctx.Err())
+	}
+}
@@ -2,8 +2,43 @@
 package base
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
+)
+
+// Sentinel errors for use with errors.Is. Each concrete error type below
+// implements Is(target error) bool so errors.Is(err, base.ErrNotFound)
+// works against a wrapped *APIError, and so two instances of the same
+// concrete error type match each other even when their fields differ.
+var (
+	ErrNotFound      = errors.New("tenable: not found")
+	ErrUnauthorized  = errors.New("tenable: unauthorized")
+	ErrForbidden     = errors.New("tenable: forbidden")
+	ErrRateLimited   = errors.New("tenable: rate limited")
+	ErrServerError   = errors.New("tenable: server error")
+	ErrExportTimeout = errors.New("tenable: export timed out")
+	ErrScanTimeout   = errors.New("tenable: scan wait timed out")
+	ErrValidation    = errors.New("tenable: validation failed")
+)
+
+// ErrorCode identifies a well-known Tenable.io error condition, as
+// documented in the API's error payloads.
+type ErrorCode string
+
+// Well-known Tenable.io error codes. ErrCodeUnknown is left when the
+// response body carries neither an "error_code" nor an "error" field; any
+// other value of those fields is kept as the code, listed here or not.
+const (
+	ErrCodeUnknown            ErrorCode = ""
+	ErrCodeInvalidCredentials ErrorCode = "invalid_credentials"
+	ErrCodeQuotaExceeded      ErrorCode = "quota_exceeded"
+	ErrCodeScanNotFound       ErrorCode = "scan_not_found"
+	ErrCodeAssetNotFound      ErrorCode = "asset_not_found"
+	ErrCodePermissionDenied   ErrorCode = "permission_denied"
+	ErrCodeValidationFailed   ErrorCode = "validation_failed"
+	ErrCodeTokenExpired       ErrorCode = "token_expired"
 )
 
 // APIError represents an error returned by the Tenable API.
@@ -12,6 +47,15 @@ type APIError struct {
 	Message    string
 	RequestID  string
 	Response   []byte
+	// Code is the typed error condition parsed from the response body's
+	// "error" or "error_code" field, if present.
+	Code ErrorCode
+	// retryAfter is the delay to honor before retrying, parsed on 429/503
+	// responses from the Retry-After header, or from X-RateLimit-Reset
+	// when Retry-After is absent and X-RateLimit-Remaining is "0".
+	retryAfter time.Duration
+	// Err is an optional underlying cause, surfaced via Unwrap.
+	Err error
 }
 
 // Error implements the error interface.
@@ -22,6 +66,26 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("tenable API error (status %d): %s", e.StatusCode, e.Message)
 }
 
+// Temporary returns true if retrying the same request later might succeed.
+func (e *APIError) Temporary() bool {
+	return e.IsRateLimited() || e.IsServerError()
+}
+
+// Retryable is an alias for Temporary, named to match the SDK's retry
+// vocabulary used by the client's backoff logic.
+func (e *APIError) Retryable() bool {
+	return e.Temporary()
+}
+
+// RetryAfter returns the delay the server asked callers to wait before
+// retrying, as parsed from the Retry-After header or, when that is absent
+// and X-RateLimit-Remaining is "0", from X-RateLimit-Reset. It is zero for
+// statuses other than 429 and 503, and when neither header gave a usable
+// delay.
+func (e *APIError) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
 // IsNotFound returns true if the error is a 404 Not Found error.
 func (e *APIError) IsNotFound() bool {
 	return e.StatusCode == http.StatusNotFound
@@ -47,6 +111,31 @@ func (e *APIError) IsServerError() bool {
 	return e.StatusCode >= 500 && e.StatusCode < 600
 }
 
+// Unwrap returns the underlying cause, if any.
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is supports errors.Is(err, base.ErrNotFound) (and the other status
+// sentinels) against a wrapped *APIError, and matches any other *APIError
+// regardless of its fields.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.IsNotFound()
+	case ErrUnauthorized:
+		return e.IsUnauthorized()
+	case ErrForbidden:
+		return e.IsForbidden()
+	case ErrRateLimited:
+		return e.IsRateLimited()
+	case ErrServerError:
+		return e.IsServerError()
+	}
+	_, ok := target.(*APIError)
+	return ok
+}
+
 // AuthenticationError represents an authentication failure.
 type AuthenticationError struct {
 	Message string
@@ -57,6 +146,16 @@ func (e *AuthenticationError) Error() string {
 	return fmt.Sprintf("authentication error: %s", e.Message)
 }
 
+// Is supports errors.Is(err, base.ErrUnauthorized) and matches any other
+// *AuthenticationError.
+func (e *AuthenticationError) Is(target error) bool {
+	if target == ErrUnauthorized {
+		return true
+	}
+	_, ok := target.(*AuthenticationError)
+	return ok
+}
+
 // ConnectionError represents a connection failure.
 type ConnectionError struct {
 	URL     string
@@ -77,6 +176,12 @@ func (e *ConnectionError) Unwrap() error {
 	return e.Err
 }
 
+// Is matches any other *ConnectionError.
+func (e *ConnectionError) Is(target error) bool {
+	_, ok := target.(*ConnectionError)
+	return ok
+}
+
 // ValidationError represents a validation failure for input parameters.
 type ValidationError struct {
 	Field   string
@@ -88,6 +193,16 @@ func (e *ValidationError) Error() string {
 	return fmt.Sprintf("validation error for %s: %s", e.Field, e.Message)
 }
 
+// Is supports errors.Is(err, base.ErrValidation) and matches any other
+// *ValidationError.
+func (e *ValidationError) Is(target error) bool {
+	if target == ErrValidation {
+		return true
+	}
+	_, ok := target.(*ValidationError)
+	return ok
+}
+
 // FileDownloadError represents a failure to download a file.
 type FileDownloadError struct {
 	Resource   string
@@ -107,6 +222,12 @@ func (e *FileDownloadError) Unwrap() error {
 	return e.Err
 }
 
+// Is matches any other *FileDownloadError.
+func (e *FileDownloadError) Is(target error) bool {
+	_, ok := target.(*FileDownloadError)
+	return ok
+}
+
 // ExportError represents an error during export operations.
 type ExportError struct {
 	ExportType string
@@ -119,6 +240,12 @@ func (e *ExportError) Error() string {
 	return fmt.Sprintf("%s export %s error: %s", e.ExportType, e.UUID, e.Message)
 }
 
+// Is matches any other *ExportError.
+func (e *ExportError) Is(target error) bool {
+	_, ok := target.(*ExportError)
+	return ok
+}
+
 // ExportTimeoutError represents a timeout during export operations.
 type ExportTimeoutError struct {
 	ExportType string
@@ -129,3 +256,38 @@ type ExportTimeoutError struct {
 func (e *ExportTimeoutError) Error() string {
 	return fmt.Sprintf("%s export %s has timed out", e.ExportType, e.UUID)
 }
+
+// Is supports errors.Is(err, base.ErrExportTimeout) and matches any other
+// *ExportTimeoutError.
+func (e *ExportTimeoutError) Is(target error) bool {
+	if target == ErrExportTimeout {
+		return true
+	}
+	_, ok := target.(*ExportTimeoutError)
+	return ok
+}
+
+// ScanTimeoutError represents a scan-lifecycle wait (ScansAPI.Wait or
+// WaitForExport) that never observed a terminal state before ctx ended
+// or the configured deadline elapsed. It's distinct from
+// ExportTimeoutError since a scan run and a scan's file export are
+// different long-running operations with different terminal states.
+type ScanTimeoutError struct {
+	ScanID int
+	Status string
+}
+
+// Error implements the error interface.
+func (e *ScanTimeoutError) Error() string {
+	return fmt.Sprintf("scan %d wait timed out, last observed status %q", e.ScanID, e.Status)
+}
+
+// Is supports errors.Is(err, base.ErrScanTimeout) and matches any other
+// *ScanTimeoutError.
+func (e *ScanTimeoutError) Is(target error) bool {
+	if target == ErrScanTimeout {
+		return true
+	}
+	_, ok := target.(*ScanTimeoutError)
+	return ok
+}
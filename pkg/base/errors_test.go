@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestAPIError(t *testing.T) {
@@ -140,6 +141,39 @@ func TestExportError(t *testing.T) {
 	}
 }
 
+func TestAPIErrorRetryClassification(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       *APIError
+		temporary bool
+	}{
+		{"rate limited", &APIError{StatusCode: http.StatusTooManyRequests}, true},
+		{"server error", &APIError{StatusCode: http.StatusInternalServerError}, true},
+		{"not found", &APIError{StatusCode: http.StatusNotFound}, false},
+		{"bad request", &APIError{StatusCode: http.StatusBadRequest}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Temporary(); got != tt.temporary {
+				t.Errorf("Temporary() = %v, want %v", got, tt.temporary)
+			}
+			if got := tt.err.Retryable(); got != tt.temporary {
+				t.Errorf("Retryable() = %v, want %v", got, tt.temporary)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter("5"); got != 5*time.Second {
+		t.Errorf("parseRetryAfter(\"5\") = %v, want 5s", got)
+	}
+	if got := parseRetryAfter("not-a-number-or-date"); got != 0 {
+		t.Errorf("parseRetryAfter(invalid) = %v, want 0", got)
+	}
+}
+
 func TestExportTimeoutError(t *testing.T) {
 	err := &ExportTimeoutError{
 		ExportType: "vulns",
@@ -151,4 +185,3 @@ func TestExportTimeoutError(t *testing.T) {
 		t.Errorf("Error() = %v, want %v", got, want)
 	}
 }
-
@@ -0,0 +1,146 @@
+package base
+
+import "sync"
+
+// Event is implemented by every event type an EventBus can publish.
+// Concrete event types (e.g. tio's ExportStartedEvent or
+// AuthRefreshedEvent below) carry their own strongly-typed fields;
+// EventType lets subscribers filter by kind without a type switch or
+// unmarshaling a generic payload, the same pattern tio.ScannerEvent and
+// tio.AssetEvent already use for their own, narrower subscriptions.
+type Event interface {
+	EventType() string
+}
+
+// EventFilter reports whether an EventBus subscriber wants to receive
+// ev. A nil EventFilter matches every event.
+type EventFilter func(ev Event) bool
+
+// EventBackpressure controls what happens when a subscriber's channel is
+// full and it isn't keeping up, mirroring tio.WatchBackpressure.
+type EventBackpressure int
+
+const (
+	// EventBlock makes Publish block on a full subscriber channel until
+	// the subscriber drains it. No events are lost, but a slow
+	// subscriber delays delivery to every other subscriber too, since
+	// Publish delivers synchronously.
+	EventBlock EventBackpressure = iota
+	// EventDropOldest discards the oldest buffered, undelivered event to
+	// make room for the newest one, trading completeness for freshness.
+	EventDropOldest
+)
+
+// SubscribeOption configures an EventBus.Subscribe call.
+type SubscribeOption func(*eventSubscriber)
+
+// WithEventBufferSize sets the subscriber channel's capacity. The
+// default is 64.
+func WithEventBufferSize(n int) SubscribeOption {
+	return func(s *eventSubscriber) {
+		if n > 0 {
+			s.ch = make(chan Event, n)
+		}
+	}
+}
+
+// WithEventBackpressure sets what happens when the subscriber channel is
+// full. The default is EventBlock.
+func WithEventBackpressure(b EventBackpressure) SubscribeOption {
+	return func(s *eventSubscriber) {
+		s.backpressure = b
+	}
+}
+
+// eventSubscriber is one EventBus.Subscribe registration.
+type eventSubscriber struct {
+	ch           chan Event
+	filter       EventFilter
+	backpressure EventBackpressure
+}
+
+// EventBus fans strongly-typed Events out to subscribers, each with its
+// own filter and backpressure policy. Use NewEventBus; the zero value
+// has no subscriber map.
+type EventBus struct {
+	mu          sync.Mutex
+	subscribers map[<-chan Event]*eventSubscriber
+}
+
+// NewEventBus returns an empty EventBus.
+func NewEventBus() *EventBus {
+	return &EventBus{subscribers: make(map[<-chan Event]*eventSubscriber)}
+}
+
+// Subscribe registers a new subscriber and returns the channel Events
+// matching filter (or every event, if filter is nil) are delivered on.
+// The channel is never closed by EventBus, matching
+// tio.ScannerMonitor.Subscribe; call Unsubscribe when done listening, or
+// an EventBlock subscriber that stops reading can stall Publish for
+// every other subscriber.
+func (b *EventBus) Subscribe(filter EventFilter, opts ...SubscribeOption) <-chan Event {
+	sub := &eventSubscriber{ch: make(chan Event, 64), filter: filter}
+	for _, opt := range opts {
+		opt(sub)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[sub.ch] = sub
+	return sub.ch
+}
+
+// Unsubscribe stops delivery to a channel returned by Subscribe.
+func (b *EventBus) Unsubscribe(ch <-chan Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.subscribers, ch)
+}
+
+// Publish delivers ev to every subscriber whose filter matches it (or
+// every subscriber, if it registered with a nil filter), according to
+// each subscriber's backpressure policy.
+func (b *EventBus) Publish(ev Event) {
+	b.mu.Lock()
+	subs := make([]*eventSubscriber, 0, len(b.subscribers))
+	for _, sub := range b.subscribers {
+		subs = append(subs, sub)
+	}
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		if sub.filter != nil && !sub.filter(ev) {
+			continue
+		}
+		if sub.backpressure == EventDropOldest {
+			select {
+			case sub.ch <- ev:
+			default:
+				select {
+				case <-sub.ch:
+				default:
+				}
+				select {
+				case sub.ch <- ev:
+				default:
+				}
+			}
+			continue
+		}
+		sub.ch <- ev
+	}
+}
+
+// AuthRefreshedEvent is published on Client.Events when a 401 response
+// triggers a successful AuthProvider.Refresh. It is not published for a
+// provider's own proactive refresh (e.g. JWTProvider re-signing before
+// expiry in Apply), since that path has no access to the owning
+// Client's EventBus.
+type AuthRefreshedEvent struct {
+	// Mechanism is the concrete AuthProvider type that refreshed, e.g.
+	// "*base.JWTProvider".
+	Mechanism string
+}
+
+// EventType implements Event.
+func (AuthRefreshedEvent) EventType() string { return "auth.refreshed" }
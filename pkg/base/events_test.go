@@ -0,0 +1,108 @@
+package base
+
+import (
+	"testing"
+	"time"
+)
+
+type testEvent struct{ kind string }
+
+func (e testEvent) EventType() string { return e.kind }
+
+func TestEventBusPublishSubscribe(t *testing.T) {
+	bus := NewEventBus()
+	ch := bus.Subscribe(nil)
+	bus.Publish(testEvent{"a"})
+
+	select {
+	case ev := <-ch:
+		if ev.EventType() != "a" {
+			t.Fatalf("got %v, want a", ev.EventType())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+}
+
+func TestEventBusFilter(t *testing.T) {
+	bus := NewEventBus()
+	ch := bus.Subscribe(func(ev Event) bool { return ev.EventType() == "wanted" })
+	bus.Publish(testEvent{"ignored"})
+	bus.Publish(testEvent{"wanted"})
+
+	select {
+	case ev := <-ch:
+		if ev.EventType() != "wanted" {
+			t.Fatalf("got %v, want wanted", ev.EventType())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out")
+	}
+
+	select {
+	case ev := <-ch:
+		t.Fatalf("unexpected second delivery: %v", ev.EventType())
+	default:
+	}
+}
+
+func TestEventBusUnsubscribe(t *testing.T) {
+	bus := NewEventBus()
+	ch := bus.Subscribe(nil, WithEventBufferSize(1))
+	bus.Unsubscribe(ch)
+	bus.Publish(testEvent{"a"})
+
+	select {
+	case ev, ok := <-ch:
+		if ok {
+			t.Fatalf("expected no delivery after Unsubscribe, got %v", ev.EventType())
+		}
+	default:
+	}
+}
+
+func TestEventBusDropOldest(t *testing.T) {
+	bus := NewEventBus()
+	ch := bus.Subscribe(nil, WithEventBufferSize(2), WithEventBackpressure(EventDropOldest))
+	bus.Publish(testEvent{"1"})
+	bus.Publish(testEvent{"2"})
+	bus.Publish(testEvent{"3"})
+
+	var got []string
+	for i := 0; i < 2; i++ {
+		select {
+		case ev := <-ch:
+			got = append(got, ev.EventType())
+		case <-time.After(time.Second):
+			t.Fatal("timed out")
+		}
+	}
+	if got[0] != "2" || got[1] != "3" {
+		t.Fatalf("got %v, want [2 3] (oldest dropped)", got)
+	}
+}
+
+func TestEventBusBlockDeliversInOrder(t *testing.T) {
+	bus := NewEventBus()
+	ch := bus.Subscribe(nil, WithEventBufferSize(1))
+	done := make(chan struct{})
+	go func() {
+		bus.Publish(testEvent{"1"})
+		bus.Publish(testEvent{"2"})
+		close(done)
+	}()
+
+	var got []string
+	for len(got) < 2 {
+		select {
+		case ev := <-ch:
+			got = append(got, ev.EventType())
+		case <-time.After(time.Second):
+			t.Fatal("timed out")
+		}
+	}
+	<-done
+	if got[0] != "1" || got[1] != "2" {
+		t.Fatalf("got %v, want [1 2]", got)
+	}
+}
@@ -0,0 +1,350 @@
+// Package filter provides a typed, composable expression builder for
+// Tenable's two filter dialects: the numbered "f.N.field/operator/value"
+// query parameters used by endpoints like TagsAPI.ListValues, and the
+// ODATA-inspired "$filter" string used by the assets endpoints. Building
+// one Expr and rendering it either way (Params or ODataFilter) means
+// callers don't hand-assemble either format themselves, and don't end up
+// with two filters that silently drift out of sync.
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Expr is an immutable filter expression tree. Build one with Eq, Ne,
+// Gt, Lt, Ge, Le, In, Contains, or StartsWith, combine expressions with
+// And/Or, and negate with Not.
+type Expr struct {
+	node node
+}
+
+// node is the unexported interface every expression tree node satisfies.
+type node interface {
+	validate() error
+	odata(sb *strings.Builder)
+	// leaves appends this node's leaf comparisons to params, returning an
+	// error if the node (or any descendant) isn't expressible as the
+	// numbered params format, which only supports a flat conjunction of
+	// leaf comparisons — no Or, Not, or nested groups.
+	leaves(params *[]Param) error
+	tree() Tree
+}
+
+// Param is one "f.N.field/operator/value" triple, as ListValues and
+// similar endpoints expect, numbered by its position in the slice
+// returned from Expr.Params.
+type Param struct {
+	Field    string
+	Operator string
+	Value    string
+}
+
+// leafNode is a single field/operator/value(s) comparison.
+type leafNode struct {
+	field    string
+	operator string
+	values   []interface{}
+}
+
+// unaryNode is a "not" applied to another node.
+type unaryNode struct {
+	operand node
+}
+
+// binaryNode is an "and"/"or" combination of two nodes.
+type binaryNode struct {
+	op          string // "and" or "or"
+	left, right node
+}
+
+// Operator tokens used in both the numbered-params and ODATA renderings.
+const (
+	opEq         = "eq"
+	opNe         = "ne"
+	opGt         = "gt"
+	opLt         = "lt"
+	opGe         = "ge"
+	opLe         = "le"
+	opIn         = "in"
+	opContains   = "contains"
+	opStartsWith = "starts-with"
+)
+
+// Eq builds a "field equals value" comparison.
+func Eq(field string, value interface{}) Expr { return leaf(field, opEq, value) }
+
+// Ne builds a "field not equal to value" comparison.
+func Ne(field string, value interface{}) Expr { return leaf(field, opNe, value) }
+
+// Gt builds a "field greater than value" comparison.
+func Gt(field string, value interface{}) Expr { return leaf(field, opGt, value) }
+
+// Lt builds a "field less than value" comparison.
+func Lt(field string, value interface{}) Expr { return leaf(field, opLt, value) }
+
+// Ge builds a "field greater than or equal to value" comparison.
+func Ge(field string, value interface{}) Expr { return leaf(field, opGe, value) }
+
+// Le builds a "field less than or equal to value" comparison.
+func Le(field string, value interface{}) Expr { return leaf(field, opLe, value) }
+
+// In builds a "field is one of values" comparison.
+func In(field string, values ...interface{}) Expr {
+	return Expr{node: leafNode{field: field, operator: opIn, values: values}}
+}
+
+// Contains builds a substring match comparison, rendered as an ODATA
+// contains(field,'value') call and, for the numbered-params format, a
+// "contains" operator.
+func Contains(field string, value interface{}) Expr { return leaf(field, opContains, value) }
+
+// StartsWith builds a prefix match comparison, rendered as an ODATA
+// startswith(field,'value') call and, for the numbered-params format, a
+// "starts-with" operator.
+func StartsWith(field string, value interface{}) Expr { return leaf(field, opStartsWith, value) }
+
+func leaf(field, operator string, value interface{}) Expr {
+	return Expr{node: leafNode{field: field, operator: operator, values: []interface{}{value}}}
+}
+
+// And combines e with other, requiring both to hold.
+func (e Expr) And(other Expr) Expr {
+	return Expr{node: binaryNode{op: "and", left: e.node, right: other.node}}
+}
+
+// Or combines e with other, requiring either to hold.
+func (e Expr) Or(other Expr) Expr {
+	return Expr{node: binaryNode{op: "or", left: e.node, right: other.node}}
+}
+
+// Not negates e.
+func Not(e Expr) Expr {
+	return Expr{node: unaryNode{operand: e.node}}
+}
+
+// IsZero reports whether e was never assigned an expression, so callers
+// can treat an unset filter as "no filter" without a separate bool flag.
+func (e Expr) IsZero() bool { return e.node == nil }
+
+// Validate checks e (and every subexpression) for structural problems:
+// empty field names, a comparison with no values, or an "in" with no
+// candidates. It does not check that params-rendering is possible — call
+// Params and check its error for that, since many valid expressions
+// (anything using Or or Not) are ODATA-only.
+func (e Expr) Validate() error {
+	if e.node == nil {
+		return fmt.Errorf("filter: empty expression")
+	}
+	return e.node.validate()
+}
+
+// String renders e as an ODATA $filter string for logging, ignoring any
+// rendering error (an expression too malformed to render any other way
+// still needs to be printable in a log line or error message).
+func (e Expr) String() string {
+	if e.node == nil {
+		return ""
+	}
+	var sb strings.Builder
+	e.node.odata(&sb)
+	return sb.String()
+}
+
+// ODataFilter renders e as an ODATA-inspired $filter string, e.g.
+// `(operating_system eq 'Windows' and last_seen gt 2024-01-01T00:00:00Z)`,
+// for AssetsAPI.List, AssetsAPI.Vulnerabilities, and BulkDeleteQuery.
+func (e Expr) ODataFilter() (string, error) {
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+	return e.String(), nil
+}
+
+// Params renders e as the numbered "f.N.field/operator/value" parameters
+// TagsAPI.ListValues expects. Only a flat conjunction of leaf comparisons
+// can be expressed this way — that query format has no representation
+// for Or, Not, or nested groups — so Params returns an error for any
+// expression using them; use ODataFilter for those instead.
+func (e Expr) Params() ([]Param, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	var params []Param
+	if err := e.node.leaves(&params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// Tree renders e as a generic tree, for callers (such as
+// BulkDeleteQueryFromExpr) that need to walk the full expression —
+// including Or and Not, which Params' flat conjunction can't carry and
+// ODataFilter only renders as text — into an endpoint-specific query
+// structure of their own.
+func (e Expr) Tree() (Tree, error) {
+	if err := e.Validate(); err != nil {
+		return Tree{}, err
+	}
+	return e.node.tree(), nil
+}
+
+// Tree is a generic node in an Expr's tree: either a Leaf comparison
+// (Op == "") or an "and"/"or"/"not" combination of Children.
+type Tree struct {
+	Op       string // "", "and", "or", or "not"
+	Leaf     *Leaf
+	Children []Tree
+}
+
+// Leaf is a single field/operator/value(s) comparison, as carried by a
+// Tree node with Op == "".
+type Leaf struct {
+	Field    string
+	Operator string
+	Values   []interface{}
+}
+
+func (n leafNode) tree() Tree {
+	return Tree{Leaf: &Leaf{Field: n.field, Operator: n.operator, Values: n.values}}
+}
+
+func (n unaryNode) tree() Tree {
+	return Tree{Op: "not", Children: []Tree{n.operand.tree()}}
+}
+
+func (n binaryNode) tree() Tree {
+	return Tree{Op: n.op, Children: []Tree{n.left.tree(), n.right.tree()}}
+}
+
+func (n leafNode) validate() error {
+	if n.field == "" {
+		return fmt.Errorf("filter: comparison is missing a field name")
+	}
+	if len(n.values) == 0 {
+		return fmt.Errorf("filter: comparison on field %q has no value", n.field)
+	}
+	return nil
+}
+
+func (n leafNode) leaves(params *[]Param) error {
+	*params = append(*params, Param{
+		Field:    n.field,
+		Operator: n.operator,
+		Value:    joinValues(n.values),
+	})
+	return nil
+}
+
+func (n leafNode) odata(sb *strings.Builder) {
+	switch n.operator {
+	case opContains:
+		fmt.Fprintf(sb, "contains(%s,%s)", n.field, odataLiteral(n.values[0]))
+	case opStartsWith:
+		fmt.Fprintf(sb, "startswith(%s,%s)", n.field, odataLiteral(n.values[0]))
+	case opIn:
+		sb.WriteByte('(')
+		for i, v := range n.values {
+			if i > 0 {
+				sb.WriteString(" or ")
+			}
+			fmt.Fprintf(sb, "%s eq %s", n.field, odataLiteral(v))
+		}
+		sb.WriteByte(')')
+	default:
+		fmt.Fprintf(sb, "%s %s %s", n.field, n.operator, odataLiteral(n.values[0]))
+	}
+}
+
+func (n unaryNode) validate() error {
+	if n.operand == nil {
+		return fmt.Errorf("filter: not() applied to an empty expression")
+	}
+	return n.operand.validate()
+}
+
+func (n unaryNode) leaves(*[]Param) error {
+	return fmt.Errorf("filter: not() has no numbered-params representation; use ODataFilter")
+}
+
+func (n unaryNode) odata(sb *strings.Builder) {
+	sb.WriteString("not (")
+	n.operand.odata(sb)
+	sb.WriteByte(')')
+}
+
+func (n binaryNode) validate() error {
+	if n.left == nil || n.right == nil {
+		return fmt.Errorf("filter: %s() applied to an empty expression", n.op)
+	}
+	if err := n.left.validate(); err != nil {
+		return err
+	}
+	return n.right.validate()
+}
+
+func (n binaryNode) leaves(params *[]Param) error {
+	if n.op != "and" {
+		return fmt.Errorf("filter: or() has no numbered-params representation; use ODataFilter")
+	}
+	if err := n.left.leaves(params); err != nil {
+		return err
+	}
+	return n.right.leaves(params)
+}
+
+func (n binaryNode) odata(sb *strings.Builder) {
+	sb.WriteByte('(')
+	n.left.odata(sb)
+	fmt.Fprintf(sb, " %s ", n.op)
+	n.right.odata(sb)
+	sb.WriteByte(')')
+}
+
+// joinValues renders a leaf's values as the numbered-params format
+// expects: a single value as-is, multiple (an "in" comparison) joined
+// with commas, both using formatValue's RFC3339 handling for times.
+func joinValues(values []interface{}) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = formatValue(v)
+	}
+	return strings.Join(parts, ",")
+}
+
+// formatValue renders a leaf value as a plain string, formatting
+// time.Time as RFC3339 so callers don't pass ad hoc date string formats.
+func formatValue(v interface{}) string {
+	switch val := v.(type) {
+	case time.Time:
+		return val.UTC().Format(time.RFC3339)
+	case string:
+		return val
+	case fmt.Stringer:
+		return val.String()
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// odataLiteral renders a leaf value as an ODATA literal: single-quoted
+// (with embedded quotes escaped) for strings, RFC3339 and unquoted for
+// time.Time, and unquoted for numbers and bools.
+func odataLiteral(v interface{}) string {
+	switch val := v.(type) {
+	case time.Time:
+		return val.UTC().Format(time.RFC3339)
+	case string:
+		return "'" + strings.ReplaceAll(val, "'", "''") + "'"
+	case bool:
+		return strconv.FormatBool(val)
+	case int, int32, int64, float32, float64:
+		return fmt.Sprint(val)
+	case fmt.Stringer:
+		return "'" + strings.ReplaceAll(val.String(), "'", "''") + "'"
+	default:
+		return "'" + strings.ReplaceAll(fmt.Sprint(val), "'", "''") + "'"
+	}
+}
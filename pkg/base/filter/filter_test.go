@@ -0,0 +1,100 @@
+package filter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestODataFilterRendersConjunctionAndDisjunction(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expr := Eq("operating_system", "Windows").
+		And(In("tags.env", "prod", "staging")).
+		Or(Gt("last_seen", at))
+
+	got, err := expr.ODataFilter()
+	if err != nil {
+		t.Fatalf("ODataFilter() error = %v", err)
+	}
+	want := "((operating_system eq 'Windows' and (tags.env eq 'prod' or tags.env eq 'staging')) or last_seen gt 2024-01-01T00:00:00Z)"
+	if got != want {
+		t.Errorf("ODataFilter() = %q, want %q", got, want)
+	}
+}
+
+func TestODataFilterRendersContainsStartsWithAndNot(t *testing.T) {
+	expr := Not(Contains("hostname", "db").Or(StartsWith("hostname", "web")))
+
+	got, err := expr.ODataFilter()
+	if err != nil {
+		t.Fatalf("ODataFilter() error = %v", err)
+	}
+	want := "not ((contains(hostname,'db') or startswith(hostname,'web')))"
+	if got != want {
+		t.Errorf("ODataFilter() = %q, want %q", got, want)
+	}
+}
+
+func TestODataFilterEscapesQuotesInStringLiterals(t *testing.T) {
+	got, err := Eq("name", "O'Brien's box").ODataFilter()
+	if err != nil {
+		t.Fatalf("ODataFilter() error = %v", err)
+	}
+	if want := "name eq 'O''Brien''s box'"; got != want {
+		t.Errorf("ODataFilter() = %q, want %q", got, want)
+	}
+}
+
+func TestParamsRendersFlatConjunction(t *testing.T) {
+	expr := Eq("field_a", "x").And(Gt("field_b", 5)).And(In("field_c", "y", "z"))
+
+	params, err := expr.Params()
+	if err != nil {
+		t.Fatalf("Params() error = %v", err)
+	}
+	want := []Param{
+		{Field: "field_a", Operator: "eq", Value: "x"},
+		{Field: "field_b", Operator: "gt", Value: "5"},
+		{Field: "field_c", Operator: "in", Value: "y,z"},
+	}
+	if len(params) != len(want) {
+		t.Fatalf("Params() = %+v, want %+v", params, want)
+	}
+	for i := range want {
+		if params[i] != want[i] {
+			t.Errorf("Params()[%d] = %+v, want %+v", i, params[i], want[i])
+		}
+	}
+}
+
+func TestParamsRejectsOr(t *testing.T) {
+	expr := Eq("field_a", "x").Or(Eq("field_b", "y"))
+	if _, err := expr.Params(); err == nil {
+		t.Error("Params() error = nil, want error for an Or expression")
+	}
+}
+
+func TestParamsRejectsNot(t *testing.T) {
+	expr := Not(Eq("field_a", "x"))
+	if _, err := expr.Params(); err == nil {
+		t.Error("Params() error = nil, want error for a Not expression")
+	}
+}
+
+func TestValidateCatchesEmptyFieldAndEmptyIn(t *testing.T) {
+	if err := Eq("", "x").Validate(); err == nil {
+		t.Error("Validate() error = nil, want error for empty field name")
+	}
+	if err := In("field").Validate(); err == nil {
+		t.Error("Validate() error = nil, want error for in() with no values")
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	var e Expr
+	if !e.IsZero() {
+		t.Error("IsZero() = false for zero-value Expr, want true")
+	}
+	if Eq("field", "x").IsZero() {
+		t.Error("IsZero() = true for a built expression, want false")
+	}
+}
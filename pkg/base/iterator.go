@@ -4,6 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // PaginationInfo contains pagination metadata from API responses.
@@ -19,27 +23,254 @@ type PageFetcher func(ctx context.Context, offset, limit int) (json.RawMessage,
 // ItemTransformer is a function that transforms raw JSON into typed items.
 type ItemTransformer[T any] func(data json.RawMessage) ([]T, error)
 
+// StreamingFetcher is a PageFetcher/ItemTransformer alternative for
+// sources that can decode items one at a time as they arrive — e.g. a
+// chunk body read with a streaming json.Decoder or an NDJSON stream —
+// instead of reading a whole page's body into memory and unmarshaling it
+// in one call. It pushes each decoded item onto items as soon as it's
+// parsed and returns once the page is exhausted, so a large page's items
+// reach the Iterator well before the page finishes decoding.
+type StreamingFetcher[T any] func(ctx context.Context, offset, limit int, items chan<- T) (*PaginationInfo, error)
+
 // Iterator provides pagination over API results.
 type Iterator[T any] struct {
-	ctx         context.Context
-	fetcher     PageFetcher
-	transformer ItemTransformer[T]
-	limit       int
-	offset      int
-	total       int
-	page        []T
-	pageIndex   int
-	count       int
-	maxPages    int
-	pagesLoaded int
-	done        bool
-	err         error
-	current     T
+	ctx              context.Context
+	fetcher          PageFetcher
+	transformer      ItemTransformer[T]
+	streamingFetcher StreamingFetcher[T]
+	limit            int
+	offset           int
+	total            int
+	page             []T
+	pageIndex        int
+	count            int
+	maxPages         int
+	pagesLoaded      int
+	done             bool
+	err              error
+	current          T
+
+	progress         ProgressReporter
+	progressStarted  bool
+	progressFinished bool
+
+	prefetchN      int
+	prefetchTried  bool
+	prefetchSlots  chan chan prefetchResult[T]
+	prefetchCancel context.CancelFunc
+
+	// pageTimeout, if set, bounds each individual PageFetcher call via a
+	// context.WithTimeout derived from ctx. deadline, if set, bounds the
+	// whole iteration's wall-clock via context.WithDeadline, checked
+	// before every page fetch in addition to ctx.Err().
+	pageTimeout time.Duration
+	deadline    time.Time
+
+	// pageDeadline bounds the PageFetcher call currently in flight (or the
+	// next one, if none is in flight), and unlike pageTimeout/deadline
+	// above can be rearmed at any time via SetDeadline/SetPageDeadline,
+	// including while a fetch is already running, cancelling it cleanly
+	// without ending the iterator.
+	pageDeadline *deadlineGate
+	// transientPageErr marks err as having come from pageDeadline
+	// canceling the page fetch in flight, rather than a fatal fetch or
+	// transform error: the next Next() clears it and retries instead of
+	// treating the iterator as permanently done.
+	transientPageErr bool
+
+	// sortCmp, if set via WithSort, stable-sorts each page's items before
+	// they're handed to the caller. With WithGlobalSort it instead sorts
+	// the fully materialized result set once, after dedup.
+	sortCmp Comparator[T]
+	// dedupKey, if set via WithDedup, drops any item whose key has
+	// already been yielded by an earlier page, bounding it.dedupSeen to
+	// the number of distinct keys seen so far.
+	dedupKey  func(T) string
+	dedupSeen map[string]struct{}
+
+	// globalSort, set via WithGlobalSort, defers sortCmp until the whole
+	// result set has been fetched and deduped, rather than sorting each
+	// page independently.
+	globalSort     bool
+	globalSortDone bool
+
+	// pause backs PauseUntil/Resume: when active, fetchNextPageSerial and
+	// fetchNextPageStreaming block before issuing their next PageFetcher/
+	// StreamingFetcher call until it ends.
+	pause *pauseGate
+}
+
+// Comparator reports the relative order of a and b the same way
+// sort.Slice's less function would, but as a three-way comparison:
+// negative if a sorts before b, zero if they're equivalent, positive if a
+// sorts after b. This mirrors comparator libraries like Java's
+// Comparator<T> or Go's cmp.Compare rather than a bare less func, so
+// built-in comparators (tio/compare) can be combined or reversed without
+// re-deriving the boolean.
+type Comparator[T any] func(a, b T) int
+
+// prefetchResult is the outcome of one concurrently fetched and transformed
+// page, delivered through a page's dedicated slot channel so consumers can
+// read pages back out in their original order regardless of completion
+// order.
+type prefetchResult[T any] struct {
+	items []T
+	err   error
 }
 
 // IteratorOption configures an Iterator.
 type IteratorOption[T any] func(*Iterator[T])
 
+// deadlineGate is a rearmable, mid-flight-cancellable deadline, modeled on
+// net.Conn.SetDeadline: set installs an absolute point in time after which
+// channel's returned channel closes, and may be called again at any time
+// — including while a fetch that's already watching a channel obtained
+// from channel is in flight — to move the deadline earlier, later, or
+// disable it entirely (a zero time). As long as the channel currently in
+// effect hasn't closed yet, set reuses it and simply reschedules when it
+// fires, so a watcher that called channel before set was called still
+// observes the update; a fresh channel is only handed out once the
+// previous one has already closed, since a closed channel can't be
+// reopened.
+type deadlineGate struct {
+	mu       sync.Mutex
+	timer    *time.Timer
+	cancelCh chan struct{}
+	fired    bool
+}
+
+// newDeadlineGate returns a deadlineGate with no deadline set.
+func newDeadlineGate() *deadlineGate {
+	return &deadlineGate{cancelCh: make(chan struct{})}
+}
+
+// set installs t as the new deadline, rescheduling or stopping any
+// previously armed timer. A zero t disables the bound.
+func (g *deadlineGate) set(t time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.timer != nil {
+		g.timer.Stop()
+		g.timer = nil
+	}
+	if g.fired {
+		g.cancelCh = make(chan struct{})
+		g.fired = false
+	}
+
+	if t.IsZero() {
+		return
+	}
+
+	ch := g.cancelCh
+	d := time.Until(t)
+	if d <= 0 {
+		g.fired = true
+		close(ch)
+		return
+	}
+	g.timer = time.AfterFunc(d, func() {
+		g.mu.Lock()
+		g.fired = true
+		g.mu.Unlock()
+		close(ch)
+	})
+}
+
+// channel returns the channel that closes when the deadline currently in
+// effect elapses, or never, if none is set.
+func (g *deadlineGate) channel() <-chan struct{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.cancelCh
+}
+
+// pauseGate backs PauseUntil/Resume: pause blocks whoever calls wait
+// until either t arrives on its own or resume ends it early, the same
+// "rearmable, mid-flight" shape as deadlineGate but inverted — it gates
+// callers in rather than cutting one off, and doing nothing (no pause
+// ever started) must never block, unlike deadlineGate's zero-time
+// "disabled" state.
+type pauseGate struct {
+	mu       sync.Mutex
+	timer    *time.Timer
+	resumeCh chan struct{}
+	active   bool
+}
+
+// newPauseGate returns a pauseGate with no pause in effect.
+func newPauseGate() *pauseGate {
+	return &pauseGate{}
+}
+
+// pause suspends callers of wait until t, or until resume is called,
+// whichever comes first. Calling pause again while already paused
+// replaces the previous deadline.
+func (g *pauseGate) pause(t time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.timer != nil {
+		g.timer.Stop()
+		g.timer = nil
+	}
+
+	// Keep the channel of a pause already in effect, so a caller already
+	// blocked in wait follows the new deadline rather than the replaced one.
+	ch := g.resumeCh
+	if !g.active {
+		ch = make(chan struct{})
+		g.resumeCh = ch
+	}
+	g.active = true
+
+	d := time.Until(t)
+	if d <= 0 {
+		g.active = false
+		close(ch)
+		return
+	}
+	g.timer = time.AfterFunc(d, func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		if g.resumeCh == ch && g.active {
+			g.active = false
+			close(ch)
+		}
+	})
+}
+
+// resume ends the current pause immediately. It is a no-op if no pause
+// is in effect.
+func (g *pauseGate) resume() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.active {
+		return
+	}
+	if g.timer != nil {
+		g.timer.Stop()
+		g.timer = nil
+	}
+	g.active = false
+	close(g.resumeCh)
+}
+
+// wait returns the channel a caller should block on, and whether a
+// pause is currently active. ok is false (and ch nil) when nothing is
+// paused, so a caller never blocks on a pause that was never started.
+func (g *pauseGate) wait() (ch <-chan struct{}, ok bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.active {
+		return nil, false
+	}
+	return g.resumeCh, true
+}
+
 // NewIterator creates a new paginated iterator.
 func NewIterator[T any](
 	ctx context.Context,
@@ -48,13 +279,46 @@ func NewIterator[T any](
 	opts ...IteratorOption[T],
 ) *Iterator[T] {
 	it := &Iterator[T]{
-		ctx:         ctx,
-		fetcher:     fetcher,
-		transformer: transformer,
-		limit:       100,
-		offset:      0,
-		total:       -1, // Unknown until first fetch
-		maxPages:    0,  // 0 means no limit
+		ctx:          ctx,
+		fetcher:      fetcher,
+		transformer:  transformer,
+		limit:        100,
+		offset:       0,
+		total:        -1, // Unknown until first fetch
+		maxPages:     0,  // 0 means no limit
+		progress:     NoopProgressReporter,
+		pageDeadline: newDeadlineGate(),
+		pause:        newPauseGate(),
+	}
+
+	for _, opt := range opts {
+		opt(it)
+	}
+
+	return it
+}
+
+// NewStreamingIterator creates an Iterator backed by a StreamingFetcher
+// instead of a PageFetcher+ItemTransformer pair, for sources — export
+// chunk streams, NDJSON bodies — that can decode items incrementally
+// rather than returning a whole page's body to unmarshal at once. It
+// supports every IteratorOption except WithPrefetch, which only applies
+// to the PageFetcher path.
+func NewStreamingIterator[T any](
+	ctx context.Context,
+	fetcher StreamingFetcher[T],
+	opts ...IteratorOption[T],
+) *Iterator[T] {
+	it := &Iterator[T]{
+		ctx:              ctx,
+		streamingFetcher: fetcher,
+		limit:            100,
+		offset:           0,
+		total:            -1, // Unknown until first fetch
+		maxPages:         0,  // 0 means no limit
+		progress:         NoopProgressReporter,
+		pageDeadline:     newDeadlineGate(),
+		pause:            newPauseGate(),
 	}
 
 	for _, opt := range opts {
@@ -85,8 +349,111 @@ func WithMaxPages[T any](maxPages int) IteratorOption[T] {
 	}
 }
 
+// WithPrefetch enables concurrent page prefetching. Once the first page
+// reveals the total item count, a background goroutine issues up to n
+// concurrent PageFetcher calls for subsequent offsets and feeds the
+// transformed results into Next() in their original order, so callers see
+// no difference from serial iteration other than lower wall-clock time.
+// It respects WithMaxPages and WithOffset, and stops prefetching (falling
+// back to one-page-at-a-time fetches) if total is unknown after the first
+// page or if a later page comes back empty before the expected total is
+// reached. n<=1 preserves the default strictly serial behavior. n may
+// safely exceed the number of pages actually remaining; startPrefetch
+// only ever schedules as many offsets as WithMaxPages/the known total
+// allow.
+func WithPrefetch[T any](n int) IteratorOption[T] {
+	return func(it *Iterator[T]) {
+		it.prefetchN = n
+	}
+}
+
+// WithPageTimeout bounds each individual PageFetcher call to d by wrapping
+// it in a context.WithTimeout derived from the iterator's ctx, similar to
+// net.Conn.SetDeadline's per-operation timeout: a single slow page fails
+// that page's fetch (surfaced via Err/Errored) without waiting on the
+// fixed overall request timeout, so a long Next()/Channel() loop over a
+// partial outage fails fast instead of hanging on one stuck page.
+func WithPageTimeout[T any](d time.Duration) IteratorOption[T] {
+	return func(it *Iterator[T]) {
+		it.pageTimeout = d
+	}
+}
+
+// WithPageDeadline bounds the iterator's total wall-clock: once time.Now()
+// is at or after t, Next() stops fetching further pages (existing buffered
+// items already fetched are still returned) and Err() reports
+// context.DeadlineExceeded. Unlike WithPageTimeout, which only bounds one
+// PageFetcher call at a time, this bounds iteration as a whole, mirroring
+// net.Conn.SetDeadline's absolute-time semantics rather than SetDeadline's
+// per-call ones. Named WithPageDeadline rather than WithDeadline to avoid
+// colliding with the non-generic context-deriving WithDeadline in
+// deadline.go.
+func WithPageDeadline[T any](t time.Time) IteratorOption[T] {
+	return func(it *Iterator[T]) {
+		it.deadline = t
+	}
+}
+
+// WithProgress attaches a ProgressReporter that is notified once when the
+// first page's response arrives (OnStart, with PaginationInfo.Total),
+// after every page (OnProgress), and once when iteration finishes
+// (successfully or with an error).
+func WithProgress[T any](reporter ProgressReporter) IteratorOption[T] {
+	return func(it *Iterator[T]) {
+		if reporter == nil {
+			reporter = NoopProgressReporter
+		}
+		it.progress = reporter
+	}
+}
+
+// WithSort stable-sorts each page's items by cmp before Next() yields
+// them, useful because Tenable's workbench pagination can return items
+// out of order — or reordered relative to an earlier page — when data
+// mutates mid-scan. Sorting is per-page only: an item on page 2 is never
+// reordered relative to one on page 1. Combine with WithGlobalSort for a
+// total order across the whole result set.
+func WithSort[T any](cmp Comparator[T]) IteratorOption[T] {
+	return func(it *Iterator[T]) {
+		it.sortCmp = cmp
+	}
+}
+
+// WithDedup drops any item whose key (as computed by key) has already
+// been yielded by an earlier page, useful because Tenable's workbench
+// pagination can return duplicate assets across pages when data mutates
+// mid-scan. Unlike WithSort, dedup is never just per-page: it tracks
+// every key seen across the whole iteration so a duplicate that lands on
+// a later page is still caught.
+func WithDedup[T any](key func(T) string) IteratorOption[T] {
+	return func(it *Iterator[T]) {
+		it.dedupKey = key
+	}
+}
+
+// WithGlobalSort changes WithSort from a per-page stable sort into a
+// total order across the entire result set: the first Next() call fully
+// materializes every remaining page (applying WithDedup along the way)
+// before sorting once and yielding from the combined, sorted slice. This
+// trades streaming behavior and bounded memory for a correct overall
+// order, so it should only be combined with WithMaxPages or a query
+// that's known to return a bounded result set. WithGlobalSort has no
+// effect unless WithSort is also set.
+func WithGlobalSort[T any]() IteratorOption[T] {
+	return func(it *Iterator[T]) {
+		it.globalSort = true
+	}
+}
+
 // Next returns the next item. Returns false when iteration is complete.
 func (it *Iterator[T]) Next() bool {
+	if it.globalSort && !it.globalSortDone {
+		it.materializeGlobalSort()
+	}
+	if it.transientPageErr {
+		it.err = nil
+		it.transientPageErr = false
+	}
 	if it.done || it.err != nil {
 		return false
 	}
@@ -94,6 +461,7 @@ func (it *Iterator[T]) Next() bool {
 	// Check if we've reached the total
 	if it.total >= 0 && it.count >= it.total {
 		it.done = true
+		it.finishProgress(nil)
 		return false
 	}
 
@@ -122,6 +490,141 @@ func (it *Iterator[T]) Err() error {
 	return it.err
 }
 
+// Errored reports whether iteration stopped because of an error —
+// including ctx cancellation or a WithPageDeadline/WithPageTimeout expiry —
+// as opposed to reaching the end of the result set normally. Channel
+// callers, who only observe the channel closing either way, use this
+// afterward to tell "done" apart from "canceled/timed out".
+func (it *Iterator[T]) Errored() bool {
+	return it.err != nil
+}
+
+// SetPageDeadline bounds the PageFetcher call currently in flight (if
+// any) and every subsequent one to t, exactly like net.Conn.SetDeadline.
+// It may be called at any point during iteration, including while a page
+// fetch is already running, in which case that fetch is canceled as soon
+// as t elapses rather than waiting for it to return on its own. A zero t
+// disables the bound. Unlike WithPageDeadline/WithPageTimeout, which are
+// fixed for the lifetime of the Iterator, SetPageDeadline can be rearmed
+// mid-iteration, and canceling an in-flight fetch this way only fails
+// that one fetch: Err() reports context.DeadlineExceeded and the
+// iterator's caller can still call Next() again to retry a fresh fetch
+// (canceled at once while t is still in the past). A page fetched by
+// WithPrefetch's background workers and canceled this way instead ends
+// the iteration with context.DeadlineExceeded.
+func (it *Iterator[T]) SetPageDeadline(t time.Time) {
+	it.pageDeadline.set(t)
+}
+
+// SetDeadline is an alias for SetPageDeadline. net.Conn distinguishes
+// SetDeadline (both directions) from SetReadDeadline/SetWriteDeadline,
+// but an Iterator only ever has one kind of operation in flight — a
+// single PageFetcher call — so there's nothing for SetDeadline to bound
+// beyond what SetPageDeadline already does.
+func (it *Iterator[T]) SetDeadline(t time.Time) {
+	it.SetPageDeadline(t)
+}
+
+// PauseUntil suspends page fetching until t, or until Resume is called,
+// whichever comes first: the next call to Next() (and any already
+// blocked in one) waits rather than issuing another PageFetcher/
+// StreamingFetcher call, so a rate-limit-aware consumer that's been
+// told to back off can wait out a cooldown without tearing down and
+// re-creating the Iterator or burning the parent context's deadline on
+// a sleep. It only affects fetchNextPageSerial/fetchNextPageStreaming;
+// a page already requested by WithPrefetch's background workers is not
+// held back. Calling PauseUntil again replaces the previous pause.
+func (it *Iterator[T]) PauseUntil(t time.Time) {
+	it.pause.pause(t)
+}
+
+// Resume ends a pause started by PauseUntil immediately. It is a no-op
+// if the iterator isn't currently paused.
+func (it *Iterator[T]) Resume() {
+	it.pause.resume()
+}
+
+// waitForResume blocks until any pause started by PauseUntil ends, or
+// ctx is canceled, whichever comes first. It returns immediately,
+// without blocking, if no pause is currently active.
+func (it *Iterator[T]) waitForResume(ctx context.Context) error {
+	ch, active := it.pause.wait()
+	if !active {
+		return nil
+	}
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// checkDeadline reports whether ctx has been canceled or the iterator's
+// WithPageDeadline has passed, without issuing a page fetch — so Next can
+// fail fast on a canceled or expired iteration instead of blocking in one
+// more PageFetcher call first.
+func (it *Iterator[T]) checkDeadline() error {
+	if err := it.ctx.Err(); err != nil {
+		return err
+	}
+	if !it.deadline.IsZero() && !time.Now().Before(it.deadline) {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+// fetchContext derives the context.Context a single PageFetcher call
+// should use from base: base wrapped in WithTimeout(pageTimeout) if set,
+// further wrapped in WithDeadline(deadline) if set (nested WithDeadline
+// calls always honor whichever deadline is sooner, so order doesn't
+// matter), and finally wrapped in a cancelable context that a watcher
+// goroutine cancels the moment it.pageDeadline's current channel closes
+// — which SetDeadline/SetPageDeadline can trigger at any time, including
+// while the fetch this context belongs to is already in flight.
+//
+// The returned done func must be called exactly once after the fetch
+// returns; it stops the watcher goroutine and reports whether it was
+// pageDeadline, rather than ordinary completion or the outer ctx, that
+// ended the fetch — callers use that to normalize the fetcher's error to
+// context.DeadlineExceeded.
+func (it *Iterator[T]) fetchContext(base context.Context) (ctx context.Context, done func() (deadlineFired bool)) {
+	ctx = base
+	var cancels []context.CancelFunc
+	if it.pageTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, it.pageTimeout)
+		cancels = append(cancels, cancel)
+	}
+	if !it.deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, it.deadline)
+		cancels = append(cancels, cancel)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancelCh := it.pageDeadline.channel()
+	watchDone := make(chan struct{})
+	var fired int32
+	go func() {
+		select {
+		case <-cancelCh:
+			atomic.StoreInt32(&fired, 1)
+			cancel()
+		case <-watchDone:
+		}
+	}()
+
+	return ctx, func() bool {
+		close(watchDone)
+		cancel()
+		for i := len(cancels) - 1; i >= 0; i-- {
+			cancels[i]()
+		}
+		return atomic.LoadInt32(&fired) == 1
+	}
+}
+
 // Count returns the number of items returned so far.
 func (it *Iterator[T]) Count() int {
 	return it.count
@@ -132,54 +635,427 @@ func (it *Iterator[T]) Total() int {
 	return it.total
 }
 
-// fetchNextPage fetches the next page of results.
+// fetchNextPage fetches the next page of results, transparently using the
+// prefetch pipeline once it has been started.
 func (it *Iterator[T]) fetchNextPage() bool {
-	// Check max pages limit
-	if it.maxPages > 0 && it.pagesLoaded >= it.maxPages {
-		it.done = true
-		return false
+	if it.prefetchSlots != nil {
+		return it.fetchNextPagePrefetched()
 	}
+	if it.streamingFetcher != nil {
+		return it.fetchNextPageStreaming()
+	}
+	return it.fetchNextPageSerial()
+}
 
-	// Check if we've already fetched everything
-	if it.total >= 0 && it.offset >= it.total {
-		it.done = true
-		return false
+// fetchNextPageSerial fetches the next page with a single synchronous
+// PageFetcher call. This is the only fetch path when prefetching is
+// disabled, and it is also where prefetching is kicked off (after the
+// first page establishes the total) and where iteration falls back to
+// once a prefetched page runs dry early.
+func (it *Iterator[T]) fetchNextPageSerial() bool {
+	for {
+		// Check max pages limit
+		if it.maxPages > 0 && it.pagesLoaded >= it.maxPages {
+			it.done = true
+			it.finishProgress(nil)
+			return false
+		}
+
+		// Check if we've already fetched everything
+		if it.total >= 0 && it.offset >= it.total {
+			it.done = true
+			it.finishProgress(nil)
+			return false
+		}
+
+		// Fail fast on a canceled ctx or expired WithPageDeadline rather than
+		// issuing another page fetch.
+		if err := it.checkDeadline(); err != nil {
+			it.err = err
+			it.finishProgress(err)
+			return false
+		}
+
+		// Block here, rather than issuing another page fetch, while a
+		// PauseUntil is in effect.
+		if err := it.waitForResume(it.ctx); err != nil {
+			it.err = err
+			it.finishProgress(err)
+			return false
+		}
+
+		// Fetch the page
+		fetchCtx, done := it.fetchContext(it.ctx)
+		data, pagination, err := it.fetcher(fetchCtx, it.offset, it.limit)
+		deadlineFired := done()
+		if err != nil {
+			if deadlineFired {
+				// pageDeadline canceled this fetch specifically; unlike a
+				// fatal fetcher error, this doesn't end the iterator — the
+				// next Next() clears err and retries with a fresh fetch.
+				it.err = context.DeadlineExceeded
+				it.transientPageErr = true
+				return false
+			}
+			it.err = err
+			it.finishProgress(err)
+			return false
+		}
+
+		// Update pagination info
+		if pagination != nil {
+			it.total = pagination.Total
+		}
+
+		if !it.progressStarted {
+			it.progressStarted = true
+			it.progress.OnStart(int64(it.total))
+		}
+
+		// Transform the data
+		items, err := it.transformer(data)
+		if err != nil {
+			it.err = fmt.Errorf("failed to transform page data: %w", err)
+			it.finishProgress(it.err)
+			return false
+		}
+
+		if len(items) == 0 {
+			it.done = true
+			it.finishProgress(nil)
+			return false
+		}
+
+		it.offset += len(items)
+		it.pagesLoaded++
+		it.progress.OnProgress(int64(it.offset), int64(it.total))
+
+		items = it.postProcessPage(items)
+		if len(items) == 0 {
+			// WithDedup filtered out every item on this page; that's not
+			// the same as the server reporting an empty page, so keep
+			// fetching instead of ending the iteration.
+			continue
+		}
+
+		it.page = items
+		it.pageIndex = 0
+
+		if it.prefetchN > 1 && !it.prefetchTried && it.total >= 0 {
+			it.prefetchTried = true
+			it.startPrefetch()
+		}
+
+		// Get first item
+		it.current = it.page[it.pageIndex]
+		it.pageIndex++
+		it.count++
+		return true
 	}
+}
 
-	// Fetch the page
-	data, pagination, err := it.fetcher(it.ctx, it.offset, it.limit)
-	if err != nil {
-		it.err = err
-		return false
+// fetchNextPageStreaming is the fetch path for an Iterator built with
+// NewStreamingIterator: it runs it.streamingFetcher in a goroutine and
+// drains the items it decodes as they arrive, rather than reading a
+// page's whole body and unmarshaling it in one call as
+// fetchNextPageSerial does. Every decoded item of the page is collected
+// into it.page before Next() returns the first of them, so a page's
+// decoded items are all held in memory at once; the page's raw body is
+// not.
+func (it *Iterator[T]) fetchNextPageStreaming() bool {
+	for {
+		if it.maxPages > 0 && it.pagesLoaded >= it.maxPages {
+			it.done = true
+			it.finishProgress(nil)
+			return false
+		}
+
+		if it.total >= 0 && it.offset >= it.total {
+			it.done = true
+			it.finishProgress(nil)
+			return false
+		}
+
+		if err := it.checkDeadline(); err != nil {
+			it.err = err
+			it.finishProgress(err)
+			return false
+		}
+
+		if err := it.waitForResume(it.ctx); err != nil {
+			it.err = err
+			it.finishProgress(err)
+			return false
+		}
+
+		fetchCtx, done := it.fetchContext(it.ctx)
+		itemsCh := make(chan T, 1)
+		fetchErrCh := make(chan error, 1)
+		var pagination *PaginationInfo
+		go func() {
+			defer close(itemsCh)
+			p, err := it.streamingFetcher(fetchCtx, it.offset, it.limit, itemsCh)
+			pagination = p
+			fetchErrCh <- err
+		}()
+
+		var items []T
+		for item := range itemsCh {
+			items = append(items, item)
+		}
+		err := <-fetchErrCh
+		deadlineFired := done()
+		if err != nil {
+			if deadlineFired {
+				it.err = context.DeadlineExceeded
+				it.transientPageErr = true
+				return false
+			}
+			it.err = err
+			it.finishProgress(err)
+			return false
+		}
+
+		if pagination != nil {
+			it.total = pagination.Total
+		}
+
+		if !it.progressStarted {
+			it.progressStarted = true
+			it.progress.OnStart(int64(it.total))
+		}
+
+		if len(items) == 0 {
+			it.done = true
+			it.finishProgress(nil)
+			return false
+		}
+
+		it.offset += len(items)
+		it.pagesLoaded++
+		it.progress.OnProgress(int64(it.offset), int64(it.total))
+
+		items = it.postProcessPage(items)
+		if len(items) == 0 {
+			continue
+		}
+
+		it.page = items
+		it.pageIndex = 0
+
+		it.current = it.page[it.pageIndex]
+		it.pageIndex++
+		it.count++
+		return true
 	}
+}
+
+// fetchNextPagePrefetched consumes the next page produced by the prefetch
+// pipeline, in the original offset order.
+func (it *Iterator[T]) fetchNextPagePrefetched() bool {
+	for {
+		slot, ok := <-it.prefetchSlots
+		if !ok {
+			// The pipeline has delivered every page it originally planned
+			// to fetch. The total may have grown since then, so hand off
+			// to a plain serial fetch to pick up anything beyond it.
+			it.stopPrefetch()
+			return it.fetchNextPageSerial()
+		}
+
+		res := <-slot
+		if res.err != nil {
+			it.stopPrefetch()
+			it.err = res.err
+			it.finishProgress(res.err)
+			return false
+		}
+
+		if len(res.items) == 0 {
+			// The total drifted downward mid-iteration: stop trusting the
+			// precomputed offsets and fall back to fetching one page at a
+			// time, which terminates normally the next time it, too, sees
+			// an empty page.
+			it.stopPrefetch()
+			return it.fetchNextPageSerial()
+		}
+
+		it.offset += len(res.items)
+		it.pagesLoaded++
+		it.progress.OnProgress(int64(it.offset), int64(it.total))
 
-	// Update pagination info
-	if pagination != nil {
-		it.total = pagination.Total
+		items := it.postProcessPage(res.items)
+		if len(items) == 0 {
+			// Every item on this prefetched page was a duplicate of one
+			// already yielded; move on to the next slot instead of
+			// handing the caller an empty page.
+			continue
+		}
+
+		it.page = items
+		it.pageIndex = 0
+
+		it.current = it.page[it.pageIndex]
+		it.pageIndex++
+		it.count++
+		return true
 	}
+}
 
-	// Transform the data
-	items, err := it.transformer(data)
-	if err != nil {
-		it.err = fmt.Errorf("failed to transform page data: %w", err)
-		return false
+// startPrefetch computes the offsets remaining after the page already
+// loaded and spawns a background goroutine that dispatches up to
+// it.prefetchN concurrent PageFetcher calls for them, one worker goroutine
+// per offset. Each offset gets its own single-buffered slot channel pushed
+// onto it.prefetchSlots in order, so fetchNextPagePrefetched can read pages
+// back out in the order they were requested no matter which worker
+// finishes first.
+func (it *Iterator[T]) startPrefetch() {
+	offsets := it.remainingOffsets()
+	if len(offsets) == 0 {
+		return
 	}
 
-	if len(items) == 0 {
-		it.done = true
-		return false
+	ctx, cancel := context.WithCancel(it.ctx)
+	it.prefetchCancel = cancel
+
+	slots := make(chan chan prefetchResult[T], len(offsets))
+	sem := make(chan struct{}, it.prefetchN)
+
+	go func() {
+		defer close(slots)
+		for _, offset := range offsets {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			slot := make(chan prefetchResult[T], 1)
+			select {
+			case slots <- slot:
+			case <-ctx.Done():
+				<-sem
+				return
+			}
+
+			go func(offset int, slot chan prefetchResult[T]) {
+				defer func() { <-sem }()
+				fetchCtx, done := it.fetchContext(ctx)
+				data, _, err := it.fetcher(fetchCtx, offset, it.limit)
+				deadlineFired := done()
+				if err != nil {
+					if deadlineFired {
+						err = context.DeadlineExceeded
+					}
+					slot <- prefetchResult[T]{err: err}
+					return
+				}
+				items, err := it.transformer(data)
+				if err != nil {
+					slot <- prefetchResult[T]{err: fmt.Errorf("failed to transform page data: %w", err)}
+					return
+				}
+				slot <- prefetchResult[T]{items: items}
+			}(offset, slot)
+		}
+	}()
+
+	it.prefetchSlots = slots
+}
+
+// remainingOffsets returns the page offsets, in order, that a freshly
+// started prefetch pipeline should fetch: everything from the current
+// offset up to the known total, bounded by whatever's left of maxPages.
+func (it *Iterator[T]) remainingOffsets() []int {
+	var offsets []int
+	offset := it.offset
+	pages := it.pagesLoaded
+	for {
+		if it.total >= 0 && offset >= it.total {
+			break
+		}
+		if it.maxPages > 0 && pages >= it.maxPages {
+			break
+		}
+		offsets = append(offsets, offset)
+		offset += it.limit
+		pages++
+	}
+	return offsets
+}
+
+// stopPrefetch cancels any outstanding prefetch fetches and clears the
+// pipeline so fetchNextPage falls back to fetchNextPageSerial.
+func (it *Iterator[T]) stopPrefetch() {
+	if it.prefetchCancel != nil {
+		it.prefetchCancel()
+		it.prefetchCancel = nil
+	}
+	it.prefetchSlots = nil
+}
+
+// finishProgress calls the configured ProgressReporter's OnFinish exactly
+// once, the first time iteration reaches a terminal state.
+func (it *Iterator[T]) finishProgress(err error) {
+	if it.progressFinished {
+		return
+	}
+	it.progressFinished = true
+	it.progress.OnFinish(err)
+}
+
+// postProcessPage applies WithDedup and, unless WithGlobalSort deferred
+// it, WithSort to a freshly fetched page before it's handed to the
+// caller. Every fetch path funnels its raw items through this before
+// assigning them to it.page.
+func (it *Iterator[T]) postProcessPage(items []T) []T {
+	if it.dedupKey != nil {
+		if it.dedupSeen == nil {
+			it.dedupSeen = make(map[string]struct{})
+		}
+		filtered := items[:0:0]
+		for _, item := range items {
+			key := it.dedupKey(item)
+			if _, seen := it.dedupSeen[key]; seen {
+				continue
+			}
+			it.dedupSeen[key] = struct{}{}
+			filtered = append(filtered, item)
+		}
+		items = filtered
+	}
+	if it.sortCmp != nil && !it.globalSort {
+		sort.SliceStable(items, func(i, j int) bool { return it.sortCmp(items[i], items[j]) < 0 })
+	}
+	return items
+}
+
+// materializeGlobalSort fully drains the iterator's remaining pages
+// (applying WithDedup as it goes, via the ordinary fetch paths), stable-
+// sorts the combined result once with WithSort's comparator, and loads
+// it into it.page as a single page, so the rest of Next() proceeds as if
+// everything had arrived pre-sorted in one shot. It runs at most once
+// per Iterator, the first time Next() is called on one configured with
+// WithGlobalSort.
+func (it *Iterator[T]) materializeGlobalSort() {
+	it.globalSortDone = true
+
+	items, err := it.All()
+	if err != nil {
+		it.err = err
+		return
+	}
+	if it.sortCmp != nil {
+		sort.SliceStable(items, func(i, j int) bool { return it.sortCmp(items[i], items[j]) < 0 })
 	}
 
 	it.page = items
 	it.pageIndex = 0
-	it.offset += len(items)
-	it.pagesLoaded++
-
-	// Get first item
-	it.current = it.page[it.pageIndex]
-	it.pageIndex++
-	it.count++
-	return true
+	it.count = 0
+	it.total = len(items)
+	it.offset = len(items)
+	it.done = false
+	it.err = nil
 }
 
 // All returns all remaining items as a slice.
@@ -216,7 +1092,11 @@ func (it *Iterator[T]) ForEach(fn func(T) error) error {
 	return it.err
 }
 
-// Channel returns a channel that yields items.
+// Channel returns a channel that yields items. The channel is closed both
+// when iteration finishes normally and when it.ctx is canceled while
+// blocked delivering an item to a consumer that has stopped reading; Err
+// (and Errored) distinguish the two afterward, since a closed channel on
+// its own looks the same either way.
 func (it *Iterator[T]) Channel() <-chan T {
 	ch := make(chan T)
 	go func() {
@@ -225,6 +1105,7 @@ func (it *Iterator[T]) Channel() <-chan T {
 			select {
 			case ch <- it.Item():
 			case <-it.ctx.Done():
+				it.err = it.ctx.Err()
 				return
 			}
 		}
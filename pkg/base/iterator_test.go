@@ -3,7 +3,12 @@ package base
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 type testItem struct {
@@ -233,3 +238,690 @@ func TestIteratorEmpty(t *testing.T) {
 		t.Errorf("Count should be 0, got %d", iter.Count())
 	}
 }
+
+// concurrencyTrackingFetcher returns a PageFetcher over allItems that
+// records the peak number of concurrently in-flight calls, optionally
+// sleeping to simulate network latency.
+func concurrencyTrackingFetcher(allItems []testItem, delay time.Duration, inFlight, peak *int64) PageFetcher {
+	return func(ctx context.Context, offset, limit int) (json.RawMessage, *PaginationInfo, error) {
+		cur := atomic.AddInt64(inFlight, 1)
+		defer atomic.AddInt64(inFlight, -1)
+		for {
+			p := atomic.LoadInt64(peak)
+			if cur <= p || atomic.CompareAndSwapInt64(peak, p, cur) {
+				break
+			}
+		}
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			}
+		}
+
+		end := offset + limit
+		if end > len(allItems) {
+			end = len(allItems)
+		}
+		if offset >= len(allItems) {
+			data, _ := json.Marshal([]testItem{})
+			return data, &PaginationInfo{Total: len(allItems), Limit: limit, Offset: offset}, nil
+		}
+		data, _ := json.Marshal(allItems[offset:end])
+		return data, &PaginationInfo{Total: len(allItems), Limit: limit, Offset: offset}, nil
+	}
+}
+
+func TestIteratorPrefetch(t *testing.T) {
+	allItems := make([]testItem, 23)
+	for i := range allItems {
+		allItems[i] = testItem{ID: i + 1, Name: fmt.Sprintf("item-%d", i+1)}
+	}
+
+	transformer := func(data json.RawMessage) ([]testItem, error) {
+		var items []testItem
+		err := json.Unmarshal(data, &items)
+		return items, err
+	}
+
+	ctx := context.Background()
+
+	t.Run("matches serial results", func(t *testing.T) {
+		var inFlight, peak int64
+		iter := NewIterator(ctx, concurrencyTrackingFetcher(allItems, time.Millisecond, &inFlight, &peak), transformer,
+			WithLimit[testItem](5),
+			WithPrefetch[testItem](4),
+		)
+
+		collected, err := iter.All()
+		if err != nil {
+			t.Fatalf("All() error: %v", err)
+		}
+		if len(collected) != len(allItems) {
+			t.Fatalf("Expected %d items, got %d", len(allItems), len(collected))
+		}
+		for i, item := range collected {
+			if item.ID != allItems[i].ID {
+				t.Errorf("Item %d: expected ID %d, got %d", i, allItems[i].ID, item.ID)
+			}
+		}
+		if got := atomic.LoadInt64(&peak); got < 2 {
+			t.Errorf("Expected concurrent fetches, peak in-flight was %d", got)
+		}
+	})
+
+	t.Run("n<=1 is strictly serial", func(t *testing.T) {
+		var inFlight, peak int64
+		iter := NewIterator(ctx, concurrencyTrackingFetcher(allItems, time.Millisecond, &inFlight, &peak), transformer,
+			WithLimit[testItem](5),
+			WithPrefetch[testItem](1),
+		)
+
+		if _, err := iter.All(); err != nil {
+			t.Fatalf("All() error: %v", err)
+		}
+		if got := atomic.LoadInt64(&peak); got != 1 {
+			t.Errorf("Expected strictly serial fetches with n<=1, peak in-flight was %d", got)
+		}
+	})
+
+	t.Run("respects WithMaxPages", func(t *testing.T) {
+		var inFlight, peak int64
+		iter := NewIterator(ctx, concurrencyTrackingFetcher(allItems, 0, &inFlight, &peak), transformer,
+			WithLimit[testItem](5),
+			WithMaxPages[testItem](2),
+			WithPrefetch[testItem](4),
+		)
+
+		collected, err := iter.All()
+		if err != nil {
+			t.Fatalf("All() error: %v", err)
+		}
+		if len(collected) != 10 {
+			t.Errorf("Expected 10 items with max pages 2, got %d", len(collected))
+		}
+	})
+
+	t.Run("respects WithOffset", func(t *testing.T) {
+		var inFlight, peak int64
+		iter := NewIterator(ctx, concurrencyTrackingFetcher(allItems, 0, &inFlight, &peak), transformer,
+			WithLimit[testItem](5),
+			WithOffset[testItem](10),
+			WithPrefetch[testItem](4),
+		)
+
+		collected, err := iter.All()
+		if err != nil {
+			t.Fatalf("All() error: %v", err)
+		}
+		if len(collected) != len(allItems)-10 {
+			t.Errorf("Expected %d items starting at offset 10, got %d", len(allItems)-10, len(collected))
+		}
+		if len(collected) > 0 && collected[0].ID != allItems[10].ID {
+			t.Errorf("First item should have ID %d, got %d", allItems[10].ID, collected[0].ID)
+		}
+	})
+
+	t.Run("prefetch workers exceeding remaining pages", func(t *testing.T) {
+		var inFlight, peak int64
+		iter := NewIterator(ctx, concurrencyTrackingFetcher(allItems, 0, &inFlight, &peak), transformer,
+			WithLimit[testItem](5),
+			WithPrefetch[testItem](50), // far more workers than the 4 remaining pages after the first
+		)
+
+		collected, err := iter.All()
+		if err != nil {
+			t.Fatalf("All() error: %v", err)
+		}
+		if len(collected) != len(allItems) {
+			t.Errorf("Expected %d items, got %d", len(allItems), len(collected))
+		}
+	})
+
+	t.Run("unknown total falls back to serial", func(t *testing.T) {
+		var calls int64
+		fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *PaginationInfo, error) {
+			atomic.AddInt64(&calls, 1)
+			end := offset + limit
+			if end > len(allItems) {
+				end = len(allItems)
+			}
+			if offset >= len(allItems) {
+				data, _ := json.Marshal([]testItem{})
+				return data, &PaginationInfo{Total: -1, Limit: limit, Offset: offset}, nil
+			}
+			data, _ := json.Marshal(allItems[offset:end])
+			return data, &PaginationInfo{Total: -1, Limit: limit, Offset: offset}, nil
+		}
+
+		iter := NewIterator(ctx, fetcher, transformer, WithLimit[testItem](5), WithPrefetch[testItem](4))
+		collected, err := iter.All()
+		if err != nil {
+			t.Fatalf("All() error: %v", err)
+		}
+		if len(collected) != len(allItems) {
+			t.Errorf("Expected %d items, got %d", len(allItems), len(collected))
+		}
+	})
+
+	t.Run("error is propagated and cancels outstanding fetches", func(t *testing.T) {
+		boom := fmt.Errorf("boom")
+		var wg sync.WaitGroup
+		wg.Add(3) // offsets 10, 15, 20 must observe cancellation
+
+		fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *PaginationInfo, error) {
+			if offset == 5 {
+				return nil, nil, boom
+			}
+			if offset > 5 {
+				<-ctx.Done()
+				wg.Done()
+				return nil, nil, ctx.Err()
+			}
+			end := offset + limit
+			if end > len(allItems) {
+				end = len(allItems)
+			}
+			data, _ := json.Marshal(allItems[offset:end])
+			return data, &PaginationInfo{Total: len(allItems), Limit: limit, Offset: offset}, nil
+		}
+
+		iter := NewIterator(ctx, fetcher, transformer, WithLimit[testItem](5), WithPrefetch[testItem](4))
+		_, err := iter.All()
+		if err == nil {
+			t.Fatal("expected an error from All()")
+		}
+
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for outstanding fetches to observe cancellation")
+		}
+	})
+}
+
+func TestIteratorWithPageTimeout(t *testing.T) {
+	transformer := func(data json.RawMessage) ([]testItem, error) {
+		var items []testItem
+		err := json.Unmarshal(data, &items)
+		return items, err
+	}
+
+	fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *PaginationInfo, error) {
+		select {
+		case <-time.After(50 * time.Millisecond):
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+		data, _ := json.Marshal([]testItem{{ID: 1}})
+		return data, &PaginationInfo{Total: -1}, nil
+	}
+
+	iter := NewIterator(context.Background(), fetcher, transformer, WithPageTimeout[testItem](time.Millisecond))
+
+	if iter.Next() {
+		t.Fatal("Next() = true, want false (page fetch should time out)")
+	}
+	if !iter.Errored() {
+		t.Error("Errored() = false, want true after a page timeout")
+	}
+	if iter.Err() != context.DeadlineExceeded {
+		t.Errorf("Err() = %v, want context.DeadlineExceeded", iter.Err())
+	}
+}
+
+func TestIteratorWithPageDeadline(t *testing.T) {
+	allItems := []testItem{{ID: 1}, {ID: 2}, {ID: 3}}
+	fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *PaginationInfo, error) {
+		end := offset + limit
+		if end > len(allItems) {
+			end = len(allItems)
+		}
+		if offset >= len(allItems) {
+			data, _ := json.Marshal([]testItem{})
+			return data, &PaginationInfo{Total: len(allItems)}, nil
+		}
+		data, _ := json.Marshal(allItems[offset:end])
+		return data, &PaginationInfo{Total: len(allItems)}, nil
+	}
+	transformer := func(data json.RawMessage) ([]testItem, error) {
+		var items []testItem
+		err := json.Unmarshal(data, &items)
+		return items, err
+	}
+
+	iter := NewIterator(context.Background(), fetcher, transformer,
+		WithLimit[testItem](1), WithPageDeadline[testItem](time.Now().Add(-time.Second)))
+
+	if iter.Next() {
+		t.Fatal("Next() = true, want false (deadline already passed)")
+	}
+	if !iter.Errored() || iter.Err() != context.DeadlineExceeded {
+		t.Errorf("Err() = %v, Errored() = %v, want DeadlineExceeded/true", iter.Err(), iter.Errored())
+	}
+}
+
+func TestIteratorChannelPropagatesCancellation(t *testing.T) {
+	allItems := []testItem{{ID: 1}, {ID: 2}, {ID: 3}}
+	fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *PaginationInfo, error) {
+		end := offset + limit
+		if end > len(allItems) {
+			end = len(allItems)
+		}
+		if offset >= len(allItems) {
+			data, _ := json.Marshal([]testItem{})
+			return data, &PaginationInfo{Total: len(allItems)}, nil
+		}
+		data, _ := json.Marshal(allItems[offset:end])
+		return data, &PaginationInfo{Total: len(allItems)}, nil
+	}
+	transformer := func(data json.RawMessage) ([]testItem, error) {
+		var items []testItem
+		err := json.Unmarshal(data, &items)
+		return items, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	iter := NewIterator(ctx, fetcher, transformer, WithLimit[testItem](1))
+
+	ch := iter.Channel()
+	<-ch // consume the first item, then stop reading
+	cancel()
+
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				if !iter.Errored() || iter.Err() != context.Canceled {
+					t.Errorf("Err() = %v, Errored() = %v, want Canceled/true", iter.Err(), iter.Errored())
+				}
+				return
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for Channel() to close after cancellation")
+		}
+	}
+}
+
+func TestIteratorSetPageDeadlineCancelsInFlightFetchWithoutEndingIterator(t *testing.T) {
+	started := make(chan struct{})
+	var calls int
+	fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *PaginationInfo, error) {
+		calls++
+		if calls == 1 {
+			close(started)
+			<-ctx.Done()
+			return nil, nil, ctx.Err()
+		}
+		data, _ := json.Marshal([]testItem{{ID: 1}})
+		return data, &PaginationInfo{Total: 1}, nil
+	}
+	transformer := func(data json.RawMessage) ([]testItem, error) {
+		var items []testItem
+		err := json.Unmarshal(data, &items)
+		return items, err
+	}
+
+	iter := NewIterator(context.Background(), fetcher, transformer)
+
+	result := make(chan bool, 1)
+	go func() { result <- iter.Next() }()
+
+	<-started
+	iter.SetPageDeadline(time.Now())
+
+	if ok := <-result; ok {
+		t.Fatal("Next() = true, want false (page fetch canceled by SetPageDeadline)")
+	}
+	if !iter.Errored() || iter.Err() != context.DeadlineExceeded {
+		t.Errorf("Err() = %v, Errored() = %v, want DeadlineExceeded/true", iter.Err(), iter.Errored())
+	}
+
+	if !iter.Next() {
+		t.Fatalf("Next() after the canceled fetch = false, want true (iterator must still be usable); Err() = %v", iter.Err())
+	}
+	if iter.Item().ID != 1 {
+		t.Errorf("Item().ID = %d, want 1", iter.Item().ID)
+	}
+}
+
+func TestIteratorSetDeadlineZeroDisablesBound(t *testing.T) {
+	fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *PaginationInfo, error) {
+		data, _ := json.Marshal([]testItem{{ID: 1}})
+		return data, &PaginationInfo{Total: 1}, nil
+	}
+	transformer := func(data json.RawMessage) ([]testItem, error) {
+		var items []testItem
+		err := json.Unmarshal(data, &items)
+		return items, err
+	}
+
+	iter := NewIterator(context.Background(), fetcher, transformer)
+	iter.SetDeadline(time.Now().Add(time.Hour))
+	iter.SetDeadline(time.Time{})
+
+	if !iter.Next() {
+		t.Fatalf("Next() = false, want true; Err() = %v", iter.Err())
+	}
+}
+
+func TestStreamingIterator(t *testing.T) {
+	allItems := []testItem{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}}
+	fetcher := func(ctx context.Context, offset, limit int, items chan<- testItem) (*PaginationInfo, error) {
+		for i := offset; i < offset+limit && i < len(allItems); i++ {
+			select {
+			case items <- allItems[i]:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		return &PaginationInfo{Total: len(allItems)}, nil
+	}
+
+	iter := NewStreamingIterator(context.Background(), fetcher, WithLimit[testItem](2))
+	var got []testItem
+	for iter.Next() {
+		got = append(got, iter.Item())
+	}
+	if iter.Err() != nil {
+		t.Fatalf("unexpected error: %v", iter.Err())
+	}
+	if len(got) != len(allItems) {
+		t.Fatalf("got %d items, want %d", len(got), len(allItems))
+	}
+	for i, item := range got {
+		if item.ID != allItems[i].ID {
+			t.Errorf("item %d = %+v, want %+v", i, item, allItems[i])
+		}
+	}
+}
+
+func TestStreamingIteratorFetcherError(t *testing.T) {
+	fetcher := func(ctx context.Context, offset, limit int, items chan<- testItem) (*PaginationInfo, error) {
+		return nil, context.Canceled
+	}
+	iter := NewStreamingIterator(context.Background(), fetcher)
+	if iter.Next() {
+		t.Fatal("expected Next() to return false on fetcher error")
+	}
+	if iter.Err() != context.Canceled {
+		t.Fatalf("Err() = %v, want context.Canceled", iter.Err())
+	}
+}
+
+func TestStreamingIteratorSetPageDeadlineCancelsInFlightFetch(t *testing.T) {
+	started := make(chan struct{})
+	fetcher := func(ctx context.Context, offset, limit int, items chan<- testItem) (*PaginationInfo, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	iter := NewStreamingIterator(context.Background(), fetcher)
+
+	resultCh := make(chan bool, 1)
+	go func() { resultCh <- iter.Next() }()
+	<-started
+	iter.SetPageDeadline(time.Now())
+
+	select {
+	case ok := <-resultCh:
+		if ok {
+			t.Fatal("expected Next() to return false after page deadline")
+		}
+		if iter.Err() != context.DeadlineExceeded {
+			t.Fatalf("Err() = %v, want context.DeadlineExceeded", iter.Err())
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Next() did not return after SetPageDeadline")
+	}
+}
+
+// BenchmarkIteratorSerial measures throughput with prefetching disabled.
+func BenchmarkIteratorSerial(b *testing.B) {
+	allItems := make([]testItem, 200)
+	for i := range allItems {
+		allItems[i] = testItem{ID: i + 1, Name: fmt.Sprintf("item-%d", i+1)}
+	}
+	transformer := func(data json.RawMessage) ([]testItem, error) {
+		var items []testItem
+		err := json.Unmarshal(data, &items)
+		return items, err
+	}
+	var inFlight, peak int64
+	fetcher := concurrencyTrackingFetcher(allItems, time.Millisecond, &inFlight, &peak)
+
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		iter := NewIterator(ctx, fetcher, transformer, WithLimit[testItem](10))
+		if _, err := iter.All(); err != nil {
+			b.Fatalf("All() error: %v", err)
+		}
+	}
+}
+
+// BenchmarkIteratorPrefetch measures throughput with concurrent prefetching
+// enabled; it should comfortably beat BenchmarkIteratorSerial since the
+// simulated per-page latency overlaps across pages instead of stacking.
+func BenchmarkIteratorPrefetch(b *testing.B) {
+	allItems := make([]testItem, 200)
+	for i := range allItems {
+		allItems[i] = testItem{ID: i + 1, Name: fmt.Sprintf("item-%d", i+1)}
+	}
+	transformer := func(data json.RawMessage) ([]testItem, error) {
+		var items []testItem
+		err := json.Unmarshal(data, &items)
+		return items, err
+	}
+	var inFlight, peak int64
+	fetcher := concurrencyTrackingFetcher(allItems, time.Millisecond, &inFlight, &peak)
+
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		iter := NewIterator(ctx, fetcher, transformer, WithLimit[testItem](10), WithPrefetch[testItem](8))
+		if _, err := iter.All(); err != nil {
+			b.Fatalf("All() error: %v", err)
+		}
+	}
+}
+
+func pagedFetcher(pages [][]testItem) PageFetcher {
+	total := 0
+	for _, p := range pages {
+		total += len(p)
+	}
+	return func(ctx context.Context, offset, limit int) (json.RawMessage, *PaginationInfo, error) {
+		pageN := offset / limit
+		if pageN >= len(pages) {
+			data, _ := json.Marshal([]testItem{})
+			return data, &PaginationInfo{Total: total, Limit: limit, Offset: offset}, nil
+		}
+		data, _ := json.Marshal(pages[pageN])
+		return data, &PaginationInfo{Total: total, Limit: limit, Offset: offset}, nil
+	}
+}
+
+func testItemTransformer(data json.RawMessage) ([]testItem, error) {
+	var items []testItem
+	err := json.Unmarshal(data, &items)
+	return items, err
+}
+
+func byIDDesc(a, b testItem) int {
+	switch {
+	case a.ID == b.ID:
+		return 0
+	case a.ID > b.ID:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func TestIteratorWithSortOrdersEachPage(t *testing.T) {
+	pages := [][]testItem{
+		{{ID: 1}, {ID: 3}, {ID: 2}},
+		{{ID: 6}, {ID: 4}, {ID: 5}},
+	}
+	ctx := context.Background()
+	iter := NewIterator(ctx, pagedFetcher(pages), testItemTransformer, WithLimit[testItem](3), WithSort[testItem](byIDDesc))
+
+	var gotIDs []int
+	for iter.Next() {
+		gotIDs = append(gotIDs, iter.Item().ID)
+	}
+	if err := iter.Err(); err != nil {
+		t.Fatalf("iteration error: %v", err)
+	}
+	// Each page is sorted independently: page one descends 3,2,1, page two
+	// descends 6,5,4 — but page one as a whole still precedes page two.
+	want := []int{3, 2, 1, 6, 5, 4}
+	if fmt.Sprint(gotIDs) != fmt.Sprint(want) {
+		t.Errorf("ids = %v, want %v", gotIDs, want)
+	}
+}
+
+func TestIteratorWithDedupDropsRepeatsAcrossPages(t *testing.T) {
+	pages := [][]testItem{
+		{{ID: 1}, {ID: 2}},
+		{{ID: 2}, {ID: 3}},
+	}
+	ctx := context.Background()
+	iter := NewIterator(ctx, pagedFetcher(pages), testItemTransformer, WithLimit[testItem](2),
+		WithDedup[testItem](func(it testItem) string { return strconv.Itoa(it.ID) }))
+
+	items, err := iter.All()
+	if err != nil {
+		t.Fatalf("All() error: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("items = %+v, want 3 distinct IDs", items)
+	}
+}
+
+func TestIteratorWithDedupEmptiesPageWithoutEndingIteration(t *testing.T) {
+	pages := [][]testItem{
+		{{ID: 1}},
+		{{ID: 1}}, // entirely a repeat — must not be mistaken for end of data
+		{{ID: 2}},
+	}
+	ctx := context.Background()
+	iter := NewIterator(ctx, pagedFetcher(pages), testItemTransformer, WithLimit[testItem](1),
+		WithDedup[testItem](func(it testItem) string { return strconv.Itoa(it.ID) }))
+
+	items, err := iter.All()
+	if err != nil {
+		t.Fatalf("All() error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("items = %+v, want IDs 1 and 2", items)
+	}
+}
+
+func TestIteratorWithGlobalSortOrdersWholeResultSet(t *testing.T) {
+	pages := [][]testItem{
+		{{ID: 1}, {ID: 5}},
+		{{ID: 3}, {ID: 2}, {ID: 4}},
+	}
+	ctx := context.Background()
+	iter := NewIterator(ctx, pagedFetcher(pages), testItemTransformer, WithLimit[testItem](2),
+		WithSort[testItem](byIDDesc), WithGlobalSort[testItem]())
+
+	items, err := iter.All()
+	if err != nil {
+		t.Fatalf("All() error: %v", err)
+	}
+	var gotIDs []int
+	for _, it := range items {
+		gotIDs = append(gotIDs, it.ID)
+	}
+	want := []int{5, 4, 3, 2, 1}
+	if fmt.Sprint(gotIDs) != fmt.Sprint(want) {
+		t.Errorf("ids = %v, want globally sorted %v", gotIDs, want)
+	}
+}
+
+func TestIteratorPauseUntilBlocksNextFetch(t *testing.T) {
+	var calls int32
+	fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *PaginationInfo, error) {
+		atomic.AddInt32(&calls, 1)
+		data, _ := json.Marshal([]testItem{{ID: offset + 1}})
+		return data, &PaginationInfo{Total: 2}, nil
+	}
+
+	iter := NewIterator(context.Background(), fetcher, testItemTransformer, WithLimit[testItem](1))
+	iter.PauseUntil(time.Now().Add(50 * time.Millisecond))
+
+	start := time.Now()
+	if !iter.Next() {
+		t.Fatalf("Next() = false, want true; Err() = %v", iter.Err())
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Next() returned after %v, want it to block for at least the pause duration", elapsed)
+	}
+	if iter.Item().ID != 1 {
+		t.Errorf("Item().ID = %d, want 1", iter.Item().ID)
+	}
+}
+
+func TestIteratorResumeEndsAnInFlightPauseEarly(t *testing.T) {
+	fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *PaginationInfo, error) {
+		data, _ := json.Marshal([]testItem{{ID: offset + 1}})
+		return data, &PaginationInfo{Total: 1}, nil
+	}
+
+	iter := NewIterator(context.Background(), fetcher, testItemTransformer, WithLimit[testItem](1))
+	iter.PauseUntil(time.Now().Add(time.Hour))
+
+	result := make(chan bool, 1)
+	go func() { result <- iter.Next() }()
+
+	time.Sleep(10 * time.Millisecond)
+	iter.Resume()
+
+	select {
+	case ok := <-result:
+		if !ok {
+			t.Fatalf("Next() = false, want true; Err() = %v", iter.Err())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Next() did not return after Resume()")
+	}
+}
+
+func TestIteratorPauseUntilCanceledByCtx(t *testing.T) {
+	fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *PaginationInfo, error) {
+		data, _ := json.Marshal([]testItem{{ID: 1}})
+		return data, &PaginationInfo{Total: 1}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	iter := NewIterator(ctx, fetcher, testItemTransformer)
+	iter.PauseUntil(time.Now().Add(time.Hour))
+
+	result := make(chan bool, 1)
+	go func() { result <- iter.Next() }()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case ok := <-result:
+		if ok {
+			t.Fatal("Next() = true, want false (ctx canceled during pause)")
+		}
+		if iter.Err() != context.Canceled {
+			t.Errorf("Err() = %v, want context.Canceled", iter.Err())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Next() did not return after ctx cancellation")
+	}
+}
@@ -0,0 +1,75 @@
+package base
+
+import (
+	"context"
+	"io"
+	"mime/multipart"
+)
+
+// PostMultipart posts a multipart/form-data body to path, streaming r
+// through an io.Pipe so the request body is never buffered in full.
+// fields are sent as plain form fields ahead of the file part; the file
+// itself is sent under fileField with the given filename. PostMultipart
+// is reusable anywhere a single file needs to accompany a handful of
+// form fields — exclusions import, scan/policy attachments, agent group
+// uploads, and similar.
+func (c *Client) PostMultipart(ctx context.Context, path string, fields map[string]string, fileField, filename string, r io.Reader, out interface{}) error {
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	go func() {
+		for name, value := range fields {
+			if err := writer.WriteField(name, value); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+
+		part, err := writer.CreateFormFile(fileField, filename)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+
+		if _, err := copyWithContext(ctx, part, r); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+
+		if err := writer.Close(); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		pw.Close()
+	}()
+
+	resp, err := c.Request(ctx).
+		SetHeader("Content-Type", writer.FormDataContentType()).
+		SetBody(pr).
+		SetResult(out).
+		Post(c.buildPath(path))
+	if err != nil {
+		return &ConnectionError{URL: c.baseURL, Message: "multipart upload failed", Err: err}
+	}
+	return c.checkResponse(resp)
+}
+
+// copyWithContext copies src to dst, aborting early if ctx is cancelled.
+func copyWithContext(ctx context.Context, dst io.Writer, src io.Reader) (int64, error) {
+	done := make(chan struct{})
+	var (
+		n   int64
+		err error
+	)
+	go func() {
+		n, err = io.Copy(dst, src)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return n, err
+	case <-ctx.Done():
+		return n, ctx.Err()
+	}
+}
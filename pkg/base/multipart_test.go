@@ -0,0 +1,53 @@
+package base
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPostMultipart(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+			t.Errorf("Content-Type = %q, want multipart/form-data", r.Header.Get("Content-Type"))
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm() error = %v", err)
+		}
+		if got := r.FormValue("network_id"); got != "net-1" {
+			t.Errorf("network_id field = %q, want %q", got, "net-1")
+		}
+		file, header, err := r.FormFile("Filename")
+		if err != nil {
+			t.Fatalf("FormFile() error = %v", err)
+		}
+		defer file.Close()
+		if header.Filename != "exclusions.csv" {
+			t.Errorf("uploaded filename = %q, want %q", header.Filename, "exclusions.csv")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"fileuploaded":"staged-name.csv"}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient("TEST", srv.URL)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var result struct {
+		Fileuploaded string `json:"fileuploaded"`
+	}
+	fields := map[string]string{"network_id": "net-1"}
+	err = c.PostMultipart(context.Background(), "file/upload", fields, "Filename", "exclusions.csv", strings.NewReader("name,members\nfoo,10.0.0.0/24\n"), &result)
+	if err != nil {
+		t.Fatalf("PostMultipart() error = %v", err)
+	}
+	if result.Fileuploaded != "staged-name.csv" {
+		t.Errorf("Fileuploaded = %q, want %q", result.Fileuploaded, "staged-name.csv")
+	}
+}
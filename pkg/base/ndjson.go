@@ -0,0 +1,77 @@
+package base
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// NDJSONEncoder writes a stream of values as newline-delimited JSON (one
+// compact object per line), letting callers pipe export chunks or large
+// list results straight to disk or a downstream consumer without ever
+// materializing the full result set in memory.
+type NDJSONEncoder struct {
+	enc *json.Encoder
+}
+
+// NewNDJSONEncoder returns an NDJSONEncoder writing to w.
+func NewNDJSONEncoder(w io.Writer) *NDJSONEncoder {
+	return &NDJSONEncoder{enc: json.NewEncoder(w)}
+}
+
+// Encode writes v as one line of JSON. json.Encoder already terminates
+// every call with a newline, so successive Encode calls produce valid
+// NDJSON.
+func (e *NDJSONEncoder) Encode(v any) error {
+	return e.enc.Encode(v)
+}
+
+// DecodeNDJSONStream reads r line by line, unmarshaling each non-blank
+// line as T and pushing it onto the returned channel as soon as it's
+// decoded, rather than buffering the whole body first. Both channels
+// close once r is exhausted, a line fails to decode, or ctx is done; the
+// error channel carries at most one error.
+func DecodeNDJSONStream[T any](ctx context.Context, r io.Reader) (<-chan T, <-chan error) {
+	out := make(chan T)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errs)
+
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			var item T
+			if err := json.Unmarshal(line, &item); err != nil {
+				sendErrCtx(ctx, errs, err)
+				return
+			}
+			select {
+			case out <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			sendErrCtx(ctx, errs, err)
+		}
+	}()
+
+	return out, errs
+}
+
+// sendErrCtx delivers err on errs without blocking forever if ctx ends
+// first.
+func sendErrCtx(ctx context.Context, errs chan<- error, err error) {
+	select {
+	case errs <- err:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,67 @@
+package base
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+type ndjsonTestRecord struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestNDJSONEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewNDJSONEncoder(&buf)
+	if err := enc.Encode(ndjsonTestRecord{ID: 1, Name: "a"}); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if err := enc.Encode(ndjsonTestRecord{ID: 2, Name: "b"}); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), buf.String())
+	}
+}
+
+func TestDecodeNDJSONStream(t *testing.T) {
+	body := "{\"id\":1,\"name\":\"a\"}\n\n{\"id\":2,\"name\":\"b\"}\n"
+	out, errs := DecodeNDJSONStream[ndjsonTestRecord](context.Background(), strings.NewReader(body))
+
+	var got []ndjsonTestRecord
+	for item := range out {
+		got = append(got, item)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != 1 || got[1].ID != 2 {
+		t.Fatalf("got %+v, want 2 records", got)
+	}
+}
+
+func TestDecodeNDJSONStreamMalformedLine(t *testing.T) {
+	body := "{\"id\":1}\nnot-json\n"
+	out, errs := DecodeNDJSONStream[ndjsonTestRecord](context.Background(), strings.NewReader(body))
+	for range out {
+	}
+	if err := <-errs; err == nil {
+		t.Fatal("expected an error for the malformed line")
+	}
+}
+
+func TestDecodeNDJSONStreamRespectsContextCancellation(t *testing.T) {
+	body := "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n"
+	ctx, cancel := context.WithCancel(context.Background())
+	out, errs := DecodeNDJSONStream[ndjsonTestRecord](ctx, strings.NewReader(body))
+
+	<-out
+	cancel()
+	for range out {
+	}
+	<-errs
+}
@@ -0,0 +1,127 @@
+package base
+
+import (
+	"io"
+	"log"
+	"time"
+)
+
+// ProgressReporter receives progress notifications for long-running
+// operations such as paginated iteration (base.WithProgress) or streamed
+// downloads (base.NewProgressReader). Implementations must be safe to call
+// from a single goroutine at a time; the SDK never calls them concurrently
+// for the same operation.
+type ProgressReporter interface {
+	// OnStart is called once, before any progress is reported, with the
+	// total size or item count if known, or -1 if it isn't.
+	OnStart(total int64)
+	// OnProgress is called as work completes, with the cumulative amount
+	// done so far and the same total passed to OnStart.
+	OnProgress(current, total int64)
+	// OnFinish is called exactly once when the operation completes, with
+	// a non-nil err if it failed.
+	OnFinish(err error)
+}
+
+// noopProgressReporter discards all progress notifications.
+type noopProgressReporter struct{}
+
+func (noopProgressReporter) OnStart(total int64)             {}
+func (noopProgressReporter) OnProgress(current, total int64) {}
+func (noopProgressReporter) OnFinish(err error)              {}
+
+// NoopProgressReporter is the default ProgressReporter used when none is
+// configured; it does nothing.
+var NoopProgressReporter ProgressReporter = noopProgressReporter{}
+
+// LogProgressReporter reports progress through the standard library "log"
+// package, writing at most one OnProgress line per Interval so that fast
+// iteration or small chunk sizes don't flood the log.
+type LogProgressReporter struct {
+	// Prefix is prepended to every log line, e.g. "scans export: ".
+	Prefix string
+	// Interval is the minimum time between OnProgress log lines. Zero
+	// uses a default of one second.
+	Interval time.Duration
+
+	last time.Time
+}
+
+// OnStart implements ProgressReporter.
+func (r *LogProgressReporter) OnStart(total int64) {
+	if total >= 0 {
+		log.Printf("%sstarting, total=%d", r.Prefix, total)
+	} else {
+		log.Printf("%sstarting", r.Prefix)
+	}
+}
+
+// OnProgress implements ProgressReporter.
+func (r *LogProgressReporter) OnProgress(current, total int64) {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	if !r.last.IsZero() && time.Since(r.last) < interval {
+		return
+	}
+	r.last = time.Now()
+	if total >= 0 {
+		log.Printf("%sprogress %d/%d", r.Prefix, current, total)
+	} else {
+		log.Printf("%sprogress %d", r.Prefix, current)
+	}
+}
+
+// OnFinish implements ProgressReporter.
+func (r *LogProgressReporter) OnFinish(err error) {
+	if err != nil {
+		log.Printf("%sfailed: %v", r.Prefix, err)
+		return
+	}
+	log.Printf("%sdone", r.Prefix)
+}
+
+// progressReader wraps an io.Reader, reporting cumulative bytes read to a
+// ProgressReporter and firing OnFinish when the wrapped reader returns an
+// error (io.EOF counts as a successful finish).
+type progressReader struct {
+	r        io.Reader
+	size     int64
+	read     int64
+	reporter ProgressReporter
+	finished bool
+}
+
+// NewProgressReader wraps r so every Read reports cumulative progress
+// through reporter, analogous to the pb.ProxyReader pattern: size is the
+// total number of bytes expected, or -1 if unknown, and is passed through
+// unchanged to OnStart and every OnProgress call. Use it to drive a
+// terminal progress bar, structured logger, or Prometheus counter while
+// streaming a file or export download, without the SDK depending on any
+// specific UI library.
+func NewProgressReader(r io.Reader, size int64, reporter ProgressReporter) io.Reader {
+	if reporter == nil {
+		reporter = NoopProgressReporter
+	}
+	reporter.OnStart(size)
+	return &progressReader{r: r, size: size, reporter: reporter}
+}
+
+// Read implements io.Reader.
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.r.Read(buf)
+	if n > 0 {
+		p.read += int64(n)
+		p.reporter.OnProgress(p.read, p.size)
+	}
+	if err != nil && !p.finished {
+		p.finished = true
+		if err == io.EOF {
+			p.reporter.OnFinish(nil)
+		} else {
+			p.reporter.OnFinish(err)
+		}
+	}
+	return n, err
+}
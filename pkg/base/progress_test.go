@@ -0,0 +1,123 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type recordingProgressReporter struct {
+	starts    []int64
+	progress  [][2]int64
+	finishErr error
+	finished  bool
+}
+
+func (r *recordingProgressReporter) OnStart(total int64) {
+	r.starts = append(r.starts, total)
+}
+
+func (r *recordingProgressReporter) OnProgress(current, total int64) {
+	r.progress = append(r.progress, [2]int64{current, total})
+}
+
+func (r *recordingProgressReporter) OnFinish(err error) {
+	r.finished = true
+	r.finishErr = err
+}
+
+func TestIteratorWithProgress(t *testing.T) {
+	allItems := []testItem{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *PaginationInfo, error) {
+		end := offset + limit
+		if end > len(allItems) {
+			end = len(allItems)
+		}
+		items := allItems[offset:end]
+		data, _ := json.Marshal(items)
+		return data, &PaginationInfo{Total: len(allItems), Limit: limit, Offset: offset}, nil
+	}
+	transformer := func(data json.RawMessage) ([]testItem, error) {
+		var items []testItem
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+		return items, nil
+	}
+
+	reporter := &recordingProgressReporter{}
+	it := NewIterator(context.Background(), fetcher, transformer, WithLimit[testItem](2), WithProgress[testItem](reporter))
+
+	items, err := it.All()
+	if err != nil {
+		t.Fatalf("All() returned error: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(items))
+	}
+
+	if len(reporter.starts) != 1 || reporter.starts[0] != 3 {
+		t.Fatalf("expected a single OnStart(3), got %v", reporter.starts)
+	}
+	if len(reporter.progress) != 2 {
+		t.Fatalf("expected 2 OnProgress calls, got %v", reporter.progress)
+	}
+	if reporter.progress[len(reporter.progress)-1][0] != 3 {
+		t.Fatalf("expected final OnProgress current=3, got %v", reporter.progress)
+	}
+	if !reporter.finished || reporter.finishErr != nil {
+		t.Fatalf("expected a single successful OnFinish, got finished=%v err=%v", reporter.finished, reporter.finishErr)
+	}
+}
+
+func TestIteratorWithProgressFetchError(t *testing.T) {
+	wantErr := errors.New("boom")
+	fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *PaginationInfo, error) {
+		return nil, nil, wantErr
+	}
+	transformer := func(data json.RawMessage) ([]testItem, error) {
+		return nil, nil
+	}
+
+	reporter := &recordingProgressReporter{}
+	it := NewIterator(context.Background(), fetcher, transformer, WithProgress[testItem](reporter))
+
+	if it.Next() {
+		t.Fatal("expected Next() to return false on fetch error")
+	}
+	if !errors.Is(it.Err(), wantErr) {
+		t.Fatalf("expected wrapped fetch error, got %v", it.Err())
+	}
+	if !reporter.finished || reporter.finishErr != wantErr {
+		t.Fatalf("expected OnFinish(wantErr), got finished=%v err=%v", reporter.finished, reporter.finishErr)
+	}
+}
+
+func TestNewProgressReader(t *testing.T) {
+	reporter := &recordingProgressReporter{}
+	src := strings.NewReader("hello world")
+	r := NewProgressReader(src, int64(src.Len()), reporter)
+
+	buf := make([]byte, 4)
+	total := 0
+	for {
+		n, err := r.Read(buf)
+		total += n
+		if err != nil {
+			break
+		}
+	}
+
+	if len(reporter.starts) != 1 || reporter.starts[0] != 11 {
+		t.Fatalf("expected OnStart(11), got %v", reporter.starts)
+	}
+	if total != 11 {
+		t.Fatalf("expected to read 11 bytes, got %d", total)
+	}
+	if !reporter.finished || reporter.finishErr != nil {
+		t.Fatalf("expected a successful OnFinish, got finished=%v err=%v", reporter.finished, reporter.finishErr)
+	}
+}
@@ -0,0 +1,67 @@
+package base
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimiter governs the pace of outgoing requests. Implementations are
+// consulted before every request (including retries) and are notified of
+// the outcome so adaptive strategies can react to throttling signals.
+type RateLimiter interface {
+	// Wait blocks until the caller is permitted to send a request, or
+	// until ctx is done.
+	Wait(ctx context.Context) error
+	// OnResult is called after a request completes. throttled is true if
+	// the response was a 429 or 503; retryAfter carries the delay the
+	// server asked for, as APIError.RetryAfter parses it from Retry-After
+	// or X-RateLimit-Reset (zero if neither gave one).
+	OnResult(throttled bool, retryAfter time.Duration)
+}
+
+// TokenReporter is implemented by a RateLimiter that can report how many
+// requests it would currently admit immediately, without blocking. The
+// Client checks for it via a type assertion on the installed RateLimiter
+// so that Metrics.OnTokensAvailable can observe headroom without base
+// depending on any concrete limiter implementation (those live in
+// pkg/tio, e.g. *tio.TokenBucketLimiter, which base can't import).
+type TokenReporter interface {
+	Tokens() int
+}
+
+// Metrics are optional observability hooks invoked around each request,
+// mirroring the shape of Prometheus counters/histograms without pulling in
+// a metrics dependency.
+type Metrics struct {
+	// OnRequest is called once before a request is sent (including each
+	// retry attempt).
+	OnRequest func()
+	// OnThrottle is called before a retry whose delay the server asked
+	// for (Retry-After, or X-RateLimit-Reset as APIError.RetryAfter
+	// describes), with that delay. Waits inside the RateLimiter are not
+	// reported through it.
+	OnThrottle func(wait time.Duration)
+	// OnRetry is called before a retry attempt, with the attempt number
+	// (2 for the first retry, and so on).
+	OnRetry func(attempt int)
+	// OnCacheHit is called by a pkg/base/cache Cache wired to these hooks
+	// when a Get finds a live entry, with the cache's name (e.g.
+	// "tio.tag_category").
+	OnCacheHit func(cacheName string)
+	// OnCacheMiss is called when a Get finds no entry, or one that has
+	// expired.
+	OnCacheMiss func(cacheName string)
+	// OnCacheEvict is called when a Put evicts an entry to stay within
+	// the cache's size limit.
+	OnCacheEvict func(cacheName string)
+	// OnRateLimited is called when a 429 Too Many Requests response is
+	// about to be retried, with the delay the Client waits first (from
+	// Retry-After, X-RateLimit-Reset, or the fallback backoff policy). A
+	// 429 that is not retried does not call it.
+	OnRateLimited func(retryAfter time.Duration)
+	// OnTokensAvailable reports a rate limiter's current headroom (tokens
+	// immediately available) after each request it admits. It's only
+	// called when the installed RateLimiter also implements TokenReporter
+	// — e.g. a *tio.TokenBucketLimiter installed via tio.WithRateLimit.
+	OnTokensAvailable func(tokens int)
+}
@@ -0,0 +1,35 @@
+package base
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimitReset(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{"seconds delta", "30", 30 * time.Second},
+		{"zero", "0", 0},
+		{"empty", "", 0},
+		{"garbage", "not-a-number", 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := parseRateLimitReset(tc.value); got != tc.want {
+				t.Errorf("parseRateLimitReset(%q) = %v, want %v", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseRateLimitResetEpochTimestamp(t *testing.T) {
+	future := time.Now().Add(45 * time.Second).Unix()
+	got := parseRateLimitReset(strconv.FormatInt(future, 10))
+	if got <= 0 || got > 46*time.Second {
+		t.Fatalf("parseRateLimitReset(epoch 45s out) = %v, want ~45s", got)
+	}
+}
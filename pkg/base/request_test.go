@@ -0,0 +1,111 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestDoUnwrapsEnvelope(t *testing.T) {
+	var receivedPath string
+	var receivedQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedPath = r.URL.Path
+		receivedQuery = r.URL.Query().Get("limit")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"users": []map[string]string{{"name": "alice"}, {"name": "bob"}},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient("TEST", server.URL)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var users []struct {
+		Name string `json:"name"`
+	}
+	_, err = client.NewRequest().Method("GET").Path("users").Query("limit", 50).Envelope("users").Do(context.Background(), &users)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if receivedPath != "/users" {
+		t.Errorf("path = %q, want /users", receivedPath)
+	}
+	if receivedQuery != "50" {
+		t.Errorf("limit query = %q, want 50", receivedQuery)
+	}
+	if len(users) != 2 || users[0].Name != "alice" || users[1].Name != "bob" {
+		t.Errorf("users = %+v, want alice/bob", users)
+	}
+}
+
+func TestRequestPathEscapesArguments(t *testing.T) {
+	var receivedEscapedPath string
+	var receivedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedEscapedPath = r.URL.EscapedPath()
+		receivedPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient("TEST", server.URL)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var result interface{}
+	_, err = client.NewRequest().Method("GET").Path("networks/%s/scanners", "weird/uuid").Do(context.Background(), &result)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	// The slash inside the uuid argument must travel over the wire
+	// escaped (%2F) — net/http decodes it back into the server's
+	// r.URL.Path, so the escaped form on the request line is what
+	// actually proves Path did its job.
+	if receivedEscapedPath != "/networks/weird%2Fuuid/scanners" {
+		t.Errorf("escaped path = %q, want /networks/weird%%2Fuuid/scanners", receivedEscapedPath)
+	}
+	if receivedPath != "/networks/weird/uuid/scanners" {
+		t.Errorf("decoded path = %q, want /networks/weird/uuid/scanners", receivedPath)
+	}
+}
+
+func TestRequestDoMissingEnvelopeFieldErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"groups": []interface{}{}})
+	}))
+	defer server.Close()
+
+	client, err := NewClient("TEST", server.URL)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var out []interface{}
+	_, err = client.NewRequest().Method("GET").Path("users").Envelope("users").Do(context.Background(), &out)
+	if err == nil {
+		t.Fatal("Do() error = nil, want error for missing envelope field")
+	}
+}
+
+func TestRequestDoRejectsMissingMethodOrPath(t *testing.T) {
+	client, err := NewClient("TEST", "http://example.invalid")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.NewRequest().Path("users").Do(context.Background(), nil); err == nil {
+		t.Error("Do() with no Method = nil error, want error")
+	}
+	if _, err := client.NewRequest().Method("GET").Do(context.Background(), nil); err == nil {
+		t.Error("Do() with no Path = nil error, want error")
+	}
+}
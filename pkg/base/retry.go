@@ -0,0 +1,111 @@
+package base
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy configures how the Client retries a failed request. It is
+// installed with WithRetryPolicy; the zero value is not valid on its own,
+// use DefaultRetryPolicy as a starting point.
+//
+// On 429/503 responses the Client always honors the server's Retry-After
+// header first, regardless of this policy. InitialBackoff, MaxBackoff,
+// Multiplier, and Jitter only govern the fallback backoff used for other
+// retryable errors (other 5xx responses, transport failures): the n-th
+// retry waits rand(0, min(MaxBackoff, InitialBackoff*Multiplier^(n-1)))
+// when Jitter is true, or exactly that cap when it's false.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first,
+	// e.g. 3 means up to 2 retries.
+	MaxAttempts int
+	// InitialBackoff is the base delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the computed delay for any single retry.
+	MaxBackoff time.Duration
+	// Multiplier is the exponential growth factor applied per attempt.
+	Multiplier float64
+	// Jitter, when true, randomizes each delay in [0, cap] (full jitter)
+	// instead of always waiting the full capped delay.
+	Jitter bool
+	// RetryOn decides whether a given APIError is eligible for retry. If
+	// nil, the Client defaults to apiErr.Retryable() (429s and 5xx).
+	RetryOn func(*APIError) bool
+}
+
+// DefaultRetryPolicy is used by NewClient when WithRetryPolicy isn't
+// given and matches the historical WithRetries/WithBackoff behavior.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    DefaultRetries + 1,
+	InitialBackoff: DefaultBackoff,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     2,
+	Jitter:         true,
+}
+
+// fullJitterBackoff computes the delay before the given attempt (1-based:
+// 1 is the wait before the first retry) under policy, implementing the
+// "full jitter" algorithm: rand(0, min(cap, base*multiplier^(attempt-1))),
+// so attempt 1 waits at most base.
+func fullJitterBackoff(policy RetryPolicy, attempt int) time.Duration {
+	base := policy.InitialBackoff
+	if base <= 0 {
+		base = DefaultBackoff
+	}
+	maxWait := policy.MaxBackoff
+	if maxWait <= 0 {
+		maxWait = 30 * time.Second
+	}
+	mult := policy.Multiplier
+	if mult <= 0 {
+		mult = 2
+	}
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	capped := float64(base) * math.Pow(mult, float64(attempt-1))
+	if capped > float64(maxWait) || capped < 0 {
+		capped = float64(maxWait)
+	}
+	if capped <= 0 {
+		return 0
+	}
+	if !policy.Jitter {
+		return time.Duration(capped)
+	}
+	return time.Duration(rand.Int63n(int64(capped) + 1))
+}
+
+// isIdempotentMethod reports whether method is safe to retry without a
+// caller opt-in. POST and PATCH are excluded since blindly repeating them
+// can duplicate side effects (e.g. creating a resource twice).
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryableCtxKey marks a context as opting a non-idempotent request into
+// automatic retry.
+type retryableCtxKey struct{}
+
+// WithRetryable marks ctx so a non-idempotent request (POST, PATCH) issued
+// with it is eligible for automatic retry under the Client's RetryPolicy.
+// GET, PUT, DELETE, HEAD, and OPTIONS are retried by default; use this
+// per-call when you know a particular POST is safe to repeat.
+func WithRetryable(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryableCtxKey{}, true)
+}
+
+// isRetryableCtx reports whether ctx was marked via WithRetryable.
+func isRetryableCtx(ctx context.Context) bool {
+	v, _ := ctx.Value(retryableCtxKey{}).(bool)
+	return v
+}
@@ -0,0 +1,74 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFullJitterBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+		Jitter:         false,
+	}
+
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{5, time.Second}, // capped
+	}
+	for _, tc := range cases {
+		got := fullJitterBackoff(policy, tc.attempt)
+		if got != tc.want {
+			t.Errorf("fullJitterBackoff(attempt=%d) = %v, want %v", tc.attempt, got, tc.want)
+		}
+	}
+}
+
+func TestFullJitterBackoffJitterIsBounded(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+		Jitter:         true,
+	}
+	for i := 0; i < 50; i++ {
+		got := fullJitterBackoff(policy, 3)
+		if got < 0 || got > 400*time.Millisecond {
+			t.Fatalf("jittered backoff %v out of bounds [0, 400ms]", got)
+		}
+	}
+}
+
+func TestIsIdempotentMethod(t *testing.T) {
+	idempotent := []string{http.MethodGet, http.MethodPut, http.MethodDelete, http.MethodHead, http.MethodOptions}
+	for _, m := range idempotent {
+		if !isIdempotentMethod(m) {
+			t.Errorf("expected %s to be idempotent", m)
+		}
+	}
+	notIdempotent := []string{http.MethodPost, http.MethodPatch}
+	for _, m := range notIdempotent {
+		if isIdempotentMethod(m) {
+			t.Errorf("expected %s to not be idempotent", m)
+		}
+	}
+}
+
+func TestWithRetryableMarksContext(t *testing.T) {
+	ctx := context.Background()
+	if isRetryableCtx(ctx) {
+		t.Fatal("expected unmarked context to not be retryable")
+	}
+	ctx = WithRetryable(ctx)
+	if !isRetryableCtx(ctx) {
+		t.Fatal("expected WithRetryable to mark the context")
+	}
+}
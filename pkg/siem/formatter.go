@@ -0,0 +1,161 @@
+// Package siem streams Tenable.io export data (assets, vulnerabilities,
+// compliance findings) into SIEM-friendly formats and sinks.
+package siem
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Record is a single finding pulled off an export chunk, keyed by the raw
+// field names produced by the Tenable.io export APIs.
+type Record map[string]interface{}
+
+// FieldMap renames source fields to destination fields during formatting,
+// e.g. {"plugin.severity": "cef.severity"}. Nested fields are addressed
+// with dot-separated paths.
+type FieldMap map[string]string
+
+// Formatter renders a Record into a wire-ready line (without trailing
+// newline). Implementations should be safe for concurrent use.
+type Formatter interface {
+	Format(rec Record) (string, error)
+}
+
+// lookup resolves a dot-separated field path within a Record.
+func lookup(rec Record, path string) (interface{}, bool) {
+	parts := strings.Split(path, ".")
+	var cur interface{} = map[string]interface{}(rec)
+	for _, part := range parts {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		cur, ok = m[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// applyFieldMap produces a flat map of destination field -> value for the
+// fields named in fm. Missing source fields are skipped.
+func applyFieldMap(rec Record, fm FieldMap) map[string]interface{} {
+	out := make(map[string]interface{}, len(fm))
+	for src, dst := range fm {
+		if v, ok := lookup(rec, src); ok {
+			out[dst] = v
+		}
+	}
+	return out
+}
+
+// NDJSONFormatter renders each record as a single line of JSON.
+type NDJSONFormatter struct{}
+
+// Format implements Formatter.
+func (NDJSONFormatter) Format(rec Record) (string, error) {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// CEFFormatter renders records as ArcSight Common Event Format lines.
+type CEFFormatter struct {
+	Vendor   string
+	Product  string
+	Version  string
+	FieldMap FieldMap
+}
+
+// Format implements Formatter, producing:
+//
+//	CEF:0|Vendor|Product|Version|signatureID|name|severity|extension
+func (f CEFFormatter) Format(rec Record) (string, error) {
+	vendor, product, version := orDefault(f.Vendor, "Tenable"), orDefault(f.Product, "Tenable.io"), orDefault(f.Version, "1.0")
+	sigID, _ := lookup(rec, "plugin.id")
+	name, _ := lookup(rec, "plugin.name")
+	severity, _ := lookup(rec, "severity_id")
+
+	fields := applyFieldMap(rec, f.FieldMap)
+	var ext strings.Builder
+	for k, v := range fields {
+		fmt.Fprintf(&ext, "%s=%s ", cefEscape(k), cefEscape(fmt.Sprintf("%v", v)))
+	}
+
+	return fmt.Sprintf("CEF:0|%s|%s|%s|%v|%v|%v|%s",
+		cefEscapeHeader(vendor), cefEscapeHeader(product), cefEscapeHeader(version),
+		sigID, name, severity, strings.TrimSpace(ext.String())), nil
+}
+
+func cefEscapeHeader(s string) string {
+	r := strings.NewReplacer("\\", "\\\\", "|", "\\|")
+	return r.Replace(s)
+}
+
+func cefEscape(s string) string {
+	r := strings.NewReplacer("\\", "\\\\", "=", "\\=", "\n", "\\n")
+	return r.Replace(s)
+}
+
+// LEEFFormatter renders records as IBM QRadar Log Event Extended Format
+// lines.
+type LEEFFormatter struct {
+	Vendor   string
+	Product  string
+	Version  string
+	FieldMap FieldMap
+}
+
+// Format implements Formatter, producing:
+//
+//	LEEF:2.0|Vendor|Product|Version|eventID|key1=val1<TAB>key2=val2
+func (f LEEFFormatter) Format(rec Record) (string, error) {
+	vendor, product, version := orDefault(f.Vendor, "Tenable"), orDefault(f.Product, "Tenable.io"), orDefault(f.Version, "1.0")
+	eventID, _ := lookup(rec, "plugin.id")
+
+	fields := applyFieldMap(rec, f.FieldMap)
+	pairs := make([]string, 0, len(fields))
+	for k, v := range fields {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", k, v))
+	}
+
+	return fmt.Sprintf("LEEF:2.0|%s|%s|%s|%v|%s", vendor, product, version, eventID, strings.Join(pairs, "\t")), nil
+}
+
+// ECSFormatter renders records as Elastic Common Schema JSON documents.
+type ECSFormatter struct {
+	FieldMap FieldMap
+}
+
+// Format implements Formatter.
+func (f ECSFormatter) Format(rec Record) (string, error) {
+	doc := map[string]interface{}{
+		"@timestamp": time.Now().UTC().Format(time.RFC3339),
+		"event": map[string]interface{}{
+			"kind":     "finding",
+			"category": []string{"vulnerability"},
+		},
+	}
+	for k, v := range applyFieldMap(rec, f.FieldMap) {
+		doc[k] = v
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
@@ -0,0 +1,127 @@
+package siem
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// CheckpointStore persists the last successfully forwarded export chunk ID
+// per export type, so an interrupted Run resumes rather than re-sends
+// chunks already delivered to the sink.
+type CheckpointStore interface {
+	Load(ctx context.Context, exportType string) (lastChunk int, err error)
+	Save(ctx context.Context, exportType string, chunk int) error
+}
+
+// MemoryCheckpointStore is an in-process CheckpointStore, primarily useful
+// for tests and one-shot forwarding runs.
+type MemoryCheckpointStore struct {
+	mu sync.Mutex
+	m  map[string]int
+}
+
+// Load implements CheckpointStore. It returns -1 if no checkpoint exists.
+func (s *MemoryCheckpointStore) Load(_ context.Context, exportType string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		return -1, nil
+	}
+	chunk, ok := s.m[exportType]
+	if !ok {
+		return -1, nil
+	}
+	return chunk, nil
+}
+
+// Save implements CheckpointStore.
+func (s *MemoryCheckpointStore) Save(_ context.Context, exportType string, chunk int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		s.m = make(map[string]int)
+	}
+	s.m[exportType] = chunk
+	return nil
+}
+
+// ChunkSource downloads a single export chunk, returning its raw JSON
+// array body. ExportsAPI's AssetsExportChunk/VulnsExportChunk/
+// ComplianceExportChunk all satisfy this once bound to an export UUID.
+type ChunkSource func(ctx context.Context, chunkID int) (io.Reader, error)
+
+// Pipeline streams export chunks through a Formatter into a Sink, batching
+// formatted lines and checkpointing the last chunk delivered.
+type Pipeline struct {
+	ExportType string
+	Formatter  Formatter
+	Sink       Sink
+	Checkpoint CheckpointStore
+	BatchSize  int
+}
+
+// Process forwards every chunk in chunkIDs (in order) that hasn't already
+// been checkpointed, using fetch to download each chunk's body.
+func (p *Pipeline) Process(ctx context.Context, chunkIDs []int, fetch ChunkSource) error {
+	batchSize := p.BatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	lastChunk := -1
+	if p.Checkpoint != nil {
+		last, err := p.Checkpoint.Load(ctx, p.ExportType)
+		if err != nil {
+			return fmt.Errorf("siem: load checkpoint for %s: %w", p.ExportType, err)
+		}
+		lastChunk = last
+	}
+
+	for _, chunkID := range chunkIDs {
+		if chunkID <= lastChunk {
+			continue
+		}
+
+		reader, err := fetch(ctx, chunkID)
+		if err != nil {
+			return fmt.Errorf("siem: fetch %s chunk %d: %w", p.ExportType, chunkID, err)
+		}
+
+		var records []Record
+		if err := json.NewDecoder(reader).Decode(&records); err != nil {
+			return fmt.Errorf("siem: decode %s chunk %d: %w", p.ExportType, chunkID, err)
+		}
+
+		batch := make([]string, 0, batchSize)
+		for _, rec := range records {
+			line, err := p.Formatter.Format(rec)
+			if err != nil {
+				return fmt.Errorf("siem: format %s record: %w", p.ExportType, err)
+			}
+			batch = append(batch, line)
+			if len(batch) >= batchSize {
+				if err := p.Sink.Write(ctx, batch); err != nil {
+					return err
+				}
+				batch = batch[:0]
+			}
+		}
+		if len(batch) > 0 {
+			if err := p.Sink.Write(ctx, batch); err != nil {
+				return err
+			}
+		}
+
+		if p.Checkpoint != nil {
+			if err := p.Checkpoint.Save(ctx, p.ExportType, chunkID); err != nil {
+				return fmt.Errorf("siem: save checkpoint for %s chunk %d: %w", p.ExportType, chunkID, err)
+			}
+		}
+		lastChunk = chunkID
+	}
+
+	return nil
+}
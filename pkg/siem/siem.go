@@ -0,0 +1,91 @@
+package siem
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio"
+)
+
+// Config configures a long-running forwarder run via Run.
+type Config struct {
+	// ExportType selects which export to forward: "assets", "vulns", or
+	// "compliance".
+	ExportType string
+	// Formatter renders each exported record into a wire-ready line.
+	Formatter Formatter
+	// Sink delivers formatted lines to their destination.
+	Sink Sink
+	// Checkpoint persists the last chunk forwarded, if set, so a
+	// restarted Run resumes instead of re-forwarding everything.
+	Checkpoint CheckpointStore
+	// BatchSize caps how many lines are handed to Sink.Write at once.
+	BatchSize int
+	// PollInterval controls how often Run polls export status while
+	// waiting for chunks to become available. Defaults to 5s.
+	PollInterval time.Duration
+	// AssetsRequest, VulnsRequest, ComplianceRequest configure the
+	// underlying export; set the one matching ExportType.
+	AssetsRequest     *tio.ExportAssetsRequest
+	VulnsRequest      *tio.ExportVulnsRequest
+	ComplianceRequest *tio.ExportComplianceRequest
+}
+
+// Run drives an ExportsAPI export to completion and forwards every chunk
+// through a Pipeline built from cfg. It blocks until the export finishes
+// or ctx is cancelled, and is safe to call repeatedly (e.g. on a timer)
+// for periodic forwarding runs, since a Checkpoint skips already-sent
+// chunks even across export UUIDs as long as chunk IDs are monotonic
+// within a single run.
+func Run(ctx context.Context, client *tio.Client, cfg Config) error {
+	pollInterval := cfg.PollInterval
+	if pollInterval == 0 {
+		pollInterval = 5 * time.Second
+	}
+
+	var (
+		exportUUID string
+		err        error
+		fetch      ChunkSource
+	)
+
+	switch cfg.ExportType {
+	case "assets":
+		exportUUID, err = client.Exports.AssetsExport(ctx, cfg.AssetsRequest)
+		fetch = func(ctx context.Context, chunkID int) (io.Reader, error) {
+			return client.Exports.AssetsExportChunk(ctx, exportUUID, chunkID)
+		}
+	case "vulns":
+		exportUUID, err = client.Exports.VulnsExport(ctx, cfg.VulnsRequest)
+		fetch = func(ctx context.Context, chunkID int) (io.Reader, error) {
+			return client.Exports.VulnsExportChunk(ctx, exportUUID, chunkID)
+		}
+	case "compliance":
+		exportUUID, err = client.Exports.ComplianceExport(ctx, cfg.ComplianceRequest)
+		fetch = func(ctx context.Context, chunkID int) (io.Reader, error) {
+			return client.Exports.ComplianceExportChunk(ctx, exportUUID, chunkID)
+		}
+	default:
+		return fmt.Errorf("siem: unsupported export type %q", cfg.ExportType)
+	}
+	if err != nil {
+		return fmt.Errorf("siem: start %s export: %w", cfg.ExportType, err)
+	}
+
+	status, err := client.Exports.WaitForExport(ctx, cfg.ExportType, exportUUID, pollInterval)
+	if err != nil {
+		return fmt.Errorf("siem: wait for %s export %s: %w", cfg.ExportType, exportUUID, err)
+	}
+
+	pipeline := &Pipeline{
+		ExportType: cfg.ExportType,
+		Formatter:  cfg.Formatter,
+		Sink:       cfg.Sink,
+		Checkpoint: cfg.Checkpoint,
+		BatchSize:  cfg.BatchSize,
+	}
+
+	return pipeline.Process(ctx, status.ChunksAvailable, fetch)
+}
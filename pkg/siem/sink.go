@@ -0,0 +1,247 @@
+package siem
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// Sink delivers formatted lines to a destination. Implementations should
+// be safe for concurrent use and must support Close for graceful shutdown.
+type Sink interface {
+	Write(ctx context.Context, lines []string) error
+	Close() error
+}
+
+// SyslogNetwork selects the transport SyslogSink dials.
+type SyslogNetwork string
+
+// Supported SyslogSink transports, per RFC 5424 transport mappings.
+const (
+	SyslogUDP SyslogNetwork = "udp"
+	SyslogTCP SyslogNetwork = "tcp"
+	SyslogTLS SyslogNetwork = "tls"
+)
+
+// SyslogSink forwards lines to a syslog collector over UDP, TCP, or TLS.
+type SyslogSink struct {
+	Network   SyslogNetwork
+	Addr      string
+	TLSConfig *tls.Config
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (s *SyslogSink) dial(ctx context.Context) (net.Conn, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn != nil {
+		return s.conn, nil
+	}
+
+	var d net.Dialer
+	var conn net.Conn
+	var err error
+
+	switch s.Network {
+	case SyslogTLS:
+		conn, err = tls.DialWithDialer(&d, "tcp", s.Addr, s.TLSConfig)
+	case SyslogTCP:
+		conn, err = d.DialContext(ctx, "tcp", s.Addr)
+	default:
+		conn, err = d.DialContext(ctx, "udp", s.Addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("siem: dial syslog %s: %w", s.Addr, err)
+	}
+
+	s.conn = conn
+	return conn, nil
+}
+
+// Write implements Sink, sending each line as an RFC 5424 message body
+// terminated with a trailing newline.
+func (s *SyslogSink) Write(ctx context.Context, lines []string) error {
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		if _, err := conn.Write([]byte(line + "\n")); err != nil {
+			s.mu.Lock()
+			s.conn = nil
+			s.mu.Unlock()
+			return fmt.Errorf("siem: write syslog message: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close implements Sink.
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// FileSink appends lines to a file, rotating to a numbered backup once the
+// file exceeds MaxBytes.
+type FileSink struct {
+	Path     string
+	MaxBytes int64
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+func (s *FileSink) open() error {
+	if s.file != nil {
+		return nil
+	}
+	f, err := os.OpenFile(s.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("siem: open sink file %s: %w", s.Path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	s.file = f
+	s.size = info.Size()
+	return nil
+}
+
+// Write implements Sink.
+func (s *FileSink) Write(ctx context.Context, lines []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.open(); err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		if s.MaxBytes > 0 && s.size+int64(len(line))+1 > s.MaxBytes {
+			if err := s.rotateLocked(); err != nil {
+				return err
+			}
+		}
+		n, err := s.file.WriteString(line + "\n")
+		if err != nil {
+			return fmt.Errorf("siem: write sink file %s: %w", s.Path, err)
+		}
+		s.size += int64(n)
+	}
+	return nil
+}
+
+func (s *FileSink) rotateLocked() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(s.Path, s.Path+".1"); err != nil {
+		return fmt.Errorf("siem: rotate sink file %s: %w", s.Path, err)
+	}
+	s.file = nil
+	s.size = 0
+	return s.open()
+}
+
+// Close implements Sink.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// HTTPSink posts batches of lines, newline-joined, to an HTTP Event
+// Collector-style endpoint.
+type HTTPSink struct {
+	URL        string
+	Headers    map[string]string
+	HTTPClient *http.Client
+}
+
+// Write implements Sink.
+func (s *HTTPSink) Write(ctx context.Context, lines []string) error {
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	body := bytes.NewBufferString("")
+	for _, line := range lines {
+		body.WriteString(line)
+		body.WriteByte('\n')
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	for k, v := range s.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("siem: post to %s: %w", s.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("siem: HEC endpoint %s returned status %d", s.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// Close implements Sink. HTTPSink holds no persistent connection.
+func (s *HTTPSink) Close() error { return nil }
+
+// KafkaProducer is the subset of a Kafka client needed by KafkaSink. It is
+// defined here, rather than depending on a concrete client library, so
+// callers can plug in confluent-kafka-go, sarama, or a fake for tests.
+type KafkaProducer interface {
+	Produce(ctx context.Context, topic string, key, value []byte) error
+}
+
+// KafkaSink publishes lines as Kafka records through a pluggable
+// KafkaProducer.
+type KafkaSink struct {
+	Producer KafkaProducer
+	Topic    string
+}
+
+// Write implements Sink.
+func (s *KafkaSink) Write(ctx context.Context, lines []string) error {
+	for _, line := range lines {
+		if err := s.Producer.Produce(ctx, s.Topic, nil, []byte(line)); err != nil {
+			return fmt.Errorf("siem: produce to kafka topic %s: %w", s.Topic, err)
+		}
+	}
+	return nil
+}
+
+// Close implements Sink. The underlying KafkaProducer owns its own
+// lifecycle and is not closed here.
+func (s *KafkaSink) Close() error { return nil }
@@ -0,0 +1,316 @@
+// Package testtransport provides a record/replay http.RoundTripper so
+// endpoint packages can ship hermetic table-driven tests without a live
+// Tenable tenant. In record mode it captures real request/response pairs
+// to a YAML cassette on disk, scrubbing sensitive headers; in replay mode
+// it matches incoming requests against the cassette and returns the
+// canned response.
+package testtransport
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// scrubbedHeaders are never written to a cassette; they're replaced with
+// "REDACTED" so secrets don't end up committed to testdata.
+var scrubbedHeaders = []string{"X-Apikeys", "Authorization", "X-Request-Uuid", "X-Tio-Last-Request-Uuid"}
+
+// TestingT is the subset of *testing.T this package needs, so tests don't
+// have to import "testing" into non-test files that build cassettes.
+type TestingT interface {
+	Helper()
+	Fatalf(format string, args ...interface{})
+	Cleanup(func())
+}
+
+// MultipartPart records one part of a multipart/form-data body, matched
+// by field name and content hash rather than byte-exact boundaries (which
+// differ on every request).
+type MultipartPart struct {
+	Name     string `yaml:"name"`
+	Filename string `yaml:"filename,omitempty"`
+	BodyHash string `yaml:"body_hash"`
+}
+
+// Interaction is one recorded request/response pair.
+type Interaction struct {
+	Method          string            `yaml:"method"`
+	Path            string            `yaml:"path"`
+	Query           string            `yaml:"query,omitempty"`
+	BodyHash        string            `yaml:"body_hash,omitempty"`
+	Multipart       []MultipartPart   `yaml:"multipart,omitempty"`
+	RequestHeaders  map[string]string `yaml:"request_headers,omitempty"`
+	StatusCode      int               `yaml:"status_code"`
+	ResponseHeaders map[string]string `yaml:"response_headers,omitempty"`
+	ResponseBody    string            `yaml:"response_body"`
+}
+
+// Cassette is the on-disk YAML format: an ordered list of interactions.
+type Cassette struct {
+	Interactions []Interaction `yaml:"interactions"`
+}
+
+// Transport is an http.RoundTripper that either records live traffic to a
+// cassette file or replays a previously recorded cassette.
+type Transport struct {
+	t        TestingT
+	path     string
+	record   bool
+	real     http.RoundTripper
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// New returns a Transport in replay mode, loading the cassette at path.
+// Use it with tio.WithHTTPTransport(testtransport.New(t, "testdata/foo.yaml"))
+// to drive an endpoint package's tests without a live tenant.
+func New(t TestingT, path string) *Transport {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("testtransport: read cassette %s: %v", path, err)
+		return nil
+	}
+
+	var cassette Cassette
+	if err := yaml.Unmarshal(data, &cassette); err != nil {
+		t.Fatalf("testtransport: parse cassette %s: %v", path, err)
+		return nil
+	}
+
+	return &Transport{
+		t:        t,
+		path:     path,
+		cassette: &cassette,
+		used:     make([]bool, len(cassette.Interactions)),
+	}
+}
+
+// NewRecorder returns a Transport in record mode, forwarding requests
+// through real and appending each interaction to the cassette at path
+// (scrubbing sensitive headers). The cassette is written when the test
+// finishes, via t.Cleanup.
+func NewRecorder(t TestingT, path string, real http.RoundTripper) *Transport {
+	t.Helper()
+	if real == nil {
+		real = http.DefaultTransport
+	}
+
+	tr := &Transport{
+		t:        t,
+		path:     path,
+		record:   true,
+		real:     real,
+		cassette: &Cassette{},
+	}
+	t.Cleanup(tr.save)
+	return tr
+}
+
+// RoundTrip implements http.RoundTripper.
+func (tr *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if tr.record {
+		return tr.roundTripRecord(req)
+	}
+	return tr.roundTripReplay(req)
+}
+
+func (tr *Transport) roundTripRecord(req *http.Request) (*http.Response, error) {
+	interaction, bodyForReplay, err := buildInteraction(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Body = bodyForReplay
+
+	resp, err := tr.real.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	interaction.StatusCode = resp.StatusCode
+	interaction.ResponseHeaders = scrub(resp.Header)
+	interaction.ResponseBody = string(respBody)
+
+	tr.mu.Lock()
+	tr.cassette.Interactions = append(tr.cassette.Interactions, interaction)
+	tr.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	return resp, nil
+}
+
+func (tr *Transport) roundTripReplay(req *http.Request) (*http.Response, error) {
+	tr.t.Helper()
+
+	want, _, err := buildInteraction(req)
+	if err != nil {
+		return nil, err
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	for i, got := range tr.cassette.Interactions {
+		if tr.used[i] {
+			continue
+		}
+		if matches(want, got) {
+			tr.used[i] = true
+			return &http.Response{
+				StatusCode: got.StatusCode,
+				Status:     http.StatusText(got.StatusCode),
+				Header:     toHeader(got.ResponseHeaders),
+				Body:       io.NopCloser(bytes.NewReader([]byte(got.ResponseBody))),
+				Request:    req,
+			}, nil
+		}
+	}
+
+	tr.t.Fatalf("testtransport: no cassette interaction in %s matches %s %s", tr.path, req.Method, req.URL.String())
+	return nil, fmt.Errorf("testtransport: no match for %s %s", req.Method, req.URL.String())
+}
+
+func (tr *Transport) save() {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	data, err := yaml.Marshal(tr.cassette)
+	if err != nil {
+		tr.t.Fatalf("testtransport: marshal cassette: %v", err)
+		return
+	}
+	if err := os.WriteFile(tr.path, data, 0o644); err != nil {
+		tr.t.Fatalf("testtransport: write cassette %s: %v", tr.path, err)
+	}
+}
+
+// buildInteraction reads req's body (consuming it) and returns an
+// Interaction describing the request plus a fresh io.ReadCloser the
+// caller can install back onto req.Body so the real round trip still
+// sees the original content.
+func buildInteraction(req *http.Request) (Interaction, io.ReadCloser, error) {
+	interaction := Interaction{
+		Method:         req.Method,
+		Path:           req.URL.Path,
+		Query:          req.URL.RawQuery,
+		RequestHeaders: scrub(req.Header),
+	}
+
+	if req.Body == nil {
+		return interaction, nil, nil
+	}
+
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return interaction, nil, err
+	}
+	replacement := io.NopCloser(bytes.NewReader(data))
+
+	if mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type")); err == nil && mediaType == "multipart/form-data" {
+		interaction.Multipart = hashMultipart(data, params["boundary"])
+	} else {
+		interaction.BodyHash = hashBytes(data)
+	}
+
+	return interaction, replacement, nil
+}
+
+// matches compares two interactions for replay purposes: method, path,
+// query, and body must agree. Multipart bodies are compared part-by-part
+// by name/filename/content hash rather than byte-exact, since boundaries
+// are randomly generated on every request.
+func matches(want, got Interaction) bool {
+	if want.Method != got.Method || want.Path != got.Path || want.Query != got.Query {
+		return false
+	}
+	if len(want.Multipart) > 0 || len(got.Multipart) > 0 {
+		return multipartMatches(want.Multipart, got.Multipart)
+	}
+	return want.BodyHash == got.BodyHash
+}
+
+func multipartMatches(a, b []MultipartPart) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	index := make(map[string]MultipartPart, len(a))
+	for _, p := range a {
+		index[p.Name+"|"+p.Filename] = p
+	}
+	for _, p := range b {
+		other, ok := index[p.Name+"|"+p.Filename]
+		if !ok || other.BodyHash != p.BodyHash {
+			return false
+		}
+	}
+	return true
+}
+
+func hashMultipart(body []byte, boundary string) []MultipartPart {
+	if boundary == "" {
+		return nil
+	}
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	var parts []MultipartPart
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+		data, _ := io.ReadAll(part)
+		parts = append(parts, MultipartPart{
+			Name:     part.FormName(),
+			Filename: part.FileName(),
+			BodyHash: hashBytes(data),
+		})
+	}
+	return parts
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func scrub(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		if len(v) == 0 {
+			continue
+		}
+		out[k] = v[0]
+	}
+	for _, name := range scrubbedHeaders {
+		if _, ok := out[name]; ok {
+			out[name] = "REDACTED"
+		}
+	}
+	return out
+}
+
+func toHeader(m map[string]string) http.Header {
+	h := make(http.Header, len(m))
+	for k, v := range m {
+		h.Set(k, v)
+	}
+	return h
+}
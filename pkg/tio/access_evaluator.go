@@ -0,0 +1,241 @@
+package tio
+
+import (
+	"fmt"
+	"net"
+	"path"
+)
+
+// AssetIdentity is the subset of an asset's attributes access rules can
+// match against: its IPv4 addresses, FQDNs, and tag values (rendered as
+// "category:value", matching how TagsAPI reports tag assignments).
+type AssetIdentity struct {
+	IPv4 []string
+	FQDN []string
+	Tags []string
+}
+
+// CallerIdentity identifies the principal a permission check is for: a
+// user, optionally a member of one or more groups. AccessGroup.Principals
+// entries of type "user" match UserUUID; entries of type "group" match
+// any of GroupUUIDs.
+type CallerIdentity struct {
+	UserUUID   string
+	GroupUUIDs []string
+}
+
+// AccessDecision is the result of evaluating one AccessGroup against an
+// AssetIdentity and CallerIdentity.
+type AccessDecision struct {
+	GroupUUID string
+	GroupName string
+	// Granted is true if caller has a matching Principal entry in the
+	// group and the group's rules (or AllAssets) select asset.
+	Granted bool
+	// Permissions is the union of the Permissions of every Principal
+	// entry that matched caller.
+	Permissions []string
+	// MatchedRuleIndices is the index, within AccessGroup.Rules, of each
+	// rule that was evaluated and matched, for auditability. Nil when the
+	// group granted access via AllAssets rather than its Rules.
+	MatchedRuleIndices []int
+}
+
+// RuleMatcher decides whether rule selects asset. Evaluate and
+// EvaluateWithMatchers dispatch to one RuleMatcher per distinct
+// AccessRule.Type seen.
+type RuleMatcher func(rule AccessRule, asset AssetIdentity) (bool, error)
+
+// Evaluate determines, for each of groups, whether caller is granted
+// access to asset and with which permissions, entirely in-process: no
+// requests are made to the Tenable API. This lets tooling iterating a
+// large asset set pre-filter membership locally instead of paging the
+// server (AccessControlAPI.List or PermissionsAPI.List) for every asset.
+//
+// Only groups where caller has a matching Principal are considered; a
+// group granting AllAssets matches every asset unconditionally, and a
+// group with no Rules and AllAssets false never matches. Otherwise every
+// one of the group's Rules must match (an AND, matching how Tenable
+// access groups select assets) using the built-in matchers for "ipv4"
+// (CIDR or exact match), "fqdn" (glob), and "tag" (exact match); each
+// rule's Operator is "eq" (any Terms entry matching grants the rule) or
+// "neq" (none may match). Use EvaluateWithMatchers to evaluate an
+// AccessRule.Type not covered by those three.
+func (a *AccessControlAPI) Evaluate(groups []AccessGroup, asset AssetIdentity, caller CallerIdentity) ([]AccessDecision, error) {
+	return a.EvaluateWithMatchers(groups, asset, caller, defaultRuleMatchers())
+}
+
+// EvaluateWithMatchers is Evaluate with a caller-supplied set of
+// RuleMatcher implementations keyed by AccessRule.Type, for access
+// groups using a rule type the built-in ipv4/fqdn/tag matchers don't
+// cover.
+func (a *AccessControlAPI) EvaluateWithMatchers(groups []AccessGroup, asset AssetIdentity, caller CallerIdentity, matchers map[string]RuleMatcher) ([]AccessDecision, error) {
+	var decisions []AccessDecision
+
+	for _, g := range groups {
+		permissions, principalMatched := matchingPermissions(g.Principals, caller)
+		if !principalMatched {
+			continue
+		}
+
+		if g.AllAssets {
+			decisions = append(decisions, AccessDecision{
+				GroupUUID:   g.UUID,
+				GroupName:   g.Name,
+				Granted:     true,
+				Permissions: permissions,
+			})
+			continue
+		}
+
+		if len(g.Rules) == 0 {
+			continue
+		}
+
+		indices := make([]int, 0, len(g.Rules))
+		matchedAll := true
+		for i, rule := range g.Rules {
+			matcher, ok := matchers[rule.Type]
+			if !ok {
+				return nil, fmt.Errorf("access evaluator: no matcher registered for rule type %q", rule.Type)
+			}
+			matched, err := matcher(rule, asset)
+			if err != nil {
+				return nil, fmt.Errorf("access evaluator: group %s rule %d: %w", g.UUID, i, err)
+			}
+			if !matched {
+				matchedAll = false
+				break
+			}
+			indices = append(indices, i)
+		}
+		if !matchedAll {
+			continue
+		}
+
+		decisions = append(decisions, AccessDecision{
+			GroupUUID:          g.UUID,
+			GroupName:          g.Name,
+			Granted:            true,
+			Permissions:        permissions,
+			MatchedRuleIndices: indices,
+		})
+	}
+
+	return decisions, nil
+}
+
+// matchingPermissions returns the union of Permissions across every
+// Principal entry matching caller, and whether any matched at all.
+func matchingPermissions(principals []Principal, caller CallerIdentity) ([]string, bool) {
+	var permissions []string
+	matched := false
+
+	for _, p := range principals {
+		entryMatched := false
+		switch p.Type {
+		case "user":
+			entryMatched = p.PrincipalID == caller.UserUUID
+		case "group":
+			for _, groupUUID := range caller.GroupUUIDs {
+				if p.PrincipalID == groupUUID {
+					entryMatched = true
+					break
+				}
+			}
+		}
+		if entryMatched {
+			matched = true
+			permissions = append(permissions, p.Permissions...)
+		}
+	}
+
+	return permissions, matched
+}
+
+func defaultRuleMatchers() map[string]RuleMatcher {
+	return map[string]RuleMatcher{
+		"ipv4": matchIPv4Rule,
+		"fqdn": matchFQDNRule,
+		"tag":  matchTagRule,
+	}
+}
+
+// matchIPv4Rule matches rule.Terms, each either a bare IPv4 address or a
+// CIDR block, against asset.IPv4.
+func matchIPv4Rule(rule AccessRule, asset AssetIdentity) (bool, error) {
+	return applyOperator(rule.Operator, func() (bool, error) {
+		for _, term := range rule.Terms {
+			if _, cidr, err := net.ParseCIDR(term); err == nil {
+				for _, addr := range asset.IPv4 {
+					if ip := net.ParseIP(addr); ip != nil && cidr.Contains(ip) {
+						return true, nil
+					}
+				}
+				continue
+			}
+			termIP := net.ParseIP(term)
+			if termIP == nil {
+				return false, fmt.Errorf("access evaluator: invalid ipv4 term %q", term)
+			}
+			for _, addr := range asset.IPv4 {
+				if termIP.Equal(net.ParseIP(addr)) {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	})
+}
+
+// matchFQDNRule matches rule.Terms, each a path.Match glob (e.g.
+// "*.example.com"), against asset.FQDN.
+func matchFQDNRule(rule AccessRule, asset AssetIdentity) (bool, error) {
+	return applyOperator(rule.Operator, func() (bool, error) {
+		for _, term := range rule.Terms {
+			for _, fqdn := range asset.FQDN {
+				ok, err := path.Match(term, fqdn)
+				if err != nil {
+					return false, fmt.Errorf("access evaluator: invalid fqdn glob %q: %w", term, err)
+				}
+				if ok {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	})
+}
+
+// matchTagRule matches rule.Terms, each a "category:value" tag, against
+// asset.Tags for an exact match.
+func matchTagRule(rule AccessRule, asset AssetIdentity) (bool, error) {
+	return applyOperator(rule.Operator, func() (bool, error) {
+		for _, term := range rule.Terms {
+			for _, tag := range asset.Tags {
+				if tag == term {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	})
+}
+
+// applyOperator runs anyTermMatches and applies rule.Operator to its
+// result: "eq" (the default, for an empty operator) passes it through,
+// "neq" negates it.
+func applyOperator(operator string, anyTermMatches func() (bool, error)) (bool, error) {
+	matched, err := anyTermMatches()
+	if err != nil {
+		return false, err
+	}
+	switch operator {
+	case "", "eq":
+		return matched, nil
+	case "neq":
+		return !matched, nil
+	default:
+		return false, fmt.Errorf("access evaluator: unsupported operator %q", operator)
+	}
+}
@@ -0,0 +1,172 @@
+package tio
+
+import "testing"
+
+func TestAccessControlAPIEvaluateAllAssetsGroup(t *testing.T) {
+	api := &AccessControlAPI{}
+	groups := []AccessGroup{{
+		UUID:      "grp-all",
+		Name:      "All Assets",
+		AllAssets: true,
+		Principals: []Principal{
+			{Type: "user", PrincipalID: "user-1", Permissions: []string{"CAN_VIEW"}},
+		},
+	}}
+
+	decisions, err := api.Evaluate(groups, AssetIdentity{}, CallerIdentity{UserUUID: "user-1"})
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if len(decisions) != 1 || !decisions[0].Granted || decisions[0].MatchedRuleIndices != nil {
+		t.Fatalf("decisions = %+v, want one granted decision with no rule indices", decisions)
+	}
+}
+
+func TestAccessControlAPIEvaluateIPv4CIDRRule(t *testing.T) {
+	api := &AccessControlAPI{}
+	groups := []AccessGroup{{
+		UUID: "grp-dmz",
+		Name: "DMZ",
+		Principals: []Principal{
+			{Type: "group", PrincipalID: "group-eng", Permissions: []string{"CAN_SCAN"}},
+		},
+		Rules: []AccessRule{
+			{Type: "ipv4", Operator: "eq", Terms: []string{"10.0.0.0/24"}},
+		},
+	}}
+
+	caller := CallerIdentity{UserUUID: "user-2", GroupUUIDs: []string{"group-eng"}}
+
+	inRange, err := api.Evaluate(groups, AssetIdentity{IPv4: []string{"10.0.0.5"}}, caller)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if len(inRange) != 1 || !inRange[0].Granted || len(inRange[0].MatchedRuleIndices) != 1 || inRange[0].MatchedRuleIndices[0] != 0 {
+		t.Fatalf("in-range decisions = %+v, want one granted decision matching rule 0", inRange)
+	}
+
+	outOfRange, err := api.Evaluate(groups, AssetIdentity{IPv4: []string{"192.168.1.5"}}, caller)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if len(outOfRange) != 0 {
+		t.Fatalf("out-of-range decisions = %+v, want none", outOfRange)
+	}
+}
+
+func TestAccessControlAPIEvaluateRulesAreANDed(t *testing.T) {
+	api := &AccessControlAPI{}
+	groups := []AccessGroup{{
+		UUID: "grp-both",
+		Principals: []Principal{
+			{Type: "user", PrincipalID: "user-1", Permissions: []string{"CAN_VIEW"}},
+		},
+		Rules: []AccessRule{
+			{Type: "ipv4", Operator: "eq", Terms: []string{"10.0.0.0/24"}},
+			{Type: "tag", Operator: "eq", Terms: []string{"env:prod"}},
+		},
+	}}
+	caller := CallerIdentity{UserUUID: "user-1"}
+
+	matchesBoth, err := api.Evaluate(groups, AssetIdentity{IPv4: []string{"10.0.0.5"}, Tags: []string{"env:prod"}}, caller)
+	if err != nil || len(matchesBoth) != 1 {
+		t.Fatalf("Evaluate() = %+v, %v, want one granted decision", matchesBoth, err)
+	}
+
+	matchesOnlyIP, err := api.Evaluate(groups, AssetIdentity{IPv4: []string{"10.0.0.5"}, Tags: []string{"env:dev"}}, caller)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if len(matchesOnlyIP) != 0 {
+		t.Fatalf("decisions = %+v, want none (tag rule unmatched, rules are ANDed)", matchesOnlyIP)
+	}
+}
+
+func TestAccessControlAPIEvaluateNeqOperator(t *testing.T) {
+	api := &AccessControlAPI{}
+	groups := []AccessGroup{{
+		UUID: "grp-not-prod",
+		Principals: []Principal{
+			{Type: "user", PrincipalID: "user-1", Permissions: []string{"CAN_VIEW"}},
+		},
+		Rules: []AccessRule{
+			{Type: "tag", Operator: "neq", Terms: []string{"env:prod"}},
+		},
+	}}
+	caller := CallerIdentity{UserUUID: "user-1"}
+
+	decisions, err := api.Evaluate(groups, AssetIdentity{Tags: []string{"env:dev"}}, caller)
+	if err != nil || len(decisions) != 1 {
+		t.Fatalf("Evaluate() = %+v, %v, want one granted decision", decisions, err)
+	}
+
+	decisions, err = api.Evaluate(groups, AssetIdentity{Tags: []string{"env:prod"}}, caller)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if len(decisions) != 0 {
+		t.Fatalf("decisions = %+v, want none (env:prod excluded by neq)", decisions)
+	}
+}
+
+func TestAccessControlAPIEvaluateFQDNGlobRule(t *testing.T) {
+	api := &AccessControlAPI{}
+	groups := []AccessGroup{{
+		UUID: "grp-web",
+		Principals: []Principal{
+			{Type: "user", PrincipalID: "user-1", Permissions: []string{"CAN_VIEW"}},
+		},
+		Rules: []AccessRule{
+			{Type: "fqdn", Operator: "eq", Terms: []string{"*.example.com"}},
+		},
+	}}
+	caller := CallerIdentity{UserUUID: "user-1"}
+
+	decisions, err := api.Evaluate(groups, AssetIdentity{FQDN: []string{"web01.example.com"}}, caller)
+	if err != nil || len(decisions) != 1 {
+		t.Fatalf("Evaluate() = %+v, %v, want one granted decision", decisions, err)
+	}
+
+	decisions, err = api.Evaluate(groups, AssetIdentity{FQDN: []string{"web01.other.com"}}, caller)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if len(decisions) != 0 {
+		t.Fatalf("decisions = %+v, want none", decisions)
+	}
+}
+
+func TestAccessControlAPIEvaluateNoMatchingPrincipalSkipsGroup(t *testing.T) {
+	api := &AccessControlAPI{}
+	groups := []AccessGroup{{
+		UUID:      "grp-other",
+		AllAssets: true,
+		Principals: []Principal{
+			{Type: "user", PrincipalID: "someone-else", Permissions: []string{"CAN_VIEW"}},
+		},
+	}}
+
+	decisions, err := api.Evaluate(groups, AssetIdentity{}, CallerIdentity{UserUUID: "user-1"})
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if len(decisions) != 0 {
+		t.Fatalf("decisions = %+v, want none (caller has no matching principal)", decisions)
+	}
+}
+
+func TestAccessControlAPIEvaluateUnknownRuleTypeErrors(t *testing.T) {
+	api := &AccessControlAPI{}
+	groups := []AccessGroup{{
+		UUID: "grp-custom",
+		Principals: []Principal{
+			{Type: "user", PrincipalID: "user-1", Permissions: []string{"CAN_VIEW"}},
+		},
+		Rules: []AccessRule{{Type: "custom", Operator: "eq", Terms: []string{"x"}}},
+	}}
+
+	_, err := api.Evaluate(groups, AssetIdentity{}, CallerIdentity{UserUUID: "user-1"})
+	if err == nil {
+		t.Fatal("Evaluate() error = nil, want error for unregistered rule type")
+	}
+}
@@ -0,0 +1,138 @@
+package tio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAccessPoliciesGrantAndRevoke(t *testing.T) {
+	var grants, revokes int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/access-control/v2/policies" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var body grant
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body.Subject.Kind != UserSubject || body.Subject.ID != 42 {
+			t.Errorf("subject = %+v, want user 42", body.Subject)
+		}
+		if body.Object.Kind != TagObject || body.Object.UUID != "tag-uuid" {
+			t.Errorf("object = %+v, want tag tag-uuid", body.Object)
+		}
+		if body.Relation != ViewerRelation {
+			t.Errorf("relation = %v, want ViewerRelation", body.Relation)
+		}
+		switch r.Method {
+		case http.MethodPost:
+			grants++
+		case http.MethodDelete:
+			revokes++
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client, err := New(WithURL(srv.URL), WithAPIKeys("access", "secret"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	subject := Subject{Kind: UserSubject, ID: 42}
+	object := Object{Kind: TagObject, UUID: "tag-uuid"}
+
+	if err := client.AccessPolicies.Grant(context.Background(), subject, object, ViewerRelation); err != nil {
+		t.Fatalf("Grant() error = %v", err)
+	}
+	if err := client.AccessPolicies.Revoke(context.Background(), subject, object, ViewerRelation); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if grants != 1 || revokes != 1 {
+		t.Errorf("grants = %d, revokes = %d, want 1 and 1", grants, revokes)
+	}
+}
+
+func TestAccessPoliciesListSubjectsForObject(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/access-control/v2/policies/objects/tag/tag-uuid/subjects" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("relation"); got != string(AdminRelation) {
+			t.Errorf("relation query = %q, want admin", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"subjects": []Subject{{Kind: UserSubject, ID: 1}, {Kind: GroupSubject, ID: 2}},
+		})
+	}))
+	defer srv.Close()
+
+	client, err := New(WithURL(srv.URL), WithAPIKeys("access", "secret"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	subjects, err := client.AccessPolicies.ListSubjectsForObject(context.Background(), Object{Kind: TagObject, UUID: "tag-uuid"}, AdminRelation)
+	if err != nil {
+		t.Fatalf("ListSubjectsForObject() error = %v", err)
+	}
+	if len(subjects) != 2 || subjects[0].ID != 1 || subjects[1].ID != 2 {
+		t.Errorf("subjects = %+v, want ids 1 and 2", subjects)
+	}
+}
+
+func TestAccessPoliciesListObjectsForSubject(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/access-control/v2/policies/subjects/user/7/objects" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"objects": []Object{{Kind: NetworkObject, UUID: "net-1"}},
+		})
+	}))
+	defer srv.Close()
+
+	client, err := New(WithURL(srv.URL), WithAPIKeys("access", "secret"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	objects, err := client.AccessPolicies.ListObjectsForSubject(context.Background(), Subject{Kind: UserSubject, ID: 7}, ViewerRelation)
+	if err != nil {
+		t.Fatalf("ListObjectsForSubject() error = %v", err)
+	}
+	if len(objects) != 1 || objects[0].UUID != "net-1" {
+		t.Errorf("objects = %+v, want one net-1", objects)
+	}
+}
+
+func TestAccessPoliciesIsAuthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/access-control/v2/policies/check" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]bool{"authorized": true})
+	}))
+	defer srv.Close()
+
+	client, err := New(WithURL(srv.URL), WithAPIKeys("access", "secret"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ok, err := client.AccessPolicies.IsAuthorized(context.Background(), Subject{Kind: UserSubject, ID: 1}, Object{Kind: ScanObject, UUID: "scan-1"}, OwnerRelation)
+	if err != nil {
+		t.Fatalf("IsAuthorized() error = %v", err)
+	}
+	if !ok {
+		t.Error("IsAuthorized() = false, want true")
+	}
+}
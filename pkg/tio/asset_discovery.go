@@ -0,0 +1,252 @@
+package tio
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/base/filter"
+)
+
+// DiscoveredAsset is one entry in a DiscoveryHandle's roster: an asset as
+// last seen, alongside when it was first and most recently observed, and
+// whether it has since stopped appearing.
+type DiscoveredAsset struct {
+	Asset        Asset
+	FirstSeen    time.Time
+	LastSeen     time.Time
+	Terminated   bool
+	TerminatedAt time.Time
+}
+
+// DiscoveryRoundMetrics summarizes one Discover poll round, for callers
+// wiring AssetDiscoveryOptions.Metrics into a metrics system.
+type DiscoveryRoundMetrics struct {
+	Discovered int
+	Updated    int
+	Terminated int
+	Tracked    int // total non-terminated assets in the roster after this round
+}
+
+// AssetDiscoveryOptions configures AssetsAPI.Discover.
+type AssetDiscoveryOptions struct {
+	// Filter narrows which assets Discover tracks, e.g.
+	// filter.Eq("operating_system", "Windows"). The zero value tracks
+	// every asset AssetsAPI.List returns.
+	Filter filter.Expr
+	// Interval is how often Discover re-lists assets. Zero uses a default
+	// of 15 minutes.
+	Interval time.Duration
+	// Store persists the roster so it survives a process restart.
+	// Defaults to a fresh MemoryDiscoveryStore, which does not.
+	Store DiscoveryStore
+	// OnDiscovered, if set, is called when an asset first appears, or
+	// reappears after having been marked terminated.
+	OnDiscovered func(DiscoveredAsset)
+	// OnUpdated, if set, is called when a previously seen, still-present
+	// asset's details change between rounds.
+	OnUpdated func(previous, current DiscoveredAsset)
+	// OnTerminated, if set, is called the first round a previously seen
+	// asset fails to appear in the listing.
+	OnTerminated func(DiscoveredAsset)
+	// Metrics, if set, is called once per round with that round's counts.
+	Metrics func(DiscoveryRoundMetrics)
+}
+
+func (o AssetDiscoveryOptions) withDefaults() AssetDiscoveryOptions {
+	if o.Interval <= 0 {
+		o.Interval = 15 * time.Minute
+	}
+	if o.Store == nil {
+		o.Store = NewMemoryDiscoveryStore()
+	}
+	return o
+}
+
+// DiscoveryHandle is a running AssetsAPI.Discover roster. Call Snapshot to
+// read it and Stop to end the poll loop.
+type DiscoveryHandle struct {
+	assets *AssetsAPI
+	opts   AssetDiscoveryOptions
+
+	mu      sync.RWMutex
+	records map[string]DiscoveredAsset
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Discover lists assets matching opts.Filter on a repeating opts.Interval
+// and maintains a roster of them keyed by asset UUID, tracking when each
+// was first and last seen. An asset that fails to appear in a full round
+// is marked terminated (and, unless Snapshot is asked otherwise, excluded
+// from it) rather than dropped, so a caller can still see when and why it
+// disappeared. This gives callers a stable roster to scan against (e.g.
+// "every asset discovered in the last hour matching tag=prod") instead of
+// racing List's pagination on every use.
+//
+// Discover runs one round synchronously before returning, so the initial
+// Snapshot is populated, then continues polling on a background goroutine
+// until ctx is canceled or the returned handle's Stop is called.
+func (a *AssetsAPI) Discover(ctx context.Context, opts AssetDiscoveryOptions) (*DiscoveryHandle, error) {
+	opts = opts.withDefaults()
+
+	records, err := opts.Store.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("asset discovery: load store: %w", err)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	h := &DiscoveryHandle{
+		assets:  a,
+		opts:    opts,
+		records: records,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+
+	if err := h.round(runCtx); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go func() {
+		defer close(h.done)
+		timer := time.NewTimer(opts.Interval)
+		defer timer.Stop()
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-timer.C:
+				h.round(runCtx)
+				timer.Reset(opts.Interval)
+			}
+		}
+	}()
+
+	return h, nil
+}
+
+// Stop ends the background poll loop and waits for it to exit.
+func (h *DiscoveryHandle) Stop() {
+	h.cancel()
+	<-h.done
+}
+
+// Snapshot returns the current roster, ordered by asset UUID. Terminated
+// assets are omitted unless includeTerminated is true.
+func (h *DiscoveryHandle) Snapshot(includeTerminated bool) []DiscoveredAsset {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	out := make([]DiscoveredAsset, 0, len(h.records))
+	for _, record := range h.records {
+		if record.Terminated && !includeTerminated {
+			continue
+		}
+		out = append(out, record)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Asset.ID < out[j].Asset.ID })
+	return out
+}
+
+// round lists every asset currently matching h.opts.Filter, updates the
+// roster to match (firing OnDiscovered/OnUpdated as it goes), marks any
+// previously seen asset absent from this round's listing as terminated
+// (firing OnTerminated), and reports the round's counts via
+// h.opts.Metrics. A fetch failure from List is returned: Discover returns
+// the first round's error, while the background poll loop ignores a later
+// round's error and tries again at the next Interval.
+func (h *DiscoveryHandle) round(ctx context.Context) error {
+	now := time.Now()
+	var metrics DiscoveryRoundMetrics
+	seen := make(map[string]bool)
+
+	it := h.assets.List(ctx, &AssetListOptions{FilterExpr: h.opts.Filter})
+	for it.Next() {
+		asset := it.Item()
+		seen[asset.ID] = true
+
+		h.mu.Lock()
+		prev, ok := h.records[asset.ID]
+		var record DiscoveredAsset
+		var discovered, updated bool
+		switch {
+		case !ok || prev.Terminated:
+			record = DiscoveredAsset{Asset: asset, FirstSeen: now, LastSeen: now}
+			if ok {
+				record.FirstSeen = prev.FirstSeen
+			}
+			discovered = true
+		case !reflect.DeepEqual(prev.Asset, asset):
+			record = prev
+			record.Asset = asset
+			record.LastSeen = now
+			updated = true
+		default:
+			record = prev
+			record.LastSeen = now
+		}
+		h.records[asset.ID] = record
+		h.mu.Unlock()
+
+		if discovered || updated {
+			if err := h.opts.Store.Put(ctx, asset.ID, record); err != nil {
+				return fmt.Errorf("asset discovery: save %s: %w", asset.ID, err)
+			}
+		}
+		switch {
+		case discovered:
+			metrics.Discovered++
+			if h.opts.OnDiscovered != nil {
+				h.opts.OnDiscovered(record)
+			}
+		case updated:
+			metrics.Updated++
+			if h.opts.OnUpdated != nil {
+				h.opts.OnUpdated(prev, record)
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("asset discovery: list: %w", err)
+	}
+
+	h.mu.Lock()
+	var newlyTerminated []DiscoveredAsset
+	for id, prev := range h.records {
+		if seen[id] || prev.Terminated {
+			continue
+		}
+		record := prev
+		record.Terminated = true
+		record.TerminatedAt = now
+		h.records[id] = record
+		newlyTerminated = append(newlyTerminated, record)
+	}
+	for _, r := range h.records {
+		if !r.Terminated {
+			metrics.Tracked++
+		}
+	}
+	h.mu.Unlock()
+
+	for _, record := range newlyTerminated {
+		metrics.Terminated++
+		if err := h.opts.Store.Put(ctx, record.Asset.ID, record); err != nil {
+			return fmt.Errorf("asset discovery: save %s: %w", record.Asset.ID, err)
+		}
+		if h.opts.OnTerminated != nil {
+			h.opts.OnTerminated(record)
+		}
+	}
+
+	if h.opts.Metrics != nil {
+		h.opts.Metrics(metrics)
+	}
+	return nil
+}
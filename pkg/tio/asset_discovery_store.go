@@ -0,0 +1,149 @@
+package tio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"go.etcd.io/bbolt"
+)
+
+// DiscoveryStore persists the roster a DiscoveryHandle tracks, keyed by
+// asset UUID, so it survives a process restart instead of starting from
+// an empty roster (and re-firing OnDiscovered for every already-known
+// asset) each time Discover is called. Implementations must be safe for
+// concurrent use.
+type DiscoveryStore interface {
+	// Load returns every record previously saved, keyed by asset UUID. An
+	// empty, non-nil map (not an error) is expected when nothing has been
+	// saved yet.
+	Load(ctx context.Context) (map[string]DiscoveredAsset, error)
+	// Put persists record under assetUUID, overwriting any previous value.
+	Put(ctx context.Context, assetUUID string, record DiscoveredAsset) error
+	// Delete removes the record saved under assetUUID. Deleting a UUID
+	// that was never saved (or already deleted) is not an error.
+	Delete(ctx context.Context, assetUUID string) error
+}
+
+// MemoryDiscoveryStore is a DiscoveryStore backed by an in-process map.
+// Records do not survive a process restart; it's useful for tests and for
+// callers that only need the roster within a single run, which is also
+// why Discover uses one by default.
+type MemoryDiscoveryStore struct {
+	mu      sync.RWMutex
+	records map[string]DiscoveredAsset
+}
+
+// NewMemoryDiscoveryStore creates an empty MemoryDiscoveryStore.
+func NewMemoryDiscoveryStore() *MemoryDiscoveryStore {
+	return &MemoryDiscoveryStore{records: make(map[string]DiscoveredAsset)}
+}
+
+// Load implements DiscoveryStore.
+func (s *MemoryDiscoveryStore) Load(ctx context.Context) (map[string]DiscoveredAsset, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[string]DiscoveredAsset, len(s.records))
+	for k, v := range s.records {
+		out[k] = v
+	}
+	return out, nil
+}
+
+// Put implements DiscoveryStore.
+func (s *MemoryDiscoveryStore) Put(ctx context.Context, assetUUID string, record DiscoveredAsset) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[assetUUID] = record
+	return nil
+}
+
+// Delete implements DiscoveryStore.
+func (s *MemoryDiscoveryStore) Delete(ctx context.Context, assetUUID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, assetUUID)
+	return nil
+}
+
+// discoveryBucket is the single bbolt bucket BoltDiscoveryStore keeps its
+// records in, each key an asset UUID and each value its JSON-encoded
+// DiscoveredAsset.
+var discoveryBucket = []byte("asset_discovery")
+
+// BoltDiscoveryStore is a DiscoveryStore backed by a BoltDB file, so a
+// discovery roster survives a process restart without standing up
+// anything external. Open one with NewBoltDiscoveryStore.
+type BoltDiscoveryStore struct {
+	db *bbolt.DB
+}
+
+// NewBoltDiscoveryStore opens (creating if necessary) a BoltDB file at
+// path and prepares it for use as a DiscoveryStore. Close the returned
+// store when done to release the file lock.
+func NewBoltDiscoveryStore(path string) (*BoltDiscoveryStore, error) {
+	db, err := bbolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("bolt discovery store: open %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(discoveryBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("bolt discovery store: create bucket: %w", err)
+	}
+	return &BoltDiscoveryStore{db: db}, nil
+}
+
+// Close releases the underlying BoltDB file lock.
+func (s *BoltDiscoveryStore) Close() error {
+	return s.db.Close()
+}
+
+// Load implements DiscoveryStore.
+func (s *BoltDiscoveryStore) Load(ctx context.Context) (map[string]DiscoveredAsset, error) {
+	out := make(map[string]DiscoveredAsset)
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(discoveryBucket).ForEach(func(k, v []byte) error {
+			var record DiscoveredAsset
+			if err := json.Unmarshal(v, &record); err != nil {
+				return fmt.Errorf("decode record for %s: %w", k, err)
+			}
+			out[string(k)] = record
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bolt discovery store: load: %w", err)
+	}
+	return out, nil
+}
+
+// Put implements DiscoveryStore.
+func (s *BoltDiscoveryStore) Put(ctx context.Context, assetUUID string, record DiscoveredAsset) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("bolt discovery store: encode %s: %w", assetUUID, err)
+	}
+	err = s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(discoveryBucket).Put([]byte(assetUUID), data)
+	})
+	if err != nil {
+		return fmt.Errorf("bolt discovery store: put %s: %w", assetUUID, err)
+	}
+	return nil
+}
+
+// Delete implements DiscoveryStore.
+func (s *BoltDiscoveryStore) Delete(ctx context.Context, assetUUID string) error {
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(discoveryBucket).Delete([]byte(assetUUID))
+	})
+	if err != nil {
+		return fmt.Errorf("bolt discovery store: delete %s: %w", assetUUID, err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package tio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// discoveryFixtureTransport serves AssetsAPI.List from whichever []Asset
+// *assets currently points to, so a test can swap the roster between
+// rounds.
+func discoveryFixtureTransport(assets *[]Asset) roundTripFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		body, _ := json.Marshal(map[string]interface{}{"assets": *assets, "total": len(*assets)})
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(bytes.NewReader(body)),
+		}, nil
+	}
+}
+
+func discoveryTestClient(t *testing.T, assets *[]Asset) *Client {
+	t.Helper()
+	client, err := New(WithAPIKeys("access", "secret"), WithHTTPTransport(discoveryFixtureTransport(assets)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return client
+}
+
+func TestDiscoverFiresOnDiscoveredForNewAssets(t *testing.T) {
+	assets := []Asset{{ID: "asset-1"}, {ID: "asset-2"}}
+	client := discoveryTestClient(t, &assets)
+
+	var discoveredIDs []string
+	handle, err := client.Assets.Discover(context.Background(), AssetDiscoveryOptions{
+		Interval:     time.Hour,
+		OnDiscovered: func(d DiscoveredAsset) { discoveredIDs = append(discoveredIDs, d.Asset.ID) },
+	})
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	defer handle.Stop()
+
+	if len(discoveredIDs) != 2 {
+		t.Fatalf("discoveredIDs = %v, want 2 entries", discoveredIDs)
+	}
+
+	snapshot := handle.Snapshot(false)
+	if len(snapshot) != 2 {
+		t.Fatalf("Snapshot(false) len = %d, want 2", len(snapshot))
+	}
+}
+
+func TestDiscoverMarksMissingAssetTerminated(t *testing.T) {
+	assets := []Asset{{ID: "asset-1"}, {ID: "asset-2"}}
+	client := discoveryTestClient(t, &assets)
+
+	var terminated []string
+	handle, err := client.Assets.Discover(context.Background(), AssetDiscoveryOptions{
+		Interval:     time.Hour,
+		OnTerminated: func(d DiscoveredAsset) { terminated = append(terminated, d.Asset.ID) },
+	})
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	defer handle.Stop()
+
+	assets = []Asset{{ID: "asset-1"}}
+	if err := handle.round(context.Background()); err != nil {
+		t.Fatalf("round() error = %v", err)
+	}
+
+	if len(terminated) != 1 || terminated[0] != "asset-2" {
+		t.Fatalf("terminated = %v, want [asset-2]", terminated)
+	}
+
+	if snapshot := handle.Snapshot(false); len(snapshot) != 1 {
+		t.Errorf("Snapshot(false) len = %d, want 1 (terminated asset excluded)", len(snapshot))
+	}
+	if snapshot := handle.Snapshot(true); len(snapshot) != 2 {
+		t.Errorf("Snapshot(true) len = %d, want 2 (terminated asset included)", len(snapshot))
+	}
+}
+
+func TestDiscoverFiresOnUpdatedWhenAssetDetailsChange(t *testing.T) {
+	assets := []Asset{{ID: "asset-1", HasAgent: false}}
+	client := discoveryTestClient(t, &assets)
+
+	var updated int
+	handle, err := client.Assets.Discover(context.Background(), AssetDiscoveryOptions{
+		Interval:  time.Hour,
+		OnUpdated: func(prev, cur DiscoveredAsset) { updated++ },
+	})
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	defer handle.Stop()
+
+	assets = []Asset{{ID: "asset-1", HasAgent: true}}
+	if err := handle.round(context.Background()); err != nil {
+		t.Fatalf("round() error = %v", err)
+	}
+
+	if updated != 1 {
+		t.Errorf("updated = %d, want 1", updated)
+	}
+}
+
+func TestMemoryDiscoveryStoreRoundTrip(t *testing.T) {
+	store := NewMemoryDiscoveryStore()
+	ctx := context.Background()
+
+	record := DiscoveredAsset{Asset: Asset{ID: "asset-1"}, FirstSeen: time.Now(), LastSeen: time.Now()}
+	if err := store.Put(ctx, "asset-1", record); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	loaded, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if _, ok := loaded["asset-1"]; !ok {
+		t.Fatal("Load() missing asset-1")
+	}
+
+	if err := store.Delete(ctx, "asset-1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	loaded, err = store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if _, ok := loaded["asset-1"]; ok {
+		t.Error("Load() still has asset-1 after Delete()")
+	}
+}
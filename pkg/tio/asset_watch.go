@@ -0,0 +1,272 @@
+package tio
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/base/filter"
+)
+
+// AssetEventType identifies what changed about an asset.
+type AssetEventType string
+
+const (
+	AssetEventCreated AssetEventType = "created"
+	AssetEventUpdated AssetEventType = "updated"
+	AssetEventDeleted AssetEventType = "deleted"
+)
+
+// AssetEvent is one change Watch observed. Delivery is at-least-once: a
+// Watch resumed from a Store-persisted cursor after an interruption may
+// re-emit an event already delivered before the interruption (the write
+// to Store and the send on the channel aren't atomic), but, except under
+// WatchDropOldest, which discards buffered events when the channel is
+// full, Watch never silently skips a change. Consumers that need exactly-once processing
+// should dedupe on (Asset.ID, Type, Asset.UpdatedAt).
+type AssetEvent struct {
+	Type AssetEventType
+	// Asset is the asset's current state. For AssetEventDeleted this is
+	// the last state Watch observed, since a deleted asset is no longer
+	// fetchable.
+	Asset Asset
+	// Previous is the last-known state of the asset before this event.
+	// Zero for AssetEventCreated.
+	Previous Asset
+	Time     time.Time
+}
+
+// WatchBackpressure controls what Watch does when its event channel is
+// full and the consumer isn't keeping up.
+type WatchBackpressure int
+
+const (
+	// WatchBlock makes Watch's poll loop block on a full channel until
+	// the consumer drains it (or ctx is canceled). No events are lost,
+	// but a slow consumer delays detection of subsequent changes.
+	WatchBlock WatchBackpressure = iota
+	// WatchDropOldest discards the oldest buffered, undelivered event to
+	// make room for the newest one, trading completeness for freshness.
+	WatchDropOldest
+)
+
+// WatchOptions configures Watch.
+type WatchOptions struct {
+	// Filter additionally restricts which assets are watched, e.g.
+	// filter.Eq("network_id", prodNetworkID).
+	Filter filter.Expr
+	// Interval is how often Watch polls for changes. Defaults to 5
+	// minutes.
+	Interval time.Duration
+	// Store persists the watermark (the newest Asset.UpdatedAt seen) and
+	// last-known state of every watched asset, the same DiscoveryStore
+	// interface AssetsAPI.Discover uses, so a restarted Watch resumes
+	// from where it left off instead of replaying full history. Defaults
+	// to a MemoryDiscoveryStore, which has no such durability.
+	Store DiscoveryStore
+	// BufferSize is the capacity of the returned event channel. Defaults
+	// to 256.
+	BufferSize int
+	// Backpressure selects what happens when the event channel is full.
+	// Defaults to WatchBlock.
+	Backpressure WatchBackpressure
+	// ResyncEvery is how many polls occur between full-listing resyncs.
+	// Incremental polls only ask for assets with updated_at at or after
+	// the current watermark, which can never observe a deletion; every
+	// ResyncEvery-th poll (and always the first) instead lists every
+	// asset matching Filter, and any previously-known asset UUID absent
+	// from that listing is emitted as AssetEventDeleted. Defaults to 12
+	// (e.g. once an hour at the default 5-minute Interval).
+	ResyncEvery int
+}
+
+func (o WatchOptions) withDefaults() WatchOptions {
+	if o.Interval <= 0 {
+		o.Interval = 5 * time.Minute
+	}
+	if o.Store == nil {
+		o.Store = NewMemoryDiscoveryStore()
+	}
+	if o.BufferSize <= 0 {
+		o.BufferSize = 256
+	}
+	if o.ResyncEvery <= 0 {
+		o.ResyncEvery = 12
+	}
+	return o
+}
+
+// Watch polls AssetsAPI.List at opts.Interval and streams Created/
+// Updated/Deleted events on the returned channel as assets appear,
+// change, or disappear. It stops and closes the channel once ctx is
+// canceled. See WatchOptions for cursor persistence, backpressure, and
+// resync behavior.
+func (a *AssetsAPI) Watch(ctx context.Context, opts WatchOptions) (<-chan AssetEvent, error) {
+	opts = opts.withDefaults()
+
+	known, err := opts.Store.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("watch: load store: %w", err)
+	}
+	if known == nil {
+		known = make(map[string]DiscoveredAsset)
+	}
+
+	w := &assetWatcher{
+		assets: a,
+		opts:   opts,
+		known:  known,
+		cursor: watermark(known),
+		events: make(chan AssetEvent, opts.BufferSize),
+	}
+
+	go w.run(ctx)
+
+	return w.events, nil
+}
+
+// watermark returns the newest Asset.UpdatedAt across known, the cursor
+// an incremental poll resumes from.
+func watermark(known map[string]DiscoveredAsset) time.Time {
+	var latest time.Time
+	for _, rec := range known {
+		if rec.Asset.UpdatedAt.After(latest) {
+			latest = rec.Asset.UpdatedAt
+		}
+	}
+	return latest
+}
+
+type assetWatcher struct {
+	assets *AssetsAPI
+	opts   WatchOptions
+	known  map[string]DiscoveredAsset
+	cursor time.Time
+	events chan AssetEvent
+}
+
+func (w *assetWatcher) run(ctx context.Context) {
+	defer close(w.events)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for poll := 1; ; poll++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		resync := poll == 1 || poll%w.opts.ResyncEvery == 0
+		if err := w.poll(ctx, resync); err != nil && ctx.Err() != nil {
+			return
+		}
+
+		timer.Reset(w.opts.Interval)
+	}
+}
+
+// poll lists assets (a full listing if resync, else just those updated
+// at or after the cursor), diffs the results against w.known, persists
+// the changes, and emits the corresponding events. Errors from Store or
+// AssetsAPI.List are swallowed after logging nothing (matching
+// AssetsAPI.Discover's round, which also tolerates a failed round and
+// retries on the next poll) except when ctx has been canceled, in which
+// case run stops.
+func (w *assetWatcher) poll(ctx context.Context, resync bool) error {
+	listOpts := &AssetListOptions{FilterExpr: filterForPoll(w.opts.Filter, w.cursor, resync)}
+
+	seen := make(map[string]bool)
+	it := w.assets.List(ctx, listOpts)
+	for it.Next() {
+		asset := it.Item()
+		seen[asset.ID] = true
+		w.observe(ctx, asset)
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	if resync {
+		for uuid, prev := range w.known {
+			if seen[uuid] {
+				continue
+			}
+			delete(w.known, uuid)
+			w.emit(ctx, AssetEvent{Type: AssetEventDeleted, Asset: prev.Asset, Previous: prev.Asset, Time: time.Now()})
+			if ctx.Err() == nil {
+				_ = w.opts.Store.Delete(ctx, uuid)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (w *assetWatcher) observe(ctx context.Context, asset Asset) {
+	prev, existed := w.known[asset.ID]
+
+	record := DiscoveredAsset{Asset: asset, FirstSeen: asset.FirstSeen, LastSeen: time.Now()}
+	if existed {
+		record.FirstSeen = prev.FirstSeen
+	}
+	w.known[asset.ID] = record
+
+	if asset.UpdatedAt.After(w.cursor) {
+		w.cursor = asset.UpdatedAt
+	}
+
+	switch {
+	case !existed:
+		w.emit(ctx, AssetEvent{Type: AssetEventCreated, Asset: asset, Time: time.Now()})
+	case !prev.Asset.UpdatedAt.Equal(asset.UpdatedAt):
+		w.emit(ctx, AssetEvent{Type: AssetEventUpdated, Asset: asset, Previous: prev.Asset, Time: time.Now()})
+	}
+	// Persist only once the event has been handed off, so an interruption
+	// in between re-emits the change after a restart rather than losing it.
+	if ctx.Err() == nil {
+		_ = w.opts.Store.Put(ctx, asset.ID, record)
+	}
+}
+
+// emit delivers ev according to w.opts.Backpressure, returning early if
+// ctx is canceled first.
+func (w *assetWatcher) emit(ctx context.Context, ev AssetEvent) {
+	if w.opts.Backpressure == WatchDropOldest {
+		for {
+			select {
+			case w.events <- ev:
+				return
+			case <-ctx.Done():
+				return
+			default:
+			}
+			select {
+			case <-w.events:
+			default:
+			}
+		}
+	}
+
+	select {
+	case w.events <- ev:
+	case <-ctx.Done():
+	}
+}
+
+// filterForPoll builds the Filter for one poll: a full listing (base,
+// unmodified) for a resync, or base narrowed to assets updated at or
+// after cursor for an incremental poll. A zero cursor (Watch's first
+// incremental poll never happens, since poll 1 always resyncs) would
+// otherwise match everything anyway.
+func filterForPoll(base filter.Expr, cursor time.Time, resync bool) filter.Expr {
+	if resync || cursor.IsZero() {
+		return base
+	}
+	since := filter.Ge("updated_at", cursor.Format(time.RFC3339))
+	if base.IsZero() {
+		return since
+	}
+	return base.And(since)
+}
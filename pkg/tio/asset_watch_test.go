@@ -0,0 +1,139 @@
+package tio
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func watchTestClient(t *testing.T, assets *[]Asset) *Client {
+	t.Helper()
+	client, err := New(WithAPIKeys("access", "secret"), WithHTTPTransport(discoveryFixtureTransport(assets)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return client
+}
+
+func newTestWatcher(t *testing.T, client *Client, opts WatchOptions) *assetWatcher {
+	t.Helper()
+	opts = opts.withDefaults()
+	known, err := opts.Store.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Store.Load() error = %v", err)
+	}
+	if known == nil {
+		known = make(map[string]DiscoveredAsset)
+	}
+	return &assetWatcher{
+		assets: client.Assets,
+		opts:   opts,
+		known:  known,
+		cursor: watermark(known),
+		events: make(chan AssetEvent, opts.BufferSize),
+	}
+}
+
+func TestWatchEmitsCreatedThenUpdatedThenDeleted(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	assets := []Asset{{ID: "asset-1", UpdatedAt: base}}
+	client := watchTestClient(t, &assets)
+	ctx := context.Background()
+
+	w := newTestWatcher(t, client, WatchOptions{})
+
+	if err := w.poll(ctx, true); err != nil {
+		t.Fatalf("poll(resync) error = %v", err)
+	}
+	ev := <-w.events
+	if ev.Type != AssetEventCreated || ev.Asset.ID != "asset-1" {
+		t.Fatalf("first event = %+v, want Created asset-1", ev)
+	}
+
+	assets = []Asset{{ID: "asset-1", UpdatedAt: base.Add(time.Minute)}}
+	if err := w.poll(ctx, false); err != nil {
+		t.Fatalf("poll(incremental) error = %v", err)
+	}
+	ev = <-w.events
+	if ev.Type != AssetEventUpdated || !ev.Previous.UpdatedAt.Equal(base) {
+		t.Fatalf("second event = %+v, want Updated with Previous.UpdatedAt = %v", ev, base)
+	}
+
+	assets = nil
+	if err := w.poll(ctx, true); err != nil {
+		t.Fatalf("poll(resync) error = %v", err)
+	}
+	ev = <-w.events
+	if ev.Type != AssetEventDeleted || ev.Asset.ID != "asset-1" {
+		t.Fatalf("third event = %+v, want Deleted asset-1", ev)
+	}
+
+	if len(w.known) != 0 {
+		t.Errorf("known = %v, want empty after Deleted", w.known)
+	}
+}
+
+func TestWatchIncrementalPollNeverEmitsDeleted(t *testing.T) {
+	assets := []Asset{{ID: "asset-1"}}
+	client := watchTestClient(t, &assets)
+	ctx := context.Background()
+
+	w := newTestWatcher(t, client, WatchOptions{})
+	if err := w.poll(ctx, true); err != nil {
+		t.Fatalf("poll(resync) error = %v", err)
+	}
+	<-w.events // Created
+
+	assets = nil
+	if err := w.poll(ctx, false); err != nil {
+		t.Fatalf("poll(incremental) error = %v", err)
+	}
+
+	select {
+	case ev := <-w.events:
+		t.Fatalf("unexpected event on incremental poll: %+v", ev)
+	default:
+	}
+	if len(w.known) != 1 {
+		t.Errorf("known = %v, want asset-1 still tracked (only a resync can detect its absence)", w.known)
+	}
+}
+
+func TestWatchDropOldestDiscardsOldestOnFullChannel(t *testing.T) {
+	ctx := context.Background()
+	w := &assetWatcher{
+		opts:   WatchOptions{Backpressure: WatchDropOldest},
+		events: make(chan AssetEvent, 2),
+	}
+
+	w.emit(ctx, AssetEvent{Asset: Asset{ID: "a"}})
+	w.emit(ctx, AssetEvent{Asset: Asset{ID: "b"}})
+	w.emit(ctx, AssetEvent{Asset: Asset{ID: "c"}})
+
+	first := <-w.events
+	second := <-w.events
+	if first.Asset.ID != "b" || second.Asset.ID != "c" {
+		t.Fatalf("got events %s, %s; want b, c (a should have been dropped)", first.Asset.ID, second.Asset.ID)
+	}
+}
+
+func TestWatchPersistsKnownStateToStore(t *testing.T) {
+	assets := []Asset{{ID: "asset-1"}}
+	client := watchTestClient(t, &assets)
+	ctx := context.Background()
+
+	store := NewMemoryDiscoveryStore()
+	w := newTestWatcher(t, client, WatchOptions{Store: store})
+	if err := w.poll(ctx, true); err != nil {
+		t.Fatalf("poll(resync) error = %v", err)
+	}
+	<-w.events
+
+	loaded, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if _, ok := loaded["asset-1"]; !ok {
+		t.Error("Store has no record for asset-1 after poll")
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
-	"github.com/tenable/gotenable/pkg/base"
+	"github.com/biggeezerdevelopment/gotenable/pkg/base"
+	"github.com/biggeezerdevelopment/gotenable/pkg/base/filter"
 )
 
 // AssetsAPI handles asset-related operations.
@@ -17,62 +19,62 @@ type AssetsAPI struct {
 
 // Asset represents an asset in Tenable.io.
 type Asset struct {
-	ID                    string    `json:"id"`
-	HasAgent              bool      `json:"has_agent"`
-	HasPluginResults      bool      `json:"has_plugin_results"`
-	CreatedAt             time.Time `json:"created_at"`
-	TerminatedAt          time.Time `json:"terminated_at,omitempty"`
-	TerminatedBy          string    `json:"terminated_by,omitempty"`
-	UpdatedAt             time.Time `json:"updated_at"`
-	DeletedAt             time.Time `json:"deleted_at,omitempty"`
-	DeletedBy             string    `json:"deleted_by,omitempty"`
-	FirstSeen             time.Time `json:"first_seen"`
-	LastSeen              time.Time `json:"last_seen"`
-	FirstScanTime         time.Time `json:"first_scan_time,omitempty"`
-	LastScanTime          time.Time `json:"last_scan_time,omitempty"`
-	LastAuthenticatedScanDate time.Time `json:"last_authenticated_scan_date,omitempty"`
-	LastLicensedScanDate  time.Time `json:"last_licensed_scan_date,omitempty"`
-	LastScheduleID        string    `json:"last_schedule_id,omitempty"`
-	AzureVMID             string    `json:"azure_vm_id,omitempty"`
-	AzureResourceID       string    `json:"azure_resource_id,omitempty"`
-	AWSEC2InstanceAMIID   string    `json:"aws_ec2_instance_ami_id,omitempty"`
-	AWSEC2InstanceID      string    `json:"aws_ec2_instance_id,omitempty"`
-	AgentUUID             string    `json:"agent_uuid,omitempty"`
-	BiosUUID              string    `json:"bios_uuid,omitempty"`
-	NetworkID             string    `json:"network_id,omitempty"`
-	NetworkName           string    `json:"network_name,omitempty"`
-	AWSEC2InstanceGroupName string  `json:"aws_ec2_instance_group_name,omitempty"`
-	AWSEC2InstanceStateName string  `json:"aws_ec2_instance_state_name,omitempty"`
-	AWSEC2InstanceType    string    `json:"aws_ec2_instance_type,omitempty"`
-	AWSOwnerID            string    `json:"aws_owner_id,omitempty"`
-	AWSAvailabilityZone   string    `json:"aws_availability_zone,omitempty"`
-	AWSEC2ProductCode     string    `json:"aws_ec2_product_code,omitempty"`
-	AWSSubnetID           string    `json:"aws_subnet_id,omitempty"`
-	AWSVPCID              string    `json:"aws_vpc_id,omitempty"`
-	AWSRegion             string    `json:"aws_region,omitempty"`
-	MacAddress            []string  `json:"mac_address,omitempty"`
-	McafeeEPOGUID         string    `json:"mcafee_epo_guid,omitempty"`
-	McafeeEPOAgentGUID    string    `json:"mcafee_epo_agent_guid,omitempty"`
-	NetbiosName           []string  `json:"netbios_name,omitempty"`
-	OperatingSystem       []string  `json:"operating_system,omitempty"`
-	SystemType            []string  `json:"system_type,omitempty"`
-	TenableUUID           string    `json:"tenable_uuid,omitempty"`
-	Hostname              []string  `json:"hostname,omitempty"`
-	AgentName             []string  `json:"agent_name,omitempty"`
-	FQDN                  []string  `json:"fqdn,omitempty"`
-	IPv4                  []string  `json:"ipv4,omitempty"`
-	IPv6                  []string  `json:"ipv6,omitempty"`
-	SSHFingerprint        []string  `json:"ssh_fingerprint,omitempty"`
-	QualysAssetID         string    `json:"qualys_asset_id,omitempty"`
-	QualysHostID          string    `json:"qualys_host_id,omitempty"`
-	ServiceNowSystemID    string    `json:"servicenow_sysid,omitempty"`
-	InstalledSoftware     []string  `json:"installed_software,omitempty"`
-	Sources               []AssetSource `json:"sources,omitempty"`
-	Tags                  []AssetTag    `json:"tags,omitempty"`
-	AcrScore              int       `json:"acr_score,omitempty"`
-	AcrDrivers            []ACRDriver `json:"acr_drivers,omitempty"`
-	ExposureScore         int       `json:"exposure_score,omitempty"`
-	ScanFrequency         int       `json:"scan_frequency,omitempty"`
+	ID                        string        `json:"id"`
+	HasAgent                  bool          `json:"has_agent"`
+	HasPluginResults          bool          `json:"has_plugin_results"`
+	CreatedAt                 time.Time     `json:"created_at"`
+	TerminatedAt              time.Time     `json:"terminated_at,omitempty"`
+	TerminatedBy              string        `json:"terminated_by,omitempty"`
+	UpdatedAt                 time.Time     `json:"updated_at"`
+	DeletedAt                 time.Time     `json:"deleted_at,omitempty"`
+	DeletedBy                 string        `json:"deleted_by,omitempty"`
+	FirstSeen                 time.Time     `json:"first_seen"`
+	LastSeen                  time.Time     `json:"last_seen"`
+	FirstScanTime             time.Time     `json:"first_scan_time,omitempty"`
+	LastScanTime              time.Time     `json:"last_scan_time,omitempty"`
+	LastAuthenticatedScanDate time.Time     `json:"last_authenticated_scan_date,omitempty"`
+	LastLicensedScanDate      time.Time     `json:"last_licensed_scan_date,omitempty"`
+	LastScheduleID            string        `json:"last_schedule_id,omitempty"`
+	AzureVMID                 string        `json:"azure_vm_id,omitempty"`
+	AzureResourceID           string        `json:"azure_resource_id,omitempty"`
+	AWSEC2InstanceAMIID       string        `json:"aws_ec2_instance_ami_id,omitempty"`
+	AWSEC2InstanceID          string        `json:"aws_ec2_instance_id,omitempty"`
+	AgentUUID                 string        `json:"agent_uuid,omitempty"`
+	BiosUUID                  string        `json:"bios_uuid,omitempty"`
+	NetworkID                 string        `json:"network_id,omitempty"`
+	NetworkName               string        `json:"network_name,omitempty"`
+	AWSEC2InstanceGroupName   string        `json:"aws_ec2_instance_group_name,omitempty"`
+	AWSEC2InstanceStateName   string        `json:"aws_ec2_instance_state_name,omitempty"`
+	AWSEC2InstanceType        string        `json:"aws_ec2_instance_type,omitempty"`
+	AWSOwnerID                string        `json:"aws_owner_id,omitempty"`
+	AWSAvailabilityZone       string        `json:"aws_availability_zone,omitempty"`
+	AWSEC2ProductCode         string        `json:"aws_ec2_product_code,omitempty"`
+	AWSSubnetID               string        `json:"aws_subnet_id,omitempty"`
+	AWSVPCID                  string        `json:"aws_vpc_id,omitempty"`
+	AWSRegion                 string        `json:"aws_region,omitempty"`
+	MacAddress                []string      `json:"mac_address,omitempty"`
+	McafeeEPOGUID             string        `json:"mcafee_epo_guid,omitempty"`
+	McafeeEPOAgentGUID        string        `json:"mcafee_epo_agent_guid,omitempty"`
+	NetbiosName               []string      `json:"netbios_name,omitempty"`
+	OperatingSystem           []string      `json:"operating_system,omitempty"`
+	SystemType                []string      `json:"system_type,omitempty"`
+	TenableUUID               string        `json:"tenable_uuid,omitempty"`
+	Hostname                  []string      `json:"hostname,omitempty"`
+	AgentName                 []string      `json:"agent_name,omitempty"`
+	FQDN                      []string      `json:"fqdn,omitempty"`
+	IPv4                      []string      `json:"ipv4,omitempty"`
+	IPv6                      []string      `json:"ipv6,omitempty"`
+	SSHFingerprint            []string      `json:"ssh_fingerprint,omitempty"`
+	QualysAssetID             string        `json:"qualys_asset_id,omitempty"`
+	QualysHostID              string        `json:"qualys_host_id,omitempty"`
+	ServiceNowSystemID        string        `json:"servicenow_sysid,omitempty"`
+	InstalledSoftware         []string      `json:"installed_software,omitempty"`
+	Sources                   []AssetSource `json:"sources,omitempty"`
+	Tags                      []AssetTag    `json:"tags,omitempty"`
+	AcrScore                  int           `json:"acr_score,omitempty"`
+	AcrDrivers                []ACRDriver   `json:"acr_drivers,omitempty"`
+	ExposureScore             int           `json:"exposure_score,omitempty"`
+	ScanFrequency             int           `json:"scan_frequency,omitempty"`
 }
 
 // AssetSource represents the source of an asset.
@@ -84,11 +86,11 @@ type AssetSource struct {
 
 // AssetTag represents a tag on an asset.
 type AssetTag struct {
-	TagUUID      string    `json:"tag_uuid"`
-	TagKey       string    `json:"tag_key"`
-	TagValue     string    `json:"tag_value"`
-	AddedBy      string    `json:"added_by"`
-	AddedAt      time.Time `json:"added_at"`
+	TagUUID  string    `json:"tag_uuid"`
+	TagKey   string    `json:"tag_key"`
+	TagValue string    `json:"tag_value"`
+	AddedBy  string    `json:"added_by"`
+	AddedAt  time.Time `json:"added_at"`
 }
 
 // ACRDriver represents an ACR driver.
@@ -101,6 +103,10 @@ type ACRDriver struct {
 type AssetListOptions struct {
 	DateRange int    // Number of days to look back
 	Filter    string // Filter expression
+
+	// FilterExpr, if set, is rendered to an ODATA $filter string via
+	// filter.Expr.ODataFilter and takes precedence over Filter.
+	FilterExpr filter.Expr
 }
 
 // List retrieves a list of assets.
@@ -114,7 +120,14 @@ func (a *AssetsAPI) List(ctx context.Context, opts *AssetListOptions) *base.Iter
 			if opts.DateRange > 0 {
 				params["date_range"] = strconv.Itoa(opts.DateRange)
 			}
-			if opts.Filter != "" {
+			switch {
+			case !opts.FilterExpr.IsZero():
+				rendered, err := opts.FilterExpr.ODataFilter()
+				if err != nil {
+					return nil, nil, fmt.Errorf("assets: %w", err)
+				}
+				params["filter"] = rendered
+			case opts.Filter != "":
 				params["filter"] = opts.Filter
 			}
 		}
@@ -146,78 +159,119 @@ func (a *AssetsAPI) List(ctx context.Context, opts *AssetListOptions) *base.Iter
 	return base.NewIterator(ctx, fetcher, transformer)
 }
 
-// Get retrieves a specific asset by UUID.
+// Get retrieves a specific asset by UUID. If WithAssetCache was
+// configured, a live cache entry is returned without a request.
 func (a *AssetsAPI) Get(ctx context.Context, assetUUID string) (*Asset, error) {
+	if c := a.client.assetCache; c != nil {
+		if cached, ok := c.Get(assetUUID); ok {
+			return &cached, nil
+		}
+	}
+
 	var result Asset
 	_, err := a.client.Get(ctx, fmt.Sprintf("assets/%s", assetUUID), &result)
 	if err != nil {
 		return nil, err
 	}
+
+	if c := a.client.assetCache; c != nil {
+		c.Put(assetUUID, result)
+	}
 	return &result, nil
 }
 
 // Delete removes an asset.
 func (a *AssetsAPI) Delete(ctx context.Context, assetUUID string) error {
 	_, err := a.client.Delete(ctx, fmt.Sprintf("assets/%s", assetUUID))
-	return err
+	if err != nil {
+		return err
+	}
+
+	if c := a.client.assetCache; c != nil {
+		c.Delete(assetUUID)
+	}
+	if c := a.client.assetInfoCache; c != nil {
+		c.Delete(assetUUID)
+	}
+	return nil
 }
 
 // AssetInfo contains summary information about an asset.
 type AssetInfo struct {
-	ID                    string    `json:"id"`
-	HasAgent              bool      `json:"has_agent"`
-	HasPluginResults      bool      `json:"has_plugin_results"`
-	CreatedAt             time.Time `json:"created_at"`
-	UpdatedAt             time.Time `json:"updated_at"`
-	FirstSeen             time.Time `json:"first_seen"`
-	LastSeen              time.Time `json:"last_seen"`
-	IPv4                  []string  `json:"ipv4,omitempty"`
-	IPv6                  []string  `json:"ipv6,omitempty"`
-	FQDN                  []string  `json:"fqdn,omitempty"`
-	Hostname              []string  `json:"hostname,omitempty"`
-	NetbiosName           []string  `json:"netbios_name,omitempty"`
-	OperatingSystem       []string  `json:"operating_system,omitempty"`
-	MacAddress            []string  `json:"mac_address,omitempty"`
-	AgentName             []string  `json:"agent_name,omitempty"`
+	ID               string    `json:"id"`
+	HasAgent         bool      `json:"has_agent"`
+	HasPluginResults bool      `json:"has_plugin_results"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	FirstSeen        time.Time `json:"first_seen"`
+	LastSeen         time.Time `json:"last_seen"`
+	IPv4             []string  `json:"ipv4,omitempty"`
+	IPv6             []string  `json:"ipv6,omitempty"`
+	FQDN             []string  `json:"fqdn,omitempty"`
+	Hostname         []string  `json:"hostname,omitempty"`
+	NetbiosName      []string  `json:"netbios_name,omitempty"`
+	OperatingSystem  []string  `json:"operating_system,omitempty"`
+	MacAddress       []string  `json:"mac_address,omitempty"`
+	AgentName        []string  `json:"agent_name,omitempty"`
 }
 
-// Info retrieves summary information about an asset.
+// Info retrieves summary information about an asset. If WithAssetCache
+// was configured, a live cache entry is returned without a request.
 func (a *AssetsAPI) Info(ctx context.Context, assetUUID string) (*AssetInfo, error) {
+	if c := a.client.assetInfoCache; c != nil {
+		if cached, ok := c.Get(assetUUID); ok {
+			return &cached, nil
+		}
+	}
+
 	var result AssetInfo
 	_, err := a.client.Get(ctx, fmt.Sprintf("assets/%s/info", assetUUID), &result)
 	if err != nil {
 		return nil, err
 	}
+
+	if c := a.client.assetInfoCache; c != nil {
+		c.Put(assetUUID, result)
+	}
 	return &result, nil
 }
 
 // AssetVulnerability represents a vulnerability on an asset.
 type AssetVulnerability struct {
-	PluginID           int       `json:"plugin_id"`
-	PluginName         string    `json:"plugin_name"`
-	PluginFamily       string    `json:"plugin_family"`
-	Severity           int       `json:"severity"`
-	SeverityIndex      int       `json:"severity_index"`
-	VPRScore           float64   `json:"vpr_score,omitempty"`
-	State              string    `json:"state"`
-	Count              int       `json:"count"`
-	FirstFound         time.Time `json:"first_found"`
-	LastFound          time.Time `json:"last_found"`
-	LastFixed          time.Time `json:"last_fixed,omitempty"`
-	AcceptedCount      int       `json:"accepted_count,omitempty"`
-	RecastedCount      int       `json:"recasted_count,omitempty"`
-	CountsTotal        int       `json:"counts_by_severity_total,omitempty"`
-	CVSSBaseScore      float64   `json:"cvss_base_score,omitempty"`
-	CVSSTemporalScore  float64   `json:"cvss_temporal_score,omitempty"`
+	PluginID          int       `json:"plugin_id"`
+	PluginName        string    `json:"plugin_name"`
+	PluginFamily      string    `json:"plugin_family"`
+	Severity          int       `json:"severity"`
+	SeverityIndex     int       `json:"severity_index"`
+	VPRScore          float64   `json:"vpr_score,omitempty"`
+	State             string    `json:"state"`
+	Count             int       `json:"count"`
+	FirstFound        time.Time `json:"first_found"`
+	LastFound         time.Time `json:"last_found"`
+	LastFixed         time.Time `json:"last_fixed,omitempty"`
+	AcceptedCount     int       `json:"accepted_count,omitempty"`
+	RecastedCount     int       `json:"recasted_count,omitempty"`
+	CountsTotal       int       `json:"counts_by_severity_total,omitempty"`
+	CVSSBaseScore     float64   `json:"cvss_base_score,omitempty"`
+	CVSSTemporalScore float64   `json:"cvss_temporal_score,omitempty"`
 }
 
-// Vulnerabilities retrieves vulnerabilities for an asset.
-func (a *AssetsAPI) Vulnerabilities(ctx context.Context, assetUUID string) *base.Iterator[AssetVulnerability] {
+// Vulnerabilities retrieves vulnerabilities for an asset, optionally
+// narrowed by a filter.Expr (e.g. filter.Ge("severity", 3)), rendered to
+// an ODATA $filter string.
+func (a *AssetsAPI) Vulnerabilities(ctx context.Context, assetUUID string, expr ...filter.Expr) *base.Iterator[AssetVulnerability] {
 	fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *base.PaginationInfo, error) {
 		params := map[string]string{
 			"limit":  strconv.Itoa(limit),
 			"offset": strconv.Itoa(offset),
 		}
+		if len(expr) > 0 && !expr[0].IsZero() {
+			rendered, err := expr[0].ODataFilter()
+			if err != nil {
+				return nil, nil, fmt.Errorf("assets: %w", err)
+			}
+			params["filter"] = rendered
+		}
 
 		var result struct {
 			Vulnerabilities []AssetVulnerability `json:"vulnerabilities"`
@@ -248,18 +302,80 @@ func (a *AssetsAPI) Vulnerabilities(ctx context.Context, assetUUID string) *base
 
 // BulkDeleteRequest represents a request to bulk delete assets.
 type BulkDeleteRequest struct {
-	Query   *BulkDeleteQuery `json:"query,omitempty"`
-	HardDelete bool          `json:"hard_delete,omitempty"`
+	Query      *BulkDeleteQuery `json:"query,omitempty"`
+	HardDelete bool             `json:"hard_delete,omitempty"`
 }
 
-// BulkDeleteQuery represents the query for bulk delete.
+// BulkDeleteQuery represents the query for bulk delete: either a single
+// field/operator/value comparison, or an And/Or combination of nested
+// queries. Build one by hand for a simple comparison, or derive it from
+// a filter.Expr via BulkDeleteQueryFromExpr for anything more elaborate.
 type BulkDeleteQuery struct {
-	Field    string `json:"field"`
-	Operator string `json:"operator"`
-	Value    string `json:"value"`
+	Field    string             `json:"field,omitempty"`
+	Operator string             `json:"operator,omitempty"`
+	Value    string             `json:"value,omitempty"`
+	And      []*BulkDeleteQuery `json:"and,omitempty"`
+	Or       []*BulkDeleteQuery `json:"or,omitempty"`
+}
+
+// BulkDeleteQueryFromExpr converts expr into a BulkDeleteQuery, so the
+// same filter.Expr used to build an ODataFilter or Params can also target
+// BulkDelete. expr can't use filter.Not: BulkDeleteQuery has no
+// negation of its own to render it into.
+func BulkDeleteQueryFromExpr(expr filter.Expr) (*BulkDeleteQuery, error) {
+	tree, err := expr.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("assets: %w", err)
+	}
+	return bulkDeleteQueryFromTree(tree)
 }
 
-// BulkDelete deletes multiple assets based on a query.
+func bulkDeleteQueryFromTree(t filter.Tree) (*BulkDeleteQuery, error) {
+	switch t.Op {
+	case "":
+		return &BulkDeleteQuery{
+			Field:    t.Leaf.Field,
+			Operator: t.Leaf.Operator,
+			Value:    joinFilterValues(t.Leaf.Values),
+		}, nil
+	case "and", "or":
+		children := make([]*BulkDeleteQuery, len(t.Children))
+		for i, c := range t.Children {
+			q, err := bulkDeleteQueryFromTree(c)
+			if err != nil {
+				return nil, err
+			}
+			children[i] = q
+		}
+		if t.Op == "and" {
+			return &BulkDeleteQuery{And: children}, nil
+		}
+		return &BulkDeleteQuery{Or: children}, nil
+	default:
+		return nil, fmt.Errorf("assets: BulkDeleteQuery has no representation for filter.Not()")
+	}
+}
+
+// joinFilterValues renders a filter.Leaf's values the same way
+// filter.Expr.Params' numbered-params rendering does: a single
+// value as-is, multiple (an "in" comparison) comma-joined, with
+// time.Time formatted as RFC3339.
+func joinFilterValues(values []interface{}) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		if t, ok := v.(time.Time); ok {
+			parts[i] = t.UTC().Format(time.RFC3339)
+			continue
+		}
+		parts[i] = fmt.Sprint(v)
+	}
+	return strings.Join(parts, ",")
+}
+
+// BulkDelete deletes multiple assets based on a query. Since the matched
+// assets are selected by filter rather than named by UUID, any cached
+// AssetsAPI.Get/Info entries among them aren't invalidated here; they
+// fall out of WithAssetCache's cache once their TTL expires.
 func (a *AssetsAPI) BulkDelete(ctx context.Context, req *BulkDeleteRequest) error {
 	_, err := a.client.Post(ctx, "assets/bulk-jobs/delete", req, nil)
 	return err
@@ -273,7 +389,11 @@ func (a *AssetsAPI) AssignTags(ctx context.Context, assetUUIDs []string, tagUUID
 		"tags":   tagUUIDs,
 	}
 	_, err := a.client.Post(ctx, "tags/assets/assignments", payload, nil)
-	return err
+	if err != nil {
+		return err
+	}
+	a.invalidateAssetCache(assetUUIDs)
+	return nil
 }
 
 // UnassignTags removes tags from assets.
@@ -284,7 +404,11 @@ func (a *AssetsAPI) UnassignTags(ctx context.Context, assetUUIDs []string, tagUU
 		"tags":   tagUUIDs,
 	}
 	_, err := a.client.Post(ctx, "tags/assets/assignments", payload, nil)
-	return err
+	if err != nil {
+		return err
+	}
+	a.invalidateAssetCache(assetUUIDs)
+	return nil
 }
 
 // MoveToNetwork moves assets to a different network.
@@ -295,6 +419,22 @@ func (a *AssetsAPI) MoveToNetwork(ctx context.Context, sourceNetworkID, destNetw
 		"targets":     assetUUIDs,
 	}
 	_, err := a.client.Post(ctx, "assets/bulk-jobs/move-to-network", payload, nil)
-	return err
+	if err != nil {
+		return err
+	}
+	a.invalidateAssetCache(assetUUIDs)
+	return nil
 }
 
+// invalidateAssetCache drops assetUUIDs from the WithAssetCache Get
+// cache, a no-op if no cache is configured. AssetInfo doesn't carry tags
+// or network membership, so the Info cache is left alone.
+func (a *AssetsAPI) invalidateAssetCache(assetUUIDs []string) {
+	c := a.client.assetCache
+	if c == nil {
+		return
+	}
+	for _, id := range assetUUIDs {
+		c.Delete(id)
+	}
+}
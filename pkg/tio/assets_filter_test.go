@@ -0,0 +1,66 @@
+package tio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/base/filter"
+)
+
+func TestBulkDeleteQueryFromExprConjunction(t *testing.T) {
+	expr := filter.Eq("operating_system", "Windows").And(filter.Gt("severity", 3))
+
+	got, err := BulkDeleteQueryFromExpr(expr)
+	if err != nil {
+		t.Fatalf("BulkDeleteQueryFromExpr() error = %v", err)
+	}
+	if len(got.And) != 2 {
+		t.Fatalf("And = %+v, want 2 children", got.And)
+	}
+	if got.And[0].Field != "operating_system" || got.And[0].Operator != "eq" || got.And[0].Value != "Windows" {
+		t.Errorf("And[0] = %+v, want operating_system eq Windows", got.And[0])
+	}
+	if got.And[1].Field != "severity" || got.And[1].Operator != "gt" || got.And[1].Value != "3" {
+		t.Errorf("And[1] = %+v, want severity gt 3", got.And[1])
+	}
+}
+
+func TestBulkDeleteQueryFromExprRejectsNot(t *testing.T) {
+	expr := filter.Not(filter.Eq("field", "x"))
+	if _, err := BulkDeleteQueryFromExpr(expr); err == nil {
+		t.Error("BulkDeleteQueryFromExpr() error = nil, want error for filter.Not()")
+	}
+}
+
+func TestAssetListUsesFilterExprOverFilter(t *testing.T) {
+	var gotFilter string
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		gotFilter = r.URL.Query().Get("filter")
+		body, _ := json.Marshal(map[string]interface{}{"assets": []Asset{}, "total": 0})
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(bytes.NewReader(body)),
+		}, nil
+	})
+
+	client, err := New(WithAPIKeys("access", "secret"), WithHTTPTransport(transport))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	opts := &AssetListOptions{
+		Filter:     "ignored eq 'this'",
+		FilterExpr: filter.Eq("operating_system", "Windows"),
+	}
+	it := client.Assets.List(context.Background(), opts)
+	it.Next()
+
+	if want := "operating_system eq 'Windows'"; gotFilter != want {
+		t.Errorf("filter param = %q, want %q", gotFilter, want)
+	}
+}
@@ -129,3 +129,137 @@ func (a *AuditLogAPI) Events(ctx context.Context, opts *AuditLogOptions) *base.I
 	return base.NewIterator(ctx, fetcher, transformer)
 }
 
+// Cursor is the position AuditLogAPI.Follow tracks while tailing:
+// LastReceived is the newest event time delivered, and SeenIDs holds the
+// IDs of every event observed at LastReceived, since the audit log can
+// have more than one event sharing the same instant. It marshals to JSON.
+// Follow keeps its Cursor internally; it neither returns one nor accepts
+// one to resume from.
+type Cursor struct {
+	LastReceived time.Time
+	SeenIDs      []string
+}
+
+// cursorJSON is Cursor's on-disk shape; kept separate so Cursor's Go field
+// names can change without breaking persisted checkpoints.
+type cursorJSON struct {
+	LastReceived time.Time `json:"last_received"`
+	SeenIDs      []string  `json:"seen_ids,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c Cursor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cursorJSON{LastReceived: c.LastReceived, SeenIDs: c.SeenIDs})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Cursor) UnmarshalJSON(data []byte) error {
+	var cj cursorJSON
+	if err := json.Unmarshal(data, &cj); err != nil {
+		return err
+	}
+	c.LastReceived = cj.LastReceived
+	c.SeenIDs = cj.SeenIDs
+	return nil
+}
+
+// hasSeen reports whether id was already delivered at LastReceived.
+func (c *Cursor) hasSeen(id string) bool {
+	for _, seen := range c.SeenIDs {
+		if seen == id {
+			return true
+		}
+	}
+	return false
+}
+
+// advance folds a batch of newly delivered events (in the order returned
+// by Events) into the cursor, keeping only the IDs seen at the newest
+// timestamp so SeenIDs doesn't grow unbounded across a long Follow run.
+func (c *Cursor) advance(events []AuditEvent) {
+	for _, e := range events {
+		switch {
+		case e.Received.After(c.LastReceived):
+			c.LastReceived = e.Received
+			c.SeenIDs = []string{e.ID}
+		case e.Received.Equal(c.LastReceived):
+			c.SeenIDs = append(c.SeenIDs, e.ID)
+		}
+	}
+}
+
+// Follow tails AuditLogAPI.Events, polling every interval for events newer
+// than the last one delivered. Within a run, Follow dedupes by
+// AuditEvent.ID to guard against events sharing a timestamp with the
+// previous poll. To pick up after a restart, set opts.FromDate to the
+// Received time of the last event handled; a new Follow starts with no
+// SeenIDs, so events received at exactly that instant are delivered again.
+//
+// The returned channels close once ctx is done. Errors encountered during
+// a poll are delivered on the error channel without stopping the stream;
+// only ctx cancellation ends it.
+func (a *AuditLogAPI) Follow(ctx context.Context, opts *AuditLogOptions, interval time.Duration) (<-chan AuditEvent, <-chan error) {
+	events := make(chan AuditEvent)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		var template AuditLogOptions
+		if opts != nil {
+			template = *opts
+		}
+
+		var cursor Cursor
+		if template.FromDate != nil {
+			cursor.LastReceived = *template.FromDate
+		}
+
+		for {
+			query := template
+			if !cursor.LastReceived.IsZero() {
+				from := cursor.LastReceived
+				query.FromDate = &from
+			}
+
+			items, err := a.Events(ctx, &query).All()
+			if err != nil {
+				select {
+				case errs <- err:
+				case <-ctx.Done():
+					return
+				}
+			} else {
+				fresh := items[:0:0]
+				for _, e := range items {
+					if e.Received.Before(cursor.LastReceived) {
+						continue
+					}
+					if e.Received.Equal(cursor.LastReceived) && cursor.hasSeen(e.ID) {
+						continue
+					}
+					fresh = append(fresh, e)
+				}
+
+				for _, e := range fresh {
+					select {
+					case events <- e:
+					case <-ctx.Done():
+						return
+					}
+				}
+				cursor.advance(fresh)
+			}
+
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, errs
+}
+
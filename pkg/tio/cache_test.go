@@ -0,0 +1,111 @@
+package tio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingTransport counts every request it serves and answers with body
+// for any request, regardless of path.
+type countingTransport struct {
+	requests int32
+	body     func(r *http.Request) interface{}
+}
+
+func (c *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&c.requests, 1)
+	data, _ := json.Marshal(c.body(r))
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(data)),
+	}, nil
+}
+
+func TestWithAssetCacheAvoidsRepeatRequests(t *testing.T) {
+	transport := &countingTransport{body: func(r *http.Request) interface{} {
+		return Asset{ID: "asset-1"}
+	}}
+
+	client, err := New(WithAPIKeys("access", "secret"), WithHTTPTransport(transport), WithAssetCache(10, time.Minute))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Assets.Get(context.Background(), "asset-1"); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&transport.requests); got != 1 {
+		t.Errorf("requests = %d, want 1 (later Gets should hit the cache)", got)
+	}
+
+	if err := client.Assets.Delete(context.Background(), "asset-1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := client.Assets.Get(context.Background(), "asset-1"); err != nil {
+		t.Fatalf("Get() after Delete() error = %v", err)
+	}
+	// 1 (first Get) + 1 (Delete itself) + 1 (Get after invalidation) = 3.
+	if got := atomic.LoadInt32(&transport.requests); got != 3 {
+		t.Errorf("requests after Delete() = %d, want 3 (Delete should invalidate the cache entry)", got)
+	}
+}
+
+func TestWithoutAssetCacheEveryGetIsARequest(t *testing.T) {
+	transport := &countingTransport{body: func(r *http.Request) interface{} {
+		return Asset{ID: "asset-1"}
+	}}
+
+	client, err := New(WithAPIKeys("access", "secret"), WithHTTPTransport(transport))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Assets.Get(context.Background(), "asset-1"); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&transport.requests); got != 3 {
+		t.Errorf("requests = %d, want 3 (no cache configured)", got)
+	}
+}
+
+func TestWithTagCacheAvoidsRepeatRequests(t *testing.T) {
+	transport := &countingTransport{body: func(r *http.Request) interface{} {
+		return TagCategory{UUID: "cat-1", Name: "Environment"}
+	}}
+
+	client, err := New(WithAPIKeys("access", "secret"), WithHTTPTransport(transport), WithTagCache(10, time.Minute))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Tags.GetCategory(context.Background(), "cat-1"); err != nil {
+			t.Fatalf("GetCategory() error = %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&transport.requests); got != 1 {
+		t.Errorf("requests = %d, want 1 (later GetCategory calls should hit the cache)", got)
+	}
+
+	if err := client.Tags.DeleteCategory(context.Background(), "cat-1"); err != nil {
+		t.Fatalf("DeleteCategory() error = %v", err)
+	}
+	if _, err := client.Tags.GetCategory(context.Background(), "cat-1"); err != nil {
+		t.Fatalf("GetCategory() after DeleteCategory() error = %v", err)
+	}
+	// 1 (first GetCategory) + 1 (DeleteCategory itself) + 1 (GetCategory after invalidation) = 3.
+	if got := atomic.LoadInt32(&transport.requests); got != 3 {
+		t.Errorf("requests after DeleteCategory() = %d, want 3 (Delete should invalidate the cache entry)", got)
+	}
+}
@@ -2,9 +2,13 @@
 package tio
 
 import (
+	"context"
+	"crypto/x509"
+	"net/http"
 	"time"
 
 	"github.com/biggeezerdevelopment/gotenable/pkg/base"
+	"github.com/biggeezerdevelopment/gotenable/pkg/base/cache"
 )
 
 const (
@@ -19,44 +23,68 @@ type Client struct {
 	*base.Client
 
 	// API endpoint interfaces
-	AccessControl    *AccessControlAPI
-	AgentConfig      *AgentConfigAPI
-	AgentExclusions  *AgentExclusionsAPI
-	AgentGroups      *AgentGroupsAPI
-	Agents           *AgentsAPI
-	Assets           *AssetsAPI
-	AuditLog         *AuditLogAPI
-	Credentials      *CredentialsAPI
-	Editor           *EditorAPI
-	Exclusions       *ExclusionsAPI
-	Exports          *ExportsAPI
-	Files            *FilesAPI
-	Filters          *FiltersAPI
-	Folders          *FoldersAPI
-	Groups           *GroupsAPI
-	Networks         *NetworksAPI
-	Permissions      *PermissionsAPI
-	Plugins          *PluginsAPI
-	Policies         *PoliciesAPI
-	RemediationScans *RemediationScansAPI
-	ScannerGroups    *ScannerGroupsAPI
-	Scanners         *ScannersAPI
-	Scans            *ScansAPI
-	Server           *ServerAPI
-	Session          *SessionAPI
-	Tags             *TagsAPI
-	Users            *UsersAPI
-	Workbenches      *WorkbenchesAPI
+	AccessControl        *AccessControlAPI
+	AccessPolicies       *AccessPoliciesAPI
+	AgentConfig          *AgentConfigAPI
+	AgentExclusions      *AgentExclusionsAPI
+	AgentGroups          *AgentGroupsAPI
+	Agents               *AgentsAPI
+	Assets               *AssetsAPI
+	AuditLog             *AuditLogAPI
+	Credentials          *CredentialsAPI
+	Editor               *EditorAPI
+	Exclusions           *ExclusionsAPI
+	Exports              *ExportsAPI
+	Files                *FilesAPI
+	Filters              *FiltersAPI
+	Folders              *FoldersAPI
+	Groups               *GroupsAPI
+	Networks             *NetworksAPI
+	Permissions          *PermissionsAPI
+	Plugins              *PluginsAPI
+	Policies             *PoliciesAPI
+	RemediationScans     *RemediationScansAPI
+	ScannerGroups        *ScannerGroupsAPI
+	ScannerRegistrations *ScannerRegistrationsAPI
+	Scanners             *ScannersAPI
+	Scans                *ScansAPI
+	Server               *ServerAPI
+	Session              *SessionAPI
+	Tags                 *TagsAPI
+	Users                *UsersAPI
+	Workbenches          *WorkbenchesAPI
 
 	// Cached timezone list
 	timezones []string
+
+	// secretResolvers maps a SecretRef URI scheme (e.g. "vault", "aws",
+	// "env", "file") to the resolver that dereferences it.
+	secretResolvers map[string]SecretResolver
+
+	// Optional read-through caches in front of TagsAPI and AssetsAPI
+	// lookups, nil unless enabled via WithTagCache/WithAssetCache.
+	tagCategoryCache     *cache.LRU[string, TagCategory]
+	tagCategoryNameCache *cache.LRU[string, TagCategory]
+	tagValueCache        *cache.LRU[string, TagValue]
+	tagValueLookupCache  *cache.LRU[string, TagValue]
+	assetCache           *cache.LRU[string, Asset]
+	assetInfoCache       *cache.LRU[string, AssetInfo]
 }
 
 // Option is a function that configures the TIO Client.
 type Option func(*options)
 
 type options struct {
-	baseOpts []base.ClientOption
+	baseOpts        []base.ClientOption
+	secretResolvers map[string]SecretResolver
+
+	tagCacheEnabled bool
+	tagCacheSize    int
+	tagCacheTTL     time.Duration
+
+	assetCacheEnabled bool
+	assetCacheSize    int
+	assetCacheTTL     time.Duration
 }
 
 // New creates a new Tenable.io client.
@@ -72,11 +100,41 @@ func New(opts ...Option) (*Client, error) {
 	}
 
 	c := &Client{
-		Client: baseClient,
+		Client:          baseClient,
+		secretResolvers: o.secretResolvers,
 	}
 
-	// Initialize all API endpoints
+	if o.tagCacheEnabled {
+		metrics := baseClient.Metrics()
+		c.tagCategoryCache = cache.NewLRU[string, TagCategory](o.tagCacheSize, o.tagCacheTTL,
+			cache.WithCacheMetrics[string, TagCategory]("tio.tag_category", metrics))
+		c.tagCategoryNameCache = cache.NewLRU[string, TagCategory](o.tagCacheSize, o.tagCacheTTL,
+			cache.WithCacheMetrics[string, TagCategory]("tio.tag_category_by_name", metrics))
+		c.tagValueCache = cache.NewLRU[string, TagValue](o.tagCacheSize, o.tagCacheTTL,
+			cache.WithCacheMetrics[string, TagValue]("tio.tag_value", metrics))
+		c.tagValueLookupCache = cache.NewLRU[string, TagValue](o.tagCacheSize, o.tagCacheTTL,
+			cache.WithCacheMetrics[string, TagValue]("tio.tag_value_by_category_and_value", metrics))
+	}
+	if o.assetCacheEnabled {
+		metrics := baseClient.Metrics()
+		c.assetCache = cache.NewLRU[string, Asset](o.assetCacheSize, o.assetCacheTTL,
+			cache.WithCacheMetrics[string, Asset]("tio.asset", metrics))
+		c.assetInfoCache = cache.NewLRU[string, AssetInfo](o.assetCacheSize, o.assetCacheTTL,
+			cache.WithCacheMetrics[string, AssetInfo]("tio.asset_info", metrics))
+	}
+
+	c.wireEndpoints()
+
+	return c, nil
+}
+
+// wireEndpoints (re-)initializes every exported API endpoint field to
+// point back at c. New calls it once when constructing a Client from
+// scratch; UsersAPI.ImpersonateClient calls it again to wire up the
+// endpoint fields of a sub-client built around a cloned base.Client.
+func (c *Client) wireEndpoints() {
 	c.AccessControl = &AccessControlAPI{client: c}
+	c.AccessPolicies = &AccessPoliciesAPI{client: c}
 	c.AgentConfig = &AgentConfigAPI{client: c}
 	c.AgentExclusions = &AgentExclusionsAPI{client: c}
 	c.AgentGroups = &AgentGroupsAPI{client: c}
@@ -97,6 +155,7 @@ func New(opts ...Option) (*Client, error) {
 	c.Policies = &PoliciesAPI{client: c}
 	c.RemediationScans = &RemediationScansAPI{client: c}
 	c.ScannerGroups = &ScannerGroupsAPI{client: c}
+	c.ScannerRegistrations = &ScannerRegistrationsAPI{client: c}
 	c.Scanners = &ScannersAPI{client: c}
 	c.Scans = &ScansAPI{client: c}
 	c.Server = &ServerAPI{client: c}
@@ -104,8 +163,6 @@ func New(opts ...Option) (*Client, error) {
 	c.Tags = &TagsAPI{client: c}
 	c.Users = &UsersAPI{client: c}
 	c.Workbenches = &WorkbenchesAPI{client: c}
-
-	return c, nil
 }
 
 // WithAPIKeys sets the API access and secret keys.
@@ -163,3 +220,130 @@ func WithBuild(build string) Option {
 		o.baseOpts = append(o.baseOpts, base.WithBuild(build))
 	}
 }
+
+// WithRetry replaces the client's default retry behavior with an explicit
+// base.RetryPolicy: max attempts, backoff shape, and which errors are
+// eligible for retry. On 429/503 the client always honors the server's
+// Retry-After header first; the policy's backoff fields only govern the
+// fallback full-jitter backoff used for other retryable errors.
+func WithRetry(policy base.RetryPolicy) Option {
+	return func(o *options) {
+		o.baseOpts = append(o.baseOpts, base.WithRetryPolicy(policy))
+	}
+}
+
+// WithHTTPTransport overrides the http.RoundTripper used to send requests,
+// e.g. to plug in pkg/testtransport for hermetic tests.
+func WithHTTPTransport(transport http.RoundTripper) Option {
+	return func(o *options) {
+		o.baseOpts = append(o.baseOpts, base.WithHTTPTransport(transport))
+	}
+}
+
+// WithSecretResolver registers a SecretResolver for the given SecretRef
+// URI scheme (e.g. "vault", "aws", "env", "file"). CredentialsAPI.Create
+// and Update use it to dereference SecretRef settings values just-in-time.
+func WithSecretResolver(scheme string, resolver SecretResolver) Option {
+	return func(o *options) {
+		if o.secretResolvers == nil {
+			o.secretResolvers = make(map[string]SecretResolver)
+		}
+		o.secretResolvers[scheme] = resolver
+	}
+}
+
+// WithClientCertificate configures TLS client certificate (mTLS)
+// authentication from an in-memory PEM-encoded certificate and private key,
+// so SessionAPI, ScannersAPI, and ScannerGroupsAPI can operate against
+// on-prem Nessus Manager instances that require mutual TLS.
+func WithClientCertificate(certPEM, keyPEM []byte) Option {
+	return func(o *options) {
+		o.baseOpts = append(o.baseOpts, base.WithClientCertificate(certPEM, keyPEM))
+	}
+}
+
+// WithClientCertificateFile is like WithClientCertificate but loads the
+// certificate and private key from files on disk.
+func WithClientCertificateFile(certPath, keyPath string) Option {
+	return func(o *options) {
+		o.baseOpts = append(o.baseOpts, base.WithClientCertificateFile(certPath, keyPath, ""))
+	}
+}
+
+// WithRootCAs sets the trust store used to verify the server's TLS
+// certificate, for deployments behind a private CA.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(o *options) {
+		o.baseOpts = append(o.baseOpts, base.WithRootCAs(pool))
+	}
+}
+
+// WithInsecureSkipVerify disables TLS server certificate verification.
+// Intended only for test fixtures and local development; never enable it
+// against production endpoints.
+func WithInsecureSkipVerify(skip bool) Option {
+	return func(o *options) {
+		o.baseOpts = append(o.baseOpts, base.WithInsecureSkipVerify(skip))
+	}
+}
+
+// WithTagCache enables a read-through LRU+TTL cache, of the given size
+// (<= 0 for unbounded) and per-entry TTL (<= 0 for no expiration), in
+// front of TagsAPI.GetCategory, LookupCategoryByName, GetValue, and
+// LookupValueByCategoryAndValue. Category and value Update/Delete calls
+// invalidate the affected UUID-keyed entry; hit/miss/eviction counts are
+// reported through WithMetrics if one is configured.
+func WithTagCache(size int, ttl time.Duration) Option {
+	return func(o *options) {
+		o.tagCacheEnabled = true
+		o.tagCacheSize = size
+		o.tagCacheTTL = ttl
+	}
+}
+
+// WithAssetCache enables a read-through LRU+TTL cache, of the given size
+// (<= 0 for unbounded) and per-entry TTL (<= 0 for no expiration), in
+// front of AssetsAPI.Get and Info. Delete, AssignTags, UnassignTags, and
+// MoveToNetwork invalidate the affected asset's entry; BulkDelete targets
+// assets by filter rather than UUID, so its matches fall out of the
+// cache only once their TTL expires.
+func WithAssetCache(size int, ttl time.Duration) Option {
+	return func(o *options) {
+		o.assetCacheEnabled = true
+		o.assetCacheSize = size
+		o.assetCacheTTL = ttl
+	}
+}
+
+// WithOIDCToken installs an AuthProvider that attaches a bearer token
+// minted by tokenSource to every request, re-minting it as it nears
+// expiry or after a 401 reports it's expired — for callers federating
+// auth through an external OIDC identity provider rather than Tenable
+// API keys.
+func WithOIDCToken(tokenSource base.OIDCTokenSource) Option {
+	return func(o *options) {
+		o.baseOpts = append(o.baseOpts, base.WithAuthProvider(base.NewOIDCTokenProvider(tokenSource)))
+	}
+}
+
+// WithSAMLAssertion installs an AuthProvider that exchanges a SAML
+// assertion for a Tenable session cookie via exchange, re-exchanging
+// it once the session it returned expires or after a 401 reports it's
+// expired — for an IdP-initiated SSO flow where the caller already
+// holds a SAML assertion.
+func WithSAMLAssertion(assertion string, exchange func(ctx context.Context, assertion string) (session string, expiresAt time.Time, err error)) Option {
+	return func(o *options) {
+		o.baseOpts = append(o.baseOpts, base.WithAuthProvider(base.NewSAMLAssertionProvider(assertion, exchange)))
+	}
+}
+
+// WithSessionCookie installs an AuthProvider that attaches a
+// pre-established Tenable session cookie, such as one obtained through
+// SessionAPI's own login call, to every request. Unlike
+// base.SessionCookieProvider (used internally by SessionAPI), this
+// never logs in on its own.
+func WithSessionCookie(cookie string) Option {
+	return func(o *options) {
+		o.baseOpts = append(o.baseOpts, base.WithAuthProvider(base.NewStaticSessionCookieProvider(cookie)))
+	}
+}
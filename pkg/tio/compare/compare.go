@@ -0,0 +1,87 @@
+// Package compare provides base.Comparator implementations for the
+// result types base.Iterator most commonly sorts with WithSort/
+// WithGlobalSort — tio.WorkbenchAsset, tio.WorkbenchVuln, and tio.Policy
+// — so callers don't have to hand-write a comparator for the fields
+// Tenable data is most often ordered by.
+package compare
+
+import (
+	"strconv"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/base"
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio"
+)
+
+// WorkbenchAssetByLastSeen orders tio.WorkbenchAsset values by LastSeen
+// descending (most recently seen first). LastSeen is the Unix-epoch
+// seconds Tenable's workbench API reports as a string; an asset whose
+// LastSeen fails to parse sorts after every asset that parses.
+func WorkbenchAssetByLastSeen(a, b tio.WorkbenchAsset) int {
+	ta, aok := parseEpoch(a.LastSeen)
+	tb, bok := parseEpoch(b.LastSeen)
+	switch {
+	case aok && !bok:
+		return -1
+	case !aok && bok:
+		return 1
+	case !aok && !bok:
+		return 0
+	case ta == tb:
+		return 0
+	case ta > tb:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// WorkbenchVulnBySeverity orders tio.WorkbenchVuln values by Severity
+// descending, breaking ties by Count descending, matching how Tenable's
+// own UI ranks workbench vulnerability findings.
+func WorkbenchVulnBySeverity(a, b tio.WorkbenchVuln) int {
+	if a.Severity != b.Severity {
+		if a.Severity > b.Severity {
+			return -1
+		}
+		return 1
+	}
+	switch {
+	case a.Count == b.Count:
+		return 0
+	case a.Count > b.Count:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// PolicyByLastModificationDate orders tio.Policy values by
+// LastModificationDate descending (most recently modified first).
+func PolicyByLastModificationDate(a, b tio.Policy) int {
+	switch {
+	case a.LastModificationDate == b.LastModificationDate:
+		return 0
+	case a.LastModificationDate > b.LastModificationDate:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// parseEpoch parses s as Unix-epoch seconds, reporting false if s isn't
+// one.
+func parseEpoch(s string) (int64, bool) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// Compile-time assertions that these satisfy base.Comparator for their
+// respective element types.
+var (
+	_ base.Comparator[tio.WorkbenchAsset] = WorkbenchAssetByLastSeen
+	_ base.Comparator[tio.WorkbenchVuln]  = WorkbenchVulnBySeverity
+	_ base.Comparator[tio.Policy]         = PolicyByLastModificationDate
+)
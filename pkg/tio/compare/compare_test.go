@@ -0,0 +1,57 @@
+package compare
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio"
+)
+
+func TestWorkbenchAssetByLastSeen(t *testing.T) {
+	assets := []tio.WorkbenchAsset{
+		{ID: "old", LastSeen: "100"},
+		{ID: "new", LastSeen: "300"},
+		{ID: "mid", LastSeen: "200"},
+		{ID: "bad", LastSeen: "not-a-number"},
+	}
+	sort.SliceStable(assets, func(i, j int) bool { return WorkbenchAssetByLastSeen(assets[i], assets[j]) < 0 })
+
+	want := []string{"new", "mid", "old", "bad"}
+	for i, id := range want {
+		if assets[i].ID != id {
+			t.Errorf("assets[%d].ID = %q, want %q (order %v)", i, assets[i].ID, id, want)
+		}
+	}
+}
+
+func TestWorkbenchVulnBySeverity(t *testing.T) {
+	vulns := []tio.WorkbenchVuln{
+		{PluginID: 1, Severity: 2, Count: 5},
+		{PluginID: 2, Severity: 4, Count: 1},
+		{PluginID: 3, Severity: 2, Count: 10},
+	}
+	sort.SliceStable(vulns, func(i, j int) bool { return WorkbenchVulnBySeverity(vulns[i], vulns[j]) < 0 })
+
+	wantOrder := []int{2, 3, 1}
+	for i, id := range wantOrder {
+		if vulns[i].PluginID != id {
+			t.Errorf("vulns[%d].PluginID = %d, want %d (order %v)", i, vulns[i].PluginID, id, wantOrder)
+		}
+	}
+}
+
+func TestPolicyByLastModificationDate(t *testing.T) {
+	policies := []tio.Policy{
+		{ID: 1, LastModificationDate: 100},
+		{ID: 2, LastModificationDate: 300},
+		{ID: 3, LastModificationDate: 200},
+	}
+	sort.SliceStable(policies, func(i, j int) bool { return PolicyByLastModificationDate(policies[i], policies[j]) < 0 })
+
+	wantOrder := []int{2, 3, 1}
+	for i, id := range wantOrder {
+		if policies[i].ID != id {
+			t.Errorf("policies[%d].ID = %d, want %d (order %v)", i, policies[i].ID, id, wantOrder)
+		}
+	}
+}
@@ -120,20 +120,42 @@ func (c *CredentialsAPI) Get(ctx context.Context, credentialUUID string) (*Crede
 	return &result, nil
 }
 
-// Create creates a new credential.
+// Create creates a new credential. Settings values that are SecretRef are
+// resolved just-in-time via the client's registered SecretResolvers before
+// the request is sent; the resolved plaintext is zeroed from the request
+// copy once the call returns.
 func (c *CredentialsAPI) Create(ctx context.Context, req *CredentialCreateRequest) (*Credential, error) {
+	resolved, err := c.resolveSettings(ctx, req.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	sendReq := *req
+	sendReq.Settings = resolved
+	defer zeroPlaintextSettings(resolved)
+
 	var result Credential
-	_, err := c.client.Post(ctx, "credentials", req, &result)
+	_, err = c.client.Post(ctx, "credentials", &sendReq, &result)
 	if err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
-// Update updates a credential.
+// Update updates a credential, resolving SecretRef settings values the
+// same way Create does.
 func (c *CredentialsAPI) Update(ctx context.Context, credentialUUID string, req *CredentialCreateRequest) (*Credential, error) {
+	resolved, err := c.resolveSettings(ctx, req.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	sendReq := *req
+	sendReq.Settings = resolved
+	defer zeroPlaintextSettings(resolved)
+
 	var result Credential
-	_, err := c.client.Put(ctx, fmt.Sprintf("credentials/%s", credentialUUID), req, &result)
+	_, err = c.client.Put(ctx, fmt.Sprintf("credentials/%s", credentialUUID), &sendReq, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,234 @@
+package tio
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// templateVarPrefix/templateVarSuffix delimit a template variable
+// reference inside a string-valued editor setting, e.g.
+// "${scan_name}". Only string values are scanned for references; a
+// setting that should come from vars wholesale (not just a string
+// substring) should still be expressed this way — RenderTemplate does
+// not attempt to guess a non-string type from vars.
+const (
+	templateVarPrefix = "${"
+	templateVarSuffix = "}"
+)
+
+// Overlay mutates the credentials/plugins maps RenderTemplate is about
+// to fold into a ScanCreateRequest, after template variable substitution
+// but before the request is returned. Overlays are applied in order, so
+// a later overlay can see and adjust an earlier one's changes.
+type Overlay interface {
+	Apply(credentials, plugins map[string]interface{}) error
+}
+
+// FileOverlay reads a local .audit/.nessus compliance policy file from
+// Path and splices its contents into the rendered payload's plugins map
+// under the Tenable "compliance" key, keyed by its base filename. This
+// module doesn't carry a parser for the .audit/.nessus compliance DSL,
+// so the file's content is attached as an opaque string; Tenable accepts
+// a compliance block this way when no finer-grained policy is needed,
+// and a caller that does need individual compliance checks addressable
+// should parse the file itself and use a more targeted Overlay.
+type FileOverlay struct {
+	Path string
+}
+
+// Apply implements Overlay.
+func (o FileOverlay) Apply(credentials, plugins map[string]interface{}) error {
+	data, err := os.ReadFile(o.Path)
+	if err != nil {
+		return fmt.Errorf("editor: read overlay file %s: %w", o.Path, err)
+	}
+
+	compliance, _ := plugins["compliance"].(map[string]interface{})
+	if compliance == nil {
+		compliance = make(map[string]interface{})
+		plugins["compliance"] = compliance
+	}
+	name := o.Path
+	if idx := strings.LastIndexByte(name, '/'); idx >= 0 {
+		name = name[idx+1:]
+	}
+	compliance[name] = string(data)
+	return nil
+}
+
+// scanSettingsFields are the ScanSettings keys RenderTemplate knows how
+// to populate from an editor template's settings map, addressed by the
+// json tag a template emits them under.
+var scanSettingsFields = map[string]func(*ScanSettings, interface{}){
+	"name":         func(s *ScanSettings, v interface{}) { s.Name, _ = v.(string) },
+	"description":  func(s *ScanSettings, v interface{}) { s.Description, _ = v.(string) },
+	"scanner_id":   func(s *ScanSettings, v interface{}) { s.ScannerID, _ = v.(string) },
+	"policy_id":    func(s *ScanSettings, v interface{}) { s.PolicyID, _ = toInt(v) },
+	"text_targets": func(s *ScanSettings, v interface{}) { s.TextTargets, _ = v.(string) },
+	"file_targets": func(s *ScanSettings, v interface{}) { s.FileTargets, _ = v.(string) },
+	"emails":       func(s *ScanSettings, v interface{}) { s.Emails, _ = v.(string) },
+	"enabled":      func(s *ScanSettings, v interface{}) { s.Enabled, _ = v.(bool) },
+	"launch":       func(s *ScanSettings, v interface{}) { s.Launch, _ = v.(string) },
+	"rrules":       func(s *ScanSettings, v interface{}) { s.RRules, _ = v.(string) },
+	"starttime":    func(s *ScanSettings, v interface{}) { s.Starttime, _ = v.(string) },
+	"timezone":     func(s *ScanSettings, v interface{}) { s.Timezone, _ = v.(string) },
+}
+
+func toInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case float64:
+		return int(n), true
+	default:
+		return 0, false
+	}
+}
+
+// RenderTemplate fetches the template templateUUID (a scan or policy
+// template depending on objectType, as passed to TemplateDetails),
+// substitutes vars into its settings tree's string values wherever a
+// "${name}" reference appears, applies overlays to the resulting
+// credentials/plugins maps, and returns a ready-to-POST ScanCreateRequest
+// — sparing callers from hand-assembling one from TemplateDetails' raw
+// maps.
+//
+// A "${name}" reference with no matching entry in vars is an error:
+// unlike a plain map merge, RenderTemplate treats every reference in the
+// template as a required input, the same way a missing required field
+// would fail at Tenable's API instead of silently rendering "${name}"
+// into the live payload.
+func (e *EditorAPI) RenderTemplate(ctx context.Context, objectType, templateUUID string, vars map[string]interface{}, overlays []Overlay) (*ScanCreateRequest, error) {
+	details, err := e.TemplateDetails(ctx, objectType, templateUUID)
+	if err != nil {
+		return nil, fmt.Errorf("editor: render template %s: %w", templateUUID, err)
+	}
+
+	renderedSettings, err := substituteVars(details.Settings, vars)
+	if err != nil {
+		return nil, fmt.Errorf("editor: render template %s: %w", templateUUID, err)
+	}
+	renderedCredentials, err := substituteVars(details.Credentials, vars)
+	if err != nil {
+		return nil, fmt.Errorf("editor: render template %s: %w", templateUUID, err)
+	}
+	renderedPlugins, err := substituteVars(details.Plugins, vars)
+	if err != nil {
+		return nil, fmt.Errorf("editor: render template %s: %w", templateUUID, err)
+	}
+
+	credentials, _ := renderedCredentials.(map[string]interface{})
+	if credentials == nil {
+		credentials = make(map[string]interface{})
+	}
+	plugins, _ := renderedPlugins.(map[string]interface{})
+	if plugins == nil {
+		plugins = make(map[string]interface{})
+	}
+
+	for _, overlay := range overlays {
+		if err := overlay.Apply(credentials, plugins); err != nil {
+			return nil, fmt.Errorf("editor: render template %s: %w", templateUUID, err)
+		}
+	}
+
+	var settings ScanSettings
+	settings.Enabled = true
+	if settingsMap, ok := renderedSettings.(map[string]interface{}); ok {
+		for key, apply := range scanSettingsFields {
+			if v, ok := settingsMap[key]; ok {
+				apply(&settings, v)
+			}
+		}
+	}
+	if settings.Name == "" {
+		return nil, fmt.Errorf("editor: render template %s: vars did not resolve a scan name", templateUUID)
+	}
+
+	return &ScanCreateRequest{
+		UUID:        templateUUID,
+		Settings:    settings,
+		Credentials: credentials,
+		Plugins:     plugins,
+	}, nil
+}
+
+// substituteVars walks v (as decoded from JSON: maps, slices, and
+// scalars) and, for every string that contains a "${name}" reference,
+// substitutes vars["name"] — formatted with fmt.Sprint if it isn't
+// itself a string — in its place. A reference with no corresponding
+// vars entry is reported as an error rather than left in the output.
+func substituteVars(v interface{}, vars map[string]interface{}) (interface{}, error) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, child := range val {
+			rendered, err := substituteVars(child, vars)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = rendered
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, child := range val {
+			rendered, err := substituteVars(child, vars)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = rendered
+		}
+		return out, nil
+	case string:
+		return substituteVarsInString(val, vars)
+	default:
+		return v, nil
+	}
+}
+
+// maxTemplateVarDepth bounds how many rounds of substitution
+// substituteVarsInString will chase through a value that itself contains
+// another "${...}" reference, so a vars map with a substitution cycle
+// (e.g. vars["a"] = "${b}", vars["b"] = "${a}") fails with an error
+// instead of recursing until the stack overflows.
+const maxTemplateVarDepth = 25
+
+// substituteVarsInString replaces every "${name}" reference in s. A
+// string that is *exactly* one reference is replaced with vars[name]
+// itself (preserving its type, e.g. a bool or number); a reference
+// embedded in a larger string is replaced with its fmt.Sprint form.
+func substituteVarsInString(s string, vars map[string]interface{}) (interface{}, error) {
+	return substituteVarsInStringDepth(s, vars, 0)
+}
+
+func substituteVarsInStringDepth(s string, vars map[string]interface{}, depth int) (interface{}, error) {
+	if depth >= maxTemplateVarDepth {
+		return nil, fmt.Errorf("template variable substitution exceeded %d rounds, likely a cycle", maxTemplateVarDepth)
+	}
+
+	start := strings.Index(s, templateVarPrefix)
+	if start < 0 {
+		return s, nil
+	}
+	end := strings.Index(s[start:], templateVarSuffix)
+	if end < 0 {
+		return s, nil
+	}
+	end += start
+	name := s[start+len(templateVarPrefix) : end]
+
+	value, ok := vars[name]
+	if !ok {
+		return nil, fmt.Errorf("unresolved template variable %q", name)
+	}
+
+	if start == 0 && end+len(templateVarSuffix) == len(s) {
+		return value, nil
+	}
+
+	replaced := s[:start] + fmt.Sprint(value) + s[end+len(templateVarSuffix):]
+	return substituteVarsInStringDepth(replaced, vars, depth+1)
+}
@@ -0,0 +1,119 @@
+package tio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTemplateTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(EditorDetails{
+			UUID: "templ-1",
+			Settings: map[string]interface{}{
+				"name":         "${scan_name}",
+				"text_targets": "${targets}",
+				"enabled":      true,
+			},
+			Credentials: map[string]interface{}{},
+			Plugins:     map[string]interface{}{},
+		})
+	}))
+}
+
+func TestRenderTemplateSubstitutesVars(t *testing.T) {
+	srv := newTemplateTestServer(t)
+	defer srv.Close()
+
+	client, err := New(WithURL(srv.URL), WithAPIKeys("access", "secret"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	req, err := client.Editor.RenderTemplate(context.Background(), "scan", "templ-1", map[string]interface{}{
+		"scan_name": "nightly scan",
+		"targets":   "10.0.0.0/24",
+	}, nil)
+	if err != nil {
+		t.Fatalf("RenderTemplate() error = %v", err)
+	}
+	if req.Settings.Name != "nightly scan" {
+		t.Errorf("Settings.Name = %q, want %q", req.Settings.Name, "nightly scan")
+	}
+	if req.Settings.TextTargets != "10.0.0.0/24" {
+		t.Errorf("Settings.TextTargets = %q, want %q", req.Settings.TextTargets, "10.0.0.0/24")
+	}
+	if req.UUID != "templ-1" {
+		t.Errorf("UUID = %q, want %q", req.UUID, "templ-1")
+	}
+}
+
+func TestRenderTemplateRejectsUnresolvedVar(t *testing.T) {
+	srv := newTemplateTestServer(t)
+	defer srv.Close()
+
+	client, err := New(WithURL(srv.URL), WithAPIKeys("access", "secret"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	_, err = client.Editor.RenderTemplate(context.Background(), "scan", "templ-1", map[string]interface{}{
+		"scan_name": "nightly scan",
+	}, nil)
+	if err == nil {
+		t.Fatal("RenderTemplate() error = nil, want unresolved variable error")
+	}
+}
+
+func TestSubstituteVarsInStringRejectsCycle(t *testing.T) {
+	// Neither "a" nor "b"'s value is an *exact* single reference (each
+	// has "x"/"y" padding), so every round re-scans the replaced string
+	// and finds another reference to chase, alternating between "a" and
+	// "b" forever without the depth guard.
+	vars := map[string]interface{}{
+		"a": "x${b}y",
+		"b": "x${a}y",
+	}
+	_, err := substituteVarsInString("start ${a} end", vars)
+	if err == nil {
+		t.Fatal("substituteVarsInString() error = nil, want cycle error")
+	}
+}
+
+func TestRenderTemplateAppliesFileOverlay(t *testing.T) {
+	srv := newTemplateTestServer(t)
+	defer srv.Close()
+
+	client, err := New(WithURL(srv.URL), WithAPIKeys("access", "secret"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	auditPath := filepath.Join(dir, "cis.audit")
+	if err := os.WriteFile(auditPath, []byte("<check_type>...</check_type>"), 0o600); err != nil {
+		t.Fatalf("write audit file: %v", err)
+	}
+
+	req, err := client.Editor.RenderTemplate(context.Background(), "scan", "templ-1", map[string]interface{}{
+		"scan_name": "cis scan",
+		"targets":   "10.0.0.0/24",
+	}, []Overlay{FileOverlay{Path: auditPath}})
+	if err != nil {
+		t.Fatalf("RenderTemplate() error = %v", err)
+	}
+
+	compliance, ok := req.Plugins["compliance"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Plugins[compliance] = %+v, want a map", req.Plugins["compliance"])
+	}
+	if compliance["cis.audit"] != "<check_type>...</check_type>" {
+		t.Errorf("compliance[cis.audit] = %v, want file contents", compliance["cis.audit"])
+	}
+}
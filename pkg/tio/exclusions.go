@@ -2,7 +2,10 @@ package tio
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"time"
 )
 
@@ -13,14 +16,14 @@ type ExclusionsAPI struct {
 
 // Exclusion represents a scan exclusion.
 type Exclusion struct {
-	ID                   int       `json:"id"`
-	Name                 string    `json:"name"`
-	Description          string    `json:"description,omitempty"`
-	CreationDate         int64     `json:"creation_date"`
-	LastModificationDate int64     `json:"last_modification_date"`
+	ID                   int                `json:"id"`
+	Name                 string             `json:"name"`
+	Description          string             `json:"description,omitempty"`
+	CreationDate         int64              `json:"creation_date"`
+	LastModificationDate int64              `json:"last_modification_date"`
 	Schedule             *ExclusionSchedule `json:"schedule,omitempty"`
-	Members              string    `json:"members"`
-	NetworkID            string    `json:"network_id,omitempty"`
+	Members              string             `json:"members"`
+	NetworkID            string             `json:"network_id,omitempty"`
 }
 
 // ExclusionSchedule represents the schedule for an exclusion.
@@ -91,13 +94,88 @@ func (e *ExclusionsAPI) Delete(ctx context.Context, exclusionID int) error {
 	return err
 }
 
-// Import imports exclusions from a file.
+// Import imports exclusions from a file that has already been staged
+// server-side, e.g. by ImportFile. filename must be the server-assigned
+// name returned from that upload, not a local path.
 func (e *ExclusionsAPI) Import(ctx context.Context, filename string) error {
 	payload := map[string]string{"file": filename}
 	_, err := e.client.Post(ctx, "exclusions/import", payload, nil)
 	return err
 }
 
+// ImportFile uploads r to Tenable.io's file/upload endpoint and imports
+// the resulting server-side file as exclusions in one step. filename is
+// the name reported to the API for the upload; it does not need to
+// match a local path.
+func (e *ExclusionsAPI) ImportFile(ctx context.Context, r io.Reader, filename string) error {
+	var uploaded struct {
+		Fileuploaded string `json:"fileuploaded"`
+	}
+	if err := e.client.PostMultipart(ctx, "file/upload", nil, "Filename", filename, r, &uploaded); err != nil {
+		return fmt.Errorf("upload exclusion import file: %w", err)
+	}
+	return e.Import(ctx, uploaded.Fileuploaded)
+}
+
+// ImportFromCSV creates one exclusion per row of a local CSV file read
+// from r, via Create, instead of staging and importing a file
+// server-side. The header row must be
+// "name,description,members,network_id"; description and network_id
+// may be left blank.
+func (e *ExclusionsAPI) ImportFromCSV(ctx context.Context, r io.Reader) ([]Exclusion, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parse exclusion CSV: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	var created []Exclusion
+	for i, row := range rows[1:] { // skip header
+		if len(row) < 3 {
+			return created, fmt.Errorf("exclusion CSV row %d: expected at least 3 columns, got %d", i+2, len(row))
+		}
+		req := &ExclusionCreateRequest{
+			Name:    row[0],
+			Members: row[2],
+		}
+		if len(row) > 1 {
+			req.Description = row[1]
+		}
+		if len(row) > 3 {
+			req.NetworkID = row[3]
+		}
+		exc, err := e.Create(ctx, req)
+		if err != nil {
+			return created, fmt.Errorf("create exclusion %q: %w", req.Name, err)
+		}
+		created = append(created, *exc)
+	}
+	return created, nil
+}
+
+// ImportFromJSON creates one exclusion per entry of a local JSON array of
+// ExclusionCreateRequest read from r, via Create, instead of staging and
+// importing a file server-side.
+func (e *ExclusionsAPI) ImportFromJSON(ctx context.Context, r io.Reader) ([]Exclusion, error) {
+	var reqs []ExclusionCreateRequest
+	if err := json.NewDecoder(r).Decode(&reqs); err != nil {
+		return nil, fmt.Errorf("parse exclusion JSON: %w", err)
+	}
+
+	var created []Exclusion
+	for _, req := range reqs {
+		req := req
+		exc, err := e.Create(ctx, &req)
+		if err != nil {
+			return created, fmt.Errorf("create exclusion %q: %w", req.Name, err)
+		}
+		created = append(created, *exc)
+	}
+	return created, nil
+}
+
 // AgentExclusionsAPI handles agent exclusion operations.
 type AgentExclusionsAPI struct {
 	client *Client
@@ -105,11 +183,11 @@ type AgentExclusionsAPI struct {
 
 // AgentExclusion represents an agent exclusion.
 type AgentExclusion struct {
-	ID                   int       `json:"id"`
-	Name                 string    `json:"name"`
-	Description          string    `json:"description,omitempty"`
-	CreationDate         int64     `json:"creation_date"`
-	LastModificationDate int64     `json:"last_modification_date"`
+	ID                   int                     `json:"id"`
+	Name                 string                  `json:"name"`
+	Description          string                  `json:"description,omitempty"`
+	CreationDate         int64                   `json:"creation_date"`
+	LastModificationDate int64                   `json:"last_modification_date"`
 	Schedule             *AgentExclusionSchedule `json:"schedule,omitempty"`
 }
 
@@ -208,4 +286,3 @@ func CreateSchedule(enabled bool, startTime, endTime time.Time, timezone, rrules
 		RRules:    rrules,
 	}
 }
-
@@ -0,0 +1,81 @@
+package tio
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// EphemeralResult is the outcome of a CreateEphemeral exclusion's
+// supervisor goroutine, delivered once the exclusion has been deleted
+// (or delivered with Err set, if the delete itself failed).
+type EphemeralResult struct {
+	Deleted bool
+	Err     error
+}
+
+// CreateEphemeral creates a one-shot exclusion bounded to
+// [now, now+ttl] (RRULE COUNT=1) and spawns a supervisor goroutine that
+// deletes it when ttl elapses or when the returned CancelFunc is
+// invoked, whichever comes first. The supervisor also watches ctx: if
+// it's cancelled before either of those, the supervisor still attempts
+// the delete, using a bounded 30s fallback context so an already-gone
+// caller context doesn't leave the exclusion orphaned on the server. The
+// outcome is delivered on the returned channel exactly once, after which
+// it's closed.
+func (e *ExclusionsAPI) CreateEphemeral(ctx context.Context, req *ExclusionCreateRequest, ttl time.Duration) (*Exclusion, context.CancelFunc, <-chan EphemeralResult, error) {
+	now := time.Now().UTC()
+	scoped := *req
+	scoped.Schedule = &ExclusionSchedule{
+		Enabled:   true,
+		StartTime: now.Format(scheduleTimeLayout),
+		EndTime:   now.Add(ttl).Format(scheduleTimeLayout),
+		Timezone:  "UTC",
+		RRules:    "FREQ=DAILY;COUNT=1",
+	}
+
+	exc, err := e.Create(ctx, &scoped)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	cancelCh := make(chan struct{})
+	var cancelOnce sync.Once
+	cancel := context.CancelFunc(func() {
+		cancelOnce.Do(func() { close(cancelCh) })
+	})
+
+	result := make(chan EphemeralResult, 1)
+	go e.superviseEphemeral(ctx, exc.ID, ttl, cancelCh, result)
+
+	return exc, cancel, result, nil
+}
+
+// scheduleTimeLayout matches the format CreateSchedule writes
+// StartTime/EndTime in.
+const scheduleTimeLayout = "2006-01-02T15:04:05"
+
+// superviseEphemeral waits for ttl to elapse, cancelCh to close, or ctx
+// to be cancelled — whichever comes first — then deletes exclusionID
+// using a bounded fallback context so the delete still has a chance to
+// succeed even if ctx is already done.
+func (e *ExclusionsAPI) superviseEphemeral(ctx context.Context, exclusionID int, ttl time.Duration, cancelCh <-chan struct{}, result chan<- EphemeralResult) {
+	defer close(result)
+
+	expired := make(chan struct{})
+	timer := time.AfterFunc(ttl, func() { close(expired) })
+
+	select {
+	case <-expired:
+	case <-cancelCh:
+		timer.Stop()
+	case <-ctx.Done():
+		timer.Stop()
+	}
+
+	fallbackCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	err := e.Delete(fallbackCtx, exclusionID)
+	result <- EphemeralResult{Deleted: err == nil, Err: err}
+}
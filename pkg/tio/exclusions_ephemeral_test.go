@@ -0,0 +1,122 @@
+package tio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newEphemeralTestServer(t *testing.T, deleteCount *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/exclusions":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(Exclusion{ID: 42, Name: "ephemeral"})
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/exclusions/"):
+			atomic.AddInt32(deleteCount, 1)
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestCreateEphemeralDeletesOnExpiry(t *testing.T) {
+	var deletes int32
+	srv := newEphemeralTestServer(t, &deletes)
+	defer srv.Close()
+
+	client, err := New(WithURL(srv.URL), WithAPIKeys("access", "secret"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	_, cancel, result, err := client.Exclusions.CreateEphemeral(context.Background(), &ExclusionCreateRequest{Name: "ephemeral"}, 20*time.Millisecond)
+	if err != nil {
+		t.Fatalf("CreateEphemeral() error = %v", err)
+	}
+	defer cancel()
+
+	select {
+	case res := <-result:
+		if !res.Deleted {
+			t.Errorf("EphemeralResult.Deleted = false, err = %v", res.Err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for ephemeral result")
+	}
+
+	if got := atomic.LoadInt32(&deletes); got != 1 {
+		t.Errorf("delete issued %d times, want exactly 1", got)
+	}
+}
+
+func TestCreateEphemeralDeletesOnceUnderRacingCancelAndExpiry(t *testing.T) {
+	var deletes int32
+	srv := newEphemeralTestServer(t, &deletes)
+	defer srv.Close()
+
+	client, err := New(WithURL(srv.URL), WithAPIKeys("access", "secret"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// A very short TTL races the expiry timer against an immediate
+	// cancel; exactly one of them should win, and the supervisor must
+	// still only issue one delete.
+	_, cancel, result, err := client.Exclusions.CreateEphemeral(context.Background(), &ExclusionCreateRequest{Name: "ephemeral"}, time.Millisecond)
+	if err != nil {
+		t.Fatalf("CreateEphemeral() error = %v", err)
+	}
+	cancel()
+
+	select {
+	case res := <-result:
+		if !res.Deleted {
+			t.Errorf("EphemeralResult.Deleted = false, err = %v", res.Err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for ephemeral result")
+	}
+
+	if got := atomic.LoadInt32(&deletes); got != 1 {
+		t.Errorf("delete issued %d times, want exactly 1", got)
+	}
+}
+
+func TestCreateEphemeralCancelBeforeExpiry(t *testing.T) {
+	var deletes int32
+	srv := newEphemeralTestServer(t, &deletes)
+	defer srv.Close()
+
+	client, err := New(WithURL(srv.URL), WithAPIKeys("access", "secret"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	_, cancel, result, err := client.Exclusions.CreateEphemeral(context.Background(), &ExclusionCreateRequest{Name: "ephemeral"}, time.Hour)
+	if err != nil {
+		t.Fatalf("CreateEphemeral() error = %v", err)
+	}
+	cancel()
+
+	select {
+	case res := <-result:
+		if !res.Deleted {
+			t.Errorf("EphemeralResult.Deleted = false, err = %v", res.Err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for ephemeral result")
+	}
+
+	if got := atomic.LoadInt32(&deletes); got != 1 {
+		t.Errorf("delete issued %d times, want exactly 1", got)
+	}
+}
@@ -0,0 +1,77 @@
+package tio
+
+import (
+	"context"
+	"fmt"
+)
+
+// NetworkScopedExclusions operates on exclusions belonging to a single
+// network, injecting NetworkID into every Create/Update and rejecting
+// List/Get/Update/Delete operations against an exclusion that belongs to
+// a different network.
+type NetworkScopedExclusions struct {
+	client    *Client
+	networkID string
+}
+
+// ForNetwork scopes e to networkID, so subsequent operations only ever
+// see or mutate that network's exclusions.
+func (e *ExclusionsAPI) ForNetwork(networkID string) *NetworkScopedExclusions {
+	return &NetworkScopedExclusions{client: e.client, networkID: networkID}
+}
+
+// List retrieves the exclusions belonging to this network.
+func (n *NetworkScopedExclusions) List(ctx context.Context) ([]Exclusion, error) {
+	all, err := n.client.Exclusions.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var scoped []Exclusion
+	for _, exc := range all {
+		if exc.NetworkID == n.networkID {
+			scoped = append(scoped, exc)
+		}
+	}
+	return scoped, nil
+}
+
+// Get retrieves exclusionID, returning an error if it belongs to a
+// different network.
+func (n *NetworkScopedExclusions) Get(ctx context.Context, exclusionID int) (*Exclusion, error) {
+	exc, err := n.client.Exclusions.Get(ctx, exclusionID)
+	if err != nil {
+		return nil, err
+	}
+	if exc.NetworkID != n.networkID {
+		return nil, fmt.Errorf("tio: exclusion %d belongs to network %q, not %q", exclusionID, exc.NetworkID, n.networkID)
+	}
+	return exc, nil
+}
+
+// Create creates req within this network, overwriting any NetworkID req
+// already carried.
+func (n *NetworkScopedExclusions) Create(ctx context.Context, req *ExclusionCreateRequest) (*Exclusion, error) {
+	scoped := *req
+	scoped.NetworkID = n.networkID
+	return n.client.Exclusions.Create(ctx, &scoped)
+}
+
+// Update updates exclusionID within this network, rejecting the call if
+// the exclusion currently belongs to a different network.
+func (n *NetworkScopedExclusions) Update(ctx context.Context, exclusionID int, req *ExclusionCreateRequest) (*Exclusion, error) {
+	if _, err := n.Get(ctx, exclusionID); err != nil {
+		return nil, err
+	}
+	scoped := *req
+	scoped.NetworkID = n.networkID
+	return n.client.Exclusions.Update(ctx, exclusionID, &scoped)
+}
+
+// Delete removes exclusionID, rejecting the call if it currently belongs
+// to a different network.
+func (n *NetworkScopedExclusions) Delete(ctx context.Context, exclusionID int) error {
+	if _, err := n.Get(ctx, exclusionID); err != nil {
+		return err
+	}
+	return n.client.Exclusions.Delete(ctx, exclusionID)
+}
@@ -0,0 +1,111 @@
+package tio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MultiTenantExclusionManager fans exclusion operations out across many
+// tenants, each with its own isolated *Client (and thus its own API key
+// pair), so a caller never has to hold more than one tenant's
+// credentials in scope at a time.
+type MultiTenantExclusionManager struct {
+	clients map[string]*Client
+}
+
+// NewMultiTenantExclusionManager creates a manager over clients, keyed by
+// an opaque tenant ID chosen by the caller.
+func NewMultiTenantExclusionManager(clients map[string]*Client) *MultiTenantExclusionManager {
+	return &MultiTenantExclusionManager{clients: clients}
+}
+
+// MultiTenantError collects the per-tenant errors from a fanned-out
+// operation. It's nil-safe: a *MultiTenantError with no entries is never
+// returned by this package's methods, which return a plain nil error
+// instead.
+type MultiTenantError struct {
+	Errors map[string]error
+}
+
+// Error implements error.
+func (e *MultiTenantError) Error() string {
+	parts := make([]string, 0, len(e.Errors))
+	for tenantID, err := range e.Errors {
+		parts = append(parts, fmt.Sprintf("%s: %v", tenantID, err))
+	}
+	return fmt.Sprintf("tio: %d tenant(s) failed: %s", len(e.Errors), strings.Join(parts, "; "))
+}
+
+// ListAll lists exclusions for every tenant concurrently, returning the
+// per-tenant results keyed by tenant ID. If any tenant fails, ListAll
+// still returns the results that did succeed alongside a
+// *MultiTenantError covering the rest.
+func (m *MultiTenantExclusionManager) ListAll(ctx context.Context) (map[string][]Exclusion, error) {
+	results := make(map[string][]Exclusion, len(m.clients))
+	errs := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for tenantID, client := range m.clients {
+		tenantID, client := tenantID, client
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			excs, err := client.Exclusions.List(ctx)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[tenantID] = err
+				return
+			}
+			results[tenantID] = excs
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return results, &MultiTenantError{Errors: errs}
+	}
+	return results, nil
+}
+
+// ApplyToAll creates req in every tenant for which filter returns true
+// (or every tenant, if filter is nil), concurrently, returning the
+// created exclusion keyed by tenant ID. If any tenant fails, ApplyToAll
+// still returns the exclusions that were created alongside a
+// *MultiTenantError covering the rest; it does not roll back tenants
+// that already succeeded.
+func (m *MultiTenantExclusionManager) ApplyToAll(ctx context.Context, req *ExclusionCreateRequest, filter func(tenantID string) bool) (map[string]*Exclusion, error) {
+	results := make(map[string]*Exclusion, len(m.clients))
+	errs := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for tenantID, client := range m.clients {
+		if filter != nil && !filter(tenantID) {
+			continue
+		}
+		tenantID, client := tenantID, client
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reqCopy := *req
+			exc, err := client.Exclusions.Create(ctx, &reqCopy)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[tenantID] = err
+				return
+			}
+			results[tenantID] = exc
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return results, &MultiTenantError{Errors: errs}
+	}
+	return results, nil
+}
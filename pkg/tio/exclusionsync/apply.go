@@ -0,0 +1,263 @@
+package exclusionsync
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio"
+)
+
+// ApplyOptions configures Apply.
+type ApplyOptions struct {
+	// Concurrency is how many changes Apply executes at once. Defaults
+	// to 1 (sequential) if <= 0.
+	Concurrency int
+	// DryRun, if true, computes what Apply would do without making any
+	// API calls.
+	DryRun bool
+	// RollbackOnError, if true, reverses every change already applied
+	// in this Apply call when a later change fails: creates are
+	// deleted, updates are reverted to their pre-apply request, and
+	// deletes are re-created from their pre-apply snapshot.
+	RollbackOnError bool
+}
+
+// ApplyResult is the outcome of an Apply call, partitioned by which
+// operation each completed change performed — so rollback knows how to
+// reverse it without having to infer intent from nil fields.
+type ApplyResult struct {
+	Created []Change // Before is the exclusion the create returned; After is the request
+	Updated []Change // Before is the pre-apply snapshot, After is the applied request
+	Deleted []Change // Before is the pre-apply snapshot
+
+	AgentCreated []AgentChange
+	AgentUpdated []AgentChange
+	AgentDeleted []AgentChange
+
+	RolledBack     []string // names of changes successfully rolled back
+	RollbackFailed []string // names of changes rollback could not reverse
+}
+
+// Apply executes plan against client according to opts. If opts.DryRun
+// is set, Apply reports what it would do without issuing any requests.
+// Changes run with up to opts.Concurrency workers; if any change fails
+// and opts.RollbackOnError is set, every change already applied is
+// reversed using its pre-apply snapshot before Apply returns the
+// triggering error.
+func Apply(ctx context.Context, client *tio.Client, plan *Plan, opts ApplyOptions) (*ApplyResult, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	result := &ApplyResult{}
+	var mu sync.Mutex
+	var failOnce sync.Once
+	var firstErr error
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	fail := func(err error) {
+		failOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	var jobs []func() error
+
+	for _, c := range plan.Creates {
+		c := c
+		jobs = append(jobs, func() error {
+			if opts.DryRun {
+				mu.Lock()
+				result.Created = append(result.Created, c)
+				mu.Unlock()
+				return nil
+			}
+			created, err := client.Exclusions.Create(ctx, c.After)
+			if err != nil {
+				return fmt.Errorf("create exclusion %q: %w", c.Name, err)
+			}
+			c.Before = created
+			mu.Lock()
+			result.Created = append(result.Created, c)
+			mu.Unlock()
+			return nil
+		})
+	}
+	for _, c := range plan.Updates {
+		c := c
+		jobs = append(jobs, func() error {
+			if !opts.DryRun {
+				if _, err := client.Exclusions.Update(ctx, c.Before.ID, c.After); err != nil {
+					return fmt.Errorf("update exclusion %q: %w", c.Name, err)
+				}
+			}
+			mu.Lock()
+			result.Updated = append(result.Updated, c)
+			mu.Unlock()
+			return nil
+		})
+	}
+	for _, c := range plan.Deletes {
+		c := c
+		jobs = append(jobs, func() error {
+			if !opts.DryRun {
+				if err := client.Exclusions.Delete(ctx, c.Before.ID); err != nil {
+					return fmt.Errorf("delete exclusion %q: %w", c.Name, err)
+				}
+			}
+			mu.Lock()
+			result.Deleted = append(result.Deleted, c)
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	for _, ac := range plan.AgentCreates {
+		ac := ac
+		jobs = append(jobs, func() error {
+			if opts.DryRun {
+				mu.Lock()
+				result.AgentCreated = append(result.AgentCreated, ac)
+				mu.Unlock()
+				return nil
+			}
+			created, err := client.AgentExclusions.Create(ctx, ac.ScannerID, ac.After)
+			if err != nil {
+				return fmt.Errorf("create agent exclusion %q: %w", ac.Name, err)
+			}
+			ac.Before = created
+			mu.Lock()
+			result.AgentCreated = append(result.AgentCreated, ac)
+			mu.Unlock()
+			return nil
+		})
+	}
+	for _, ac := range plan.AgentUpdates {
+		ac := ac
+		jobs = append(jobs, func() error {
+			if !opts.DryRun {
+				if _, err := client.AgentExclusions.Update(ctx, ac.ScannerID, ac.Before.ID, ac.After); err != nil {
+					return fmt.Errorf("update agent exclusion %q: %w", ac.Name, err)
+				}
+			}
+			mu.Lock()
+			result.AgentUpdated = append(result.AgentUpdated, ac)
+			mu.Unlock()
+			return nil
+		})
+	}
+	for _, ac := range plan.AgentDeletes {
+		ac := ac
+		jobs = append(jobs, func() error {
+			if !opts.DryRun {
+				if err := client.AgentExclusions.Delete(ctx, ac.ScannerID, ac.Before.ID); err != nil {
+					return fmt.Errorf("delete agent exclusion %q: %w", ac.Name, err)
+				}
+			}
+			mu.Lock()
+			result.AgentDeleted = append(result.AgentDeleted, ac)
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	runJobs(ctx, jobs, concurrency, fail)
+
+	if firstErr != nil && opts.RollbackOnError && !opts.DryRun {
+		rollback(context.Background(), client, result)
+	}
+	return result, firstErr
+}
+
+// runJobs runs jobs across concurrency workers, stopping early (via
+// fail) on the first error but letting in-flight jobs finish.
+func runJobs(ctx context.Context, jobs []func() error, concurrency int, fail func(error)) {
+	queue := make(chan func() error)
+	go func() {
+		defer close(queue)
+		for _, j := range jobs {
+			select {
+			case queue <- j:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for j := range queue {
+				if err := j(); err != nil {
+					fail(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// rollback reverses every change already applied in result, using a
+// bounded fallback context so a caller whose ctx was already cancelled
+// still gets a best-effort rollback.
+func rollback(parent context.Context, client *tio.Client, result *ApplyResult) {
+	ctx, cancel := context.WithTimeout(parent, 30*time.Second)
+	defer cancel()
+
+	record := func(name string, err error) {
+		if err == nil {
+			result.RolledBack = append(result.RolledBack, name)
+		} else {
+			result.RollbackFailed = append(result.RollbackFailed, name)
+		}
+	}
+
+	for _, c := range result.Created {
+		record(c.Name, client.Exclusions.Delete(ctx, c.Before.ID))
+	}
+	for _, c := range result.Updated {
+		_, err := client.Exclusions.Update(ctx, c.Before.ID, exclusionToCreateRequest(*c.Before))
+		record(c.Name, err)
+	}
+	for _, c := range result.Deleted {
+		_, err := client.Exclusions.Create(ctx, exclusionToCreateRequest(*c.Before))
+		record(c.Name, err)
+	}
+
+	for _, ac := range result.AgentCreated {
+		record(ac.Name, client.AgentExclusions.Delete(ctx, ac.ScannerID, ac.Before.ID))
+	}
+	for _, ac := range result.AgentUpdated {
+		_, err := client.AgentExclusions.Update(ctx, ac.ScannerID, ac.Before.ID, agentExclusionToCreateRequest(*ac.Before))
+		record(ac.Name, err)
+	}
+	for _, ac := range result.AgentDeleted {
+		_, err := client.AgentExclusions.Create(ctx, ac.ScannerID, agentExclusionToCreateRequest(*ac.Before))
+		record(ac.Name, err)
+	}
+}
+
+func exclusionToCreateRequest(e tio.Exclusion) *tio.ExclusionCreateRequest {
+	return &tio.ExclusionCreateRequest{
+		Name:        e.Name,
+		Description: e.Description,
+		Members:     e.Members,
+		Schedule:    e.Schedule,
+		NetworkID:   e.NetworkID,
+	}
+}
+
+func agentExclusionToCreateRequest(a tio.AgentExclusion) *tio.AgentExclusionCreateRequest {
+	return &tio.AgentExclusionCreateRequest{
+		Name:        a.Name,
+		Description: a.Description,
+		Schedule:    a.Schedule,
+	}
+}
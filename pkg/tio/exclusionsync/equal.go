@@ -0,0 +1,148 @@
+package exclusionsync
+
+import (
+	"sort"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio"
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio/schedule"
+)
+
+// exclusionEqual reports whether req already describes current, ignoring
+// server-assigned fields (ID, timestamps) and cosmetic schedule
+// differences.
+func exclusionEqual(current tio.Exclusion, req tio.ExclusionCreateRequest) bool {
+	if current.Description != req.Description || current.Members != req.Members || current.NetworkID != req.NetworkID {
+		return false
+	}
+	return scheduleEqual(exclusionScheduleFields(current.Schedule), exclusionScheduleFields(req.Schedule))
+}
+
+// agentExclusionEqual reports whether req already describes current,
+// ignoring server-assigned fields and cosmetic schedule differences.
+func agentExclusionEqual(current tio.AgentExclusion, req tio.AgentExclusionCreateRequest) bool {
+	if current.Description != req.Description {
+		return false
+	}
+	return scheduleEqual(agentScheduleFields(current.Schedule), agentScheduleFields(req.Schedule))
+}
+
+// scheduleFields is the set of fields both ExclusionSchedule and
+// AgentExclusionSchedule carry, used so scheduleEqual can compare either
+// kind without duplicating its logic.
+type scheduleFields struct {
+	set       bool
+	enabled   bool
+	startTime string
+	endTime   string
+	timezone  string
+	rrules    string
+}
+
+func exclusionScheduleFields(s *tio.ExclusionSchedule) scheduleFields {
+	if s == nil {
+		return scheduleFields{}
+	}
+	return scheduleFields{true, s.Enabled, s.StartTime, s.EndTime, s.Timezone, s.RRules}
+}
+
+func agentScheduleFields(s *tio.AgentExclusionSchedule) scheduleFields {
+	if s == nil {
+		return scheduleFields{}
+	}
+	return scheduleFields{true, s.Enabled, s.StartTime, s.EndTime, s.Timezone, s.RRules}
+}
+
+// scheduleEqual reports whether two schedules describe the same
+// recurrence, tolerating a reordered RRULE token list (e.g. BYDAY=MO,WE
+// vs BYDAY=WE,MO) and a timezone spelled differently but resolving to
+// the same IANA location. StartTime/EndTime are compared as wall-clock
+// strings since both sides use the same layout.
+func scheduleEqual(a, b scheduleFields) bool {
+	if a.set != b.set {
+		return false
+	}
+	if !a.set {
+		return true
+	}
+	if a.enabled != b.enabled || a.startTime != b.startTime || a.endTime != b.endTime {
+		return false
+	}
+	if !timezoneEqual(a.timezone, b.timezone) {
+		return false
+	}
+	return rruleEqual(a.rrules, b.rrules)
+}
+
+// timezoneEqual reports whether two timezone names give the same UTC
+// offset at four reference instants (noon UTC on the 15th of January,
+// April, July and October 2024), so e.g. "UTC" and "Etc/UTC" are treated
+// as equivalent even though their Location.String() differs. Comparing
+// sampled offsets rather than Location identity also treats as equal two
+// named zones that share every DST rule, and likewise two zones whose
+// offsets differ only at other times of year.
+func timezoneEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	locA, errA := time.LoadLocation(a)
+	locB, errB := time.LoadLocation(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	for _, month := range []time.Month{time.January, time.April, time.July, time.October} {
+		ref := time.Date(2024, month, 15, 12, 0, 0, 0, time.UTC)
+		_, offsetA := ref.In(locA).Zone()
+		_, offsetB := ref.In(locB).Zone()
+		if offsetA != offsetB {
+			return false
+		}
+	}
+	return true
+}
+
+// rruleEqual reports whether two raw RRULE strings describe the same
+// rule, normalizing BYDAY/BYMONTHDAY token order so cosmetic reordering
+// doesn't produce a spurious update.
+func rruleEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ra, errA := schedule.ParseRRule(a)
+	rb, errB := schedule.ParseRRule(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	if ra.Freq != rb.Freq || ra.Interval != rb.Interval || ra.Count != rb.Count || !ra.Until.Equal(rb.Until) {
+		return false
+	}
+	return sortedEqual(ra.ByDay) == sortedEqual(rb.ByDay) && intsEqual(sortedInts(ra.ByMonthDay), sortedInts(rb.ByMonthDay))
+}
+
+func sortedEqual(s []string) string {
+	cp := append([]string(nil), s...)
+	sort.Strings(cp)
+	out := ""
+	for _, v := range cp {
+		out += v + ","
+	}
+	return out
+}
+
+func sortedInts(s []int) []int {
+	cp := append([]int(nil), s...)
+	sort.Ints(cp)
+	return cp
+}
+
+func intsEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package exclusionsync
+
+import (
+	"testing"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio"
+)
+
+func TestExclusionEqualIgnoresRRULETokenOrder(t *testing.T) {
+	current := tio.Exclusion{
+		Name:    "weekday-patch-window",
+		Members: "10.0.0.0/24",
+		Schedule: &tio.ExclusionSchedule{
+			Enabled:   true,
+			StartTime: "2024-01-01T09:00:00",
+			EndTime:   "2024-01-01T10:00:00",
+			Timezone:  "UTC",
+			RRules:    "FREQ=WEEKLY;BYDAY=MO,WE",
+		},
+	}
+	req := tio.ExclusionCreateRequest{
+		Name:    "weekday-patch-window",
+		Members: "10.0.0.0/24",
+		Schedule: &tio.ExclusionSchedule{
+			Enabled:   true,
+			StartTime: "2024-01-01T09:00:00",
+			EndTime:   "2024-01-01T10:00:00",
+			Timezone:  "UTC",
+			RRules:    "FREQ=WEEKLY;BYDAY=WE,MO",
+		},
+	}
+
+	if !exclusionEqual(current, req) {
+		t.Error("exclusionEqual() = false, want true for reordered BYDAY tokens")
+	}
+}
+
+func TestExclusionEqualDetectsScheduleChange(t *testing.T) {
+	current := tio.Exclusion{
+		Name: "weekday-patch-window",
+		Schedule: &tio.ExclusionSchedule{
+			Enabled:   true,
+			StartTime: "2024-01-01T09:00:00",
+			EndTime:   "2024-01-01T10:00:00",
+			Timezone:  "UTC",
+			RRules:    "FREQ=WEEKLY;BYDAY=MO",
+		},
+	}
+	req := tio.ExclusionCreateRequest{
+		Name: "weekday-patch-window",
+		Schedule: &tio.ExclusionSchedule{
+			Enabled:   true,
+			StartTime: "2024-01-01T09:00:00",
+			EndTime:   "2024-01-01T10:00:00",
+			Timezone:  "UTC",
+			RRules:    "FREQ=WEEKLY;BYDAY=TU",
+		},
+	}
+
+	if exclusionEqual(current, req) {
+		t.Error("exclusionEqual() = true, want false for a changed BYDAY")
+	}
+}
+
+func TestTimezoneEqualResolvesEquivalentLocations(t *testing.T) {
+	if !timezoneEqual("UTC", "Etc/UTC") {
+		t.Error("timezoneEqual(UTC, Etc/UTC) = false, want true")
+	}
+	if timezoneEqual("America/New_York", "America/Los_Angeles") {
+		t.Error("timezoneEqual(New_York, Los_Angeles) = true, want false")
+	}
+}
@@ -0,0 +1,154 @@
+// Package exclusionsync provides a declarative, desired-state workflow
+// for exclusions and agent exclusions, so callers can describe the
+// exclusions they want in YAML/JSON and let BuildPlan/Apply compute and
+// execute the create/update/delete operations instead of hand-coding a
+// List+Diff+Create/Update/Delete loop on top of tio.ExclusionsAPI and
+// tio.AgentExclusionsAPI.
+package exclusionsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio"
+)
+
+// DesiredState is the full set of exclusions and agent exclusions a
+// caller wants to exist. Exclusions are keyed by Name; AgentExclusions
+// are keyed by scanner ID, then by Name within that scanner.
+type DesiredState struct {
+	Exclusions      []tio.ExclusionCreateRequest              `yaml:"exclusions,omitempty" json:"exclusions,omitempty"`
+	AgentExclusions map[int][]tio.AgentExclusionCreateRequest `yaml:"agent_exclusions,omitempty" json:"agent_exclusions,omitempty"`
+}
+
+// LoadYAML parses a DesiredState from YAML.
+func LoadYAML(data []byte) (*DesiredState, error) {
+	var desired DesiredState
+	if err := yaml.Unmarshal(data, &desired); err != nil {
+		return nil, fmt.Errorf("exclusionsync: parse YAML: %w", err)
+	}
+	return &desired, nil
+}
+
+// LoadJSON parses a DesiredState from JSON.
+func LoadJSON(data []byte) (*DesiredState, error) {
+	var desired DesiredState
+	if err := json.Unmarshal(data, &desired); err != nil {
+		return nil, fmt.Errorf("exclusionsync: parse JSON: %w", err)
+	}
+	return &desired, nil
+}
+
+// Change describes a single create, update, or delete Apply will perform
+// against ExclusionsAPI. Before is nil for a create; After is nil for a
+// delete.
+type Change struct {
+	Name   string
+	Before *tio.Exclusion
+	After  *tio.ExclusionCreateRequest
+}
+
+// AgentChange describes a single create, update, or delete Apply will
+// perform against AgentExclusionsAPI for a given scanner. Before is nil
+// for a create; After is nil for a delete.
+type AgentChange struct {
+	ScannerID int
+	Name      string
+	Before    *tio.AgentExclusion
+	After     *tio.AgentExclusionCreateRequest
+}
+
+// Plan is the diff between the current server state and a DesiredState.
+type Plan struct {
+	Creates []Change
+	Updates []Change
+	Deletes []Change
+	NoOps   []Change
+
+	AgentCreates []AgentChange
+	AgentUpdates []AgentChange
+	AgentDeletes []AgentChange
+	AgentNoOps   []AgentChange
+}
+
+// BuildPlan computes a Plan by listing the current exclusions and agent
+// exclusions from client and diffing them against desired, keyed by
+// Name. A schedule is considered unchanged if it's semantically
+// equivalent to the current one (see scheduleEqual) even when the raw
+// RRULE token order or timezone spelling differs cosmetically.
+func BuildPlan(ctx context.Context, client *tio.Client, desired *DesiredState) (*Plan, error) {
+	current, err := client.Exclusions.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("exclusionsync: list exclusions: %w", err)
+	}
+	currentByName := make(map[string]tio.Exclusion, len(current))
+	for _, e := range current {
+		currentByName[e.Name] = e
+	}
+
+	plan := &Plan{}
+
+	seen := make(map[string]bool, len(desired.Exclusions))
+	for i := range desired.Exclusions {
+		req := desired.Exclusions[i]
+		seen[req.Name] = true
+		before, exists := currentByName[req.Name]
+		switch {
+		case !exists:
+			plan.Creates = append(plan.Creates, Change{Name: req.Name, After: &req})
+		case exclusionEqual(before, req):
+			b := before
+			plan.NoOps = append(plan.NoOps, Change{Name: req.Name, Before: &b, After: &req})
+		default:
+			b := before
+			plan.Updates = append(plan.Updates, Change{Name: req.Name, Before: &b, After: &req})
+		}
+	}
+	for _, e := range current {
+		if seen[e.Name] {
+			continue
+		}
+		exc := e
+		plan.Deletes = append(plan.Deletes, Change{Name: e.Name, Before: &exc})
+	}
+
+	for scannerID, reqs := range desired.AgentExclusions {
+		agentCurrent, err := client.AgentExclusions.List(ctx, scannerID)
+		if err != nil {
+			return nil, fmt.Errorf("exclusionsync: list agent exclusions for scanner %d: %w", scannerID, err)
+		}
+		byName := make(map[string]tio.AgentExclusion, len(agentCurrent))
+		for _, a := range agentCurrent {
+			byName[a.Name] = a
+		}
+
+		agentSeen := make(map[string]bool, len(reqs))
+		for i := range reqs {
+			req := reqs[i]
+			agentSeen[req.Name] = true
+			before, exists := byName[req.Name]
+			switch {
+			case !exists:
+				plan.AgentCreates = append(plan.AgentCreates, AgentChange{ScannerID: scannerID, Name: req.Name, After: &req})
+			case agentExclusionEqual(before, req):
+				b := before
+				plan.AgentNoOps = append(plan.AgentNoOps, AgentChange{ScannerID: scannerID, Name: req.Name, Before: &b, After: &req})
+			default:
+				b := before
+				plan.AgentUpdates = append(plan.AgentUpdates, AgentChange{ScannerID: scannerID, Name: req.Name, Before: &b, After: &req})
+			}
+		}
+		for _, a := range agentCurrent {
+			if agentSeen[a.Name] {
+				continue
+			}
+			agent := a
+			plan.AgentDeletes = append(plan.AgentDeletes, AgentChange{ScannerID: scannerID, Name: a.Name, Before: &agent})
+		}
+	}
+
+	return plan, nil
+}
@@ -1,11 +1,18 @@
 package tio
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/tenable/gotenable/pkg/base"
@@ -137,13 +144,52 @@ func (e *ExportsAPI) ComplianceExportStatus(ctx context.Context, exportUUID stri
 	return &result, nil
 }
 
+// ChunkReader is returned by AssetsExportChunk. Besides io.Reader it
+// supports net.Conn-style read/write deadlines, so a caller iterating many
+// chunks can bound how long it's willing to spend on any single one
+// independent of the overall request context.
+type ChunkReader interface {
+	io.Reader
+	SetReadDeadline(t time.Time) error
+	SetWriteDeadline(t time.Time) error
+}
+
+// deadlineChunkReader adapts a fully-buffered chunk body to ChunkReader.
+// The data is already downloaded by the time it reaches here, so
+// SetWriteDeadline has nothing to bound and is a no-op; SetReadDeadline is
+// enforced on every Read against time.Now().
+type deadlineChunkReader struct {
+	r            *bytesReader
+	readDeadline time.Time
+}
+
+// Read implements io.Reader.
+func (r *deadlineChunkReader) Read(p []byte) (int, error) {
+	if !r.readDeadline.IsZero() && time.Now().After(r.readDeadline) {
+		return 0, os.ErrDeadlineExceeded
+	}
+	return r.r.Read(p)
+}
+
+// SetReadDeadline implements ChunkReader.
+func (r *deadlineChunkReader) SetReadDeadline(t time.Time) error {
+	r.readDeadline = t
+	return nil
+}
+
+// SetWriteDeadline implements ChunkReader. It's a no-op: the reader has no
+// write side to bound.
+func (r *deadlineChunkReader) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
 // AssetsExportChunk downloads an assets export chunk.
-func (e *ExportsAPI) AssetsExportChunk(ctx context.Context, exportUUID string, chunkID int) (io.Reader, error) {
+func (e *ExportsAPI) AssetsExportChunk(ctx context.Context, exportUUID string, chunkID int) (ChunkReader, error) {
 	data, err := e.client.Download(ctx, fmt.Sprintf("assets/export/%s/chunks/%d", exportUUID, chunkID))
 	if err != nil {
 		return nil, err
 	}
-	return &bytesReader{data: data}, nil
+	return &deadlineChunkReader{r: &bytesReader{data: data}}, nil
 }
 
 // VulnsExportChunk downloads a vulnerabilities export chunk.
@@ -164,6 +210,59 @@ func (e *ExportsAPI) ComplianceExportChunk(ctx context.Context, exportUUID strin
 	return &bytesReader{data: data}, nil
 }
 
+// AssetsExportChunkStream streams chunkID's items as they're decoded from
+// the response body, instead of buffering the whole chunk (as
+// AssetsExportChunk does) or running a brand-new export (as StreamAssets
+// does). Use it when the chunk ID is already known -- from
+// AssetsExportStatus, or a resumableExport's checkpointed state -- and
+// only that one chunk needs streaming. Both channels close once the
+// chunk is fully decoded, decoding fails, or ctx is done.
+func (e *ExportsAPI) AssetsExportChunkStream(ctx context.Context, exportUUID string, chunkID int) (<-chan ExportedAsset, <-chan error) {
+	out := make(chan ExportedAsset)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errs)
+
+		body, err := e.client.DownloadStream(ctx, fmt.Sprintf("assets/export/%s/chunks/%d", exportUUID, chunkID))
+		if err != nil {
+			sendErr(ctx, errs, err)
+			return
+		}
+		defer body.Close()
+		if err := decodeChunkStream(ctx, body, out); err != nil {
+			sendErr(ctx, errs, err)
+		}
+	}()
+
+	return out, errs
+}
+
+// VulnsExportChunkStream is the vulnerability-export equivalent of
+// AssetsExportChunkStream.
+func (e *ExportsAPI) VulnsExportChunkStream(ctx context.Context, exportUUID string, chunkID int) (<-chan ExportedVuln, <-chan error) {
+	out := make(chan ExportedVuln)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errs)
+
+		body, err := e.client.DownloadStream(ctx, fmt.Sprintf("vulns/export/%s/chunks/%d", exportUUID, chunkID))
+		if err != nil {
+			sendErr(ctx, errs, err)
+			return
+		}
+		defer body.Close()
+		if err := decodeChunkStream(ctx, body, out); err != nil {
+			sendErr(ctx, errs, err)
+		}
+	}()
+
+	return out, errs
+}
+
 // CancelAssetsExport cancels an assets export.
 func (e *ExportsAPI) CancelAssetsExport(ctx context.Context, exportUUID string) error {
 	_, err := e.client.Post(ctx, fmt.Sprintf("assets/export/%s/cancel", exportUUID), nil, nil)
@@ -281,12 +380,71 @@ type VulnScan struct {
 	UUID         string    `json:"uuid"`
 }
 
-// WaitForExport waits for an export to complete and returns all data.
+// ExportedCompliance represents an exported compliance check result.
+type ExportedCompliance struct {
+	Asset       Asset     `json:"asset"`
+	Check       string    `json:"check_name"`
+	Status      string    `json:"status"`
+	AuditFile   string    `json:"audit_file"`
+	Description string    `json:"description,omitempty"`
+	Remediation string    `json:"remediation,omitempty"`
+	SeeAlso     []string  `json:"see_also,omitempty"`
+	FirstSeen   time.Time `json:"first_seen,omitempty"`
+	LastSeen    time.Time `json:"last_seen,omitempty"`
+	ScanUUID    string    `json:"scan_uuid,omitempty"`
+}
+
+// ExportStartedEvent is published on Client.Events the first time
+// WaitForExport observes the export's status.
+type ExportStartedEvent struct {
+	ExportType string
+	ExportUUID string
+}
+
+// EventType implements base.Event.
+func (ExportStartedEvent) EventType() string { return "export.started" }
+
+// ExportChunkReadyEvent is published on Client.Events for every chunk ID
+// WaitForExport observes newly added to ExportStatus.ChunksAvailable.
+type ExportChunkReadyEvent struct {
+	ExportType string
+	ExportUUID string
+	ChunkID    int
+}
+
+// EventType implements base.Event.
+func (ExportChunkReadyEvent) EventType() string { return "export.chunk_ready" }
+
+// ExportCompletedEvent is published on Client.Events when WaitForExport
+// observes the export reach a terminal status.
+type ExportCompletedEvent struct {
+	ExportType string
+	ExportUUID string
+	Status     *ExportStatus
+}
+
+// EventType implements base.Event.
+func (ExportCompletedEvent) EventType() string { return "export.completed" }
+
+// WaitForExport waits for an export to complete and returns all data. It
+// polls on a single *time.Timer reused via Reset between polls instead of
+// a fresh time.After per iteration, so canceling ctx (including via a
+// base.WithDeadline deadline) stops the timer right away instead of
+// leaving it to fire into an abandoned channel. It publishes
+// ExportStartedEvent on the first poll, an ExportChunkReadyEvent for
+// every chunk ID that becomes newly available between polls, and an
+// ExportCompletedEvent once the export reaches a terminal status.
 func (e *ExportsAPI) WaitForExport(ctx context.Context, exportType, exportUUID string, pollInterval time.Duration) (*ExportStatus, error) {
 	if pollInterval == 0 {
 		pollInterval = 5 * time.Second
 	}
 
+	timer := time.NewTimer(pollInterval)
+	defer timer.Stop()
+
+	seenChunks := make(map[int]bool)
+	started := false
+
 	for {
 		var status *ExportStatus
 		var err error
@@ -306,8 +464,20 @@ func (e *ExportsAPI) WaitForExport(ctx context.Context, exportType, exportUUID s
 			return nil, err
 		}
 
+		if !started {
+			started = true
+			e.client.Events.Publish(ExportStartedEvent{ExportType: exportType, ExportUUID: exportUUID})
+		}
+		for _, chunkID := range status.ChunksAvailable {
+			if !seenChunks[chunkID] {
+				seenChunks[chunkID] = true
+				e.client.Events.Publish(ExportChunkReadyEvent{ExportType: exportType, ExportUUID: exportUUID, ChunkID: chunkID})
+			}
+		}
+
 		switch status.Status {
 		case "FINISHED":
+			e.client.Events.Publish(ExportCompletedEvent{ExportType: exportType, ExportUUID: exportUUID, Status: status})
 			return status, nil
 		case "CANCELLED":
 			return nil, &base.ExportError{ExportType: exportType, UUID: exportUUID, Message: "export cancelled"}
@@ -318,61 +488,218 @@ func (e *ExportsAPI) WaitForExport(ctx context.Context, exportType, exportUUID s
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
-		case <-time.After(pollInterval):
+		case <-timer.C:
+			timer.Reset(pollInterval)
 		}
 	}
 }
 
-// AssetsIterator returns an iterator over exported assets.
-func (e *ExportsAPI) AssetsIterator(ctx context.Context, req *ExportAssetsRequest) *base.Iterator[ExportedAsset] {
-	var exportUUID string
-	var status *ExportStatus
-	var currentChunk int
-	var chunkData []ExportedAsset
+// IteratorOption configures AssetsIterator, VulnsIterator, and
+// ComplianceIterator.
+type IteratorOption func(*iteratorConfig)
 
-	fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *base.PaginationInfo, error) {
-		// Initialize export if needed
-		if exportUUID == "" {
-			uuid, err := e.AssetsExport(ctx, req)
-			if err != nil {
-				return nil, nil, err
+type iteratorConfig struct {
+	checkpointStore base.CheckpointStore
+	checkpointKey   string
+	resumeUUID      string
+}
+
+// WithCheckpointStore makes the iterator persist {exportUUID,
+// chunksProcessed, lastStatus} to store under key after every successfully
+// decoded chunk. On startup the iterator loads that state and, as long as
+// the export UUID it names is still valid on the server, resumes from it
+// instead of issuing a fresh export, skipping chunks already recorded as
+// processed. If the stored export is gone, cancelled, or errored, the
+// iterator falls back to starting a new export as usual.
+func WithCheckpointStore(store base.CheckpointStore, key string) IteratorOption {
+	return func(c *iteratorConfig) {
+		c.checkpointStore = store
+		c.checkpointKey = key
+	}
+}
+
+// withResumeUUID binds an iterator to a pre-existing server-side export
+// without issuing the POST that starts a new one. It backs
+// ResumeAssetsFrom, ResumeVulnsFrom, and ResumeComplianceFrom.
+func withResumeUUID(exportUUID string) IteratorOption {
+	return func(c *iteratorConfig) {
+		c.resumeUUID = exportUUID
+	}
+}
+
+// exportCheckpoint is the JSON shape persisted by a checkpointed iterator
+// after each successfully decoded chunk.
+type exportCheckpoint struct {
+	ExportUUID      string        `json:"export_uuid"`
+	ChunksProcessed []int         `json:"chunks_processed"`
+	LastStatus      *ExportStatus `json:"last_status,omitempty"`
+}
+
+// resumableExport tracks the create-or-resume state shared by
+// AssetsIterator, VulnsIterator, and ComplianceIterator: which export UUID
+// is in use, its last known status, and which chunks have already been
+// delivered, optionally checkpointed to cfg.checkpointStore.
+type resumableExport struct {
+	exportType string
+	cfg        iteratorConfig
+
+	exportUUID string
+	status     *ExportStatus
+	processed  []int
+	nextIdx    int
+}
+
+// ensureStarted makes sure r.exportUUID is set, either by resuming a
+// checkpointed or explicitly-bound export, or by calling startExport to
+// create a new one.
+func (r *resumableExport) ensureStarted(ctx context.Context, startExport func(context.Context) (string, error), exportStatus func(context.Context, string) (*ExportStatus, error)) error {
+	if r.exportUUID != "" {
+		return nil
+	}
+
+	if r.cfg.resumeUUID != "" {
+		r.exportUUID = r.cfg.resumeUUID
+		return nil
+	}
+
+	if r.cfg.checkpointStore != nil {
+		state, ok, err := r.loadCheckpoint(ctx)
+		if err != nil {
+			return err
+		}
+		if ok {
+			if status, err := exportStatus(ctx, state.ExportUUID); err == nil && status.Status != "CANCELLED" && status.Status != "ERROR" {
+				r.exportUUID = state.ExportUUID
+				r.processed = state.ChunksProcessed
+				if status.Status == "FINISHED" {
+					// An export still running is left for ensureStatus to
+					// wait on, so chunks it adds later are not missed.
+					r.status = status
+				}
+				return nil
 			}
-			exportUUID = uuid
+			// The checkpointed export is gone, cancelled, or failed;
+			// fall through and start a fresh one.
 		}
+	}
 
-		// Wait for export and get status
-		if status == nil {
-			s, err := e.WaitForExport(ctx, "assets", exportUUID, 5*time.Second)
-			if err != nil {
-				return nil, nil, err
-			}
-			status = s
+	uuid, err := startExport(ctx)
+	if err != nil {
+		return err
+	}
+	r.exportUUID = uuid
+	return nil
+}
+
+// ensureStatus makes sure r.status is populated, waiting for the export to
+// finish if it hasn't been fetched yet.
+func (r *resumableExport) ensureStatus(ctx context.Context, waitForExport func(context.Context, string) (*ExportStatus, error)) error {
+	if r.status != nil {
+		return nil
+	}
+	status, err := waitForExport(ctx, r.exportUUID)
+	if err != nil {
+		return err
+	}
+	r.status = status
+	return nil
+}
+
+// nextChunkID returns the next chunk not already recorded as processed, in
+// status.ChunksAvailable order.
+func (r *resumableExport) nextChunkID() (int, bool) {
+	for r.nextIdx < len(r.status.ChunksAvailable) {
+		id := r.status.ChunksAvailable[r.nextIdx]
+		r.nextIdx++
+		if r.isProcessed(id) {
+			continue
 		}
+		return id, true
+	}
+	return 0, false
+}
 
-		// Check if we have more chunks
-		if currentChunk >= len(status.ChunksAvailable) {
+func (r *resumableExport) isProcessed(id int) bool {
+	for _, p := range r.processed {
+		if p == id {
+			return true
+		}
+	}
+	return false
+}
+
+// markProcessed records chunkID as delivered and, if a checkpoint store is
+// configured, persists the updated state.
+func (r *resumableExport) markProcessed(ctx context.Context, chunkID int) error {
+	r.processed = append(r.processed, chunkID)
+	if r.cfg.checkpointStore == nil {
+		return nil
+	}
+	state := exportCheckpoint{
+		ExportUUID:      r.exportUUID,
+		ChunksProcessed: r.processed,
+		LastStatus:      r.status,
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return r.cfg.checkpointStore.Save(ctx, r.cfg.checkpointKey, data)
+}
+
+func (r *resumableExport) loadCheckpoint(ctx context.Context) (exportCheckpoint, bool, error) {
+	data, err := r.cfg.checkpointStore.Load(ctx, r.cfg.checkpointKey)
+	if err != nil {
+		if errors.Is(err, base.ErrCheckpointNotFound) {
+			return exportCheckpoint{}, false, nil
+		}
+		return exportCheckpoint{}, false, err
+	}
+	var state exportCheckpoint
+	if err := json.Unmarshal(data, &state); err != nil {
+		return exportCheckpoint{}, false, err
+	}
+	return state, true, nil
+}
+
+// AssetsIterator returns an iterator over exported assets.
+func (e *ExportsAPI) AssetsIterator(ctx context.Context, req *ExportAssetsRequest, opts ...IteratorOption) *base.Iterator[ExportedAsset] {
+	r := &resumableExport{exportType: "assets"}
+	for _, opt := range opts {
+		opt(&r.cfg)
+	}
+
+	fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *base.PaginationInfo, error) {
+		if err := r.ensureStarted(ctx, func(ctx context.Context) (string, error) {
+			return e.AssetsExport(ctx, req)
+		}, e.AssetsExportStatus); err != nil {
+			return nil, nil, err
+		}
+		if err := r.ensureStatus(ctx, func(ctx context.Context, exportUUID string) (*ExportStatus, error) {
+			return e.WaitForExport(ctx, "assets", exportUUID, 5*time.Second)
+		}); err != nil {
+			return nil, nil, err
+		}
+
+		chunkID, ok := r.nextChunkID()
+		if !ok {
 			return json.RawMessage("[]"), &base.PaginationInfo{Total: 0}, nil
 		}
 
-		// Download chunk
-		chunkID := status.ChunksAvailable[currentChunk]
-		reader, err := e.AssetsExportChunk(ctx, exportUUID, chunkID)
+		reader, err := e.AssetsExportChunk(ctx, r.exportUUID, chunkID)
 		if err != nil {
 			return nil, nil, err
 		}
-
 		data, err := io.ReadAll(reader)
 		if err != nil {
 			return nil, nil, err
 		}
-
-		if err := json.Unmarshal(data, &chunkData); err != nil {
+		if err := r.markProcessed(ctx, chunkID); err != nil {
 			return nil, nil, err
 		}
 
-		currentChunk++
 		return data, &base.PaginationInfo{
-			Total: len(status.ChunksAvailable),
+			Total: len(r.status.ChunksAvailable),
 		}, nil
 	}
 
@@ -382,54 +709,52 @@ func (e *ExportsAPI) AssetsIterator(ctx context.Context, req *ExportAssetsReques
 		return items, err
 	}
 
-	return base.NewIterator(ctx, fetcher, transformer)
+	var iterOpts []base.IteratorOption[ExportedAsset]
+	if d := e.client.DefaultPageTimeout(); d > 0 {
+		iterOpts = append(iterOpts, base.WithPageTimeout[ExportedAsset](d))
+	}
+
+	return base.NewIterator(ctx, fetcher, transformer, iterOpts...)
 }
 
 // VulnsIterator returns an iterator over exported vulnerabilities.
-func (e *ExportsAPI) VulnsIterator(ctx context.Context, req *ExportVulnsRequest) *base.Iterator[ExportedVuln] {
-	var exportUUID string
-	var status *ExportStatus
-	var currentChunk int
+func (e *ExportsAPI) VulnsIterator(ctx context.Context, req *ExportVulnsRequest, opts ...IteratorOption) *base.Iterator[ExportedVuln] {
+	r := &resumableExport{exportType: "vulns"}
+	for _, opt := range opts {
+		opt(&r.cfg)
+	}
 
 	fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *base.PaginationInfo, error) {
-		// Initialize export if needed
-		if exportUUID == "" {
-			uuid, err := e.VulnsExport(ctx, req)
-			if err != nil {
-				return nil, nil, err
-			}
-			exportUUID = uuid
+		if err := r.ensureStarted(ctx, func(ctx context.Context) (string, error) {
+			return e.VulnsExport(ctx, req)
+		}, e.VulnsExportStatus); err != nil {
+			return nil, nil, err
 		}
-
-		// Wait for export and get status
-		if status == nil {
-			s, err := e.WaitForExport(ctx, "vulns", exportUUID, 5*time.Second)
-			if err != nil {
-				return nil, nil, err
-			}
-			status = s
+		if err := r.ensureStatus(ctx, func(ctx context.Context, exportUUID string) (*ExportStatus, error) {
+			return e.WaitForExport(ctx, "vulns", exportUUID, 5*time.Second)
+		}); err != nil {
+			return nil, nil, err
 		}
 
-		// Check if we have more chunks
-		if currentChunk >= len(status.ChunksAvailable) {
+		chunkID, ok := r.nextChunkID()
+		if !ok {
 			return json.RawMessage("[]"), &base.PaginationInfo{Total: 0}, nil
 		}
 
-		// Download chunk
-		chunkID := status.ChunksAvailable[currentChunk]
-		reader, err := e.VulnsExportChunk(ctx, exportUUID, chunkID)
+		reader, err := e.VulnsExportChunk(ctx, r.exportUUID, chunkID)
 		if err != nil {
 			return nil, nil, err
 		}
-
 		data, err := io.ReadAll(reader)
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := r.markProcessed(ctx, chunkID); err != nil {
+			return nil, nil, err
+		}
 
-		currentChunk++
 		return data, &base.PaginationInfo{
-			Total: len(status.ChunksAvailable),
+			Total: len(r.status.ChunksAvailable),
 		}, nil
 	}
 
@@ -439,7 +764,390 @@ func (e *ExportsAPI) VulnsIterator(ctx context.Context, req *ExportVulnsRequest)
 		return items, err
 	}
 
-	return base.NewIterator(ctx, fetcher, transformer)
+	var iterOpts []base.IteratorOption[ExportedVuln]
+	if d := e.client.DefaultPageTimeout(); d > 0 {
+		iterOpts = append(iterOpts, base.WithPageTimeout[ExportedVuln](d))
+	}
+
+	return base.NewIterator(ctx, fetcher, transformer, iterOpts...)
+}
+
+// ComplianceIterator returns an iterator over exported compliance checks.
+func (e *ExportsAPI) ComplianceIterator(ctx context.Context, req *ExportComplianceRequest, opts ...IteratorOption) *base.Iterator[ExportedCompliance] {
+	r := &resumableExport{exportType: "compliance"}
+	for _, opt := range opts {
+		opt(&r.cfg)
+	}
+
+	fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *base.PaginationInfo, error) {
+		if err := r.ensureStarted(ctx, func(ctx context.Context) (string, error) {
+			return e.ComplianceExport(ctx, req)
+		}, e.ComplianceExportStatus); err != nil {
+			return nil, nil, err
+		}
+		if err := r.ensureStatus(ctx, func(ctx context.Context, exportUUID string) (*ExportStatus, error) {
+			return e.WaitForExport(ctx, "compliance", exportUUID, 5*time.Second)
+		}); err != nil {
+			return nil, nil, err
+		}
+
+		chunkID, ok := r.nextChunkID()
+		if !ok {
+			return json.RawMessage("[]"), &base.PaginationInfo{Total: 0}, nil
+		}
+
+		reader, err := e.ComplianceExportChunk(ctx, r.exportUUID, chunkID)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := r.markProcessed(ctx, chunkID); err != nil {
+			return nil, nil, err
+		}
+
+		return data, &base.PaginationInfo{
+			Total: len(r.status.ChunksAvailable),
+		}, nil
+	}
+
+	transformer := func(data json.RawMessage) ([]ExportedCompliance, error) {
+		var items []ExportedCompliance
+		err := json.Unmarshal(data, &items)
+		return items, err
+	}
+
+	var iterOpts []base.IteratorOption[ExportedCompliance]
+	if d := e.client.DefaultPageTimeout(); d > 0 {
+		iterOpts = append(iterOpts, base.WithPageTimeout[ExportedCompliance](d))
+	}
+
+	return base.NewIterator(ctx, fetcher, transformer, iterOpts...)
+}
+
+// ResumeAssetsFrom returns an iterator bound to the already-running assets
+// export identified by exportUUID, without issuing a new export request.
+func (e *ExportsAPI) ResumeAssetsFrom(ctx context.Context, exportUUID string, opts ...IteratorOption) *base.Iterator[ExportedAsset] {
+	return e.AssetsIterator(ctx, nil, append(opts, withResumeUUID(exportUUID))...)
+}
+
+// ResumeVulnsFrom returns an iterator bound to the already-running
+// vulnerabilities export identified by exportUUID, without issuing a new
+// export request.
+func (e *ExportsAPI) ResumeVulnsFrom(ctx context.Context, exportUUID string, opts ...IteratorOption) *base.Iterator[ExportedVuln] {
+	return e.VulnsIterator(ctx, nil, append(opts, withResumeUUID(exportUUID))...)
+}
+
+// ResumeComplianceFrom returns an iterator bound to the already-running
+// compliance export identified by exportUUID, without issuing a new export
+// request.
+func (e *ExportsAPI) ResumeComplianceFrom(ctx context.Context, exportUUID string, opts ...IteratorOption) *base.Iterator[ExportedCompliance] {
+	return e.ComplianceIterator(ctx, nil, append(opts, withResumeUUID(exportUUID))...)
+}
+
+// defaultStreamConcurrency is how many chunks StreamAssets and StreamVulns
+// download and decode in parallel unless overridden with WithConcurrency.
+const defaultStreamConcurrency = 4
+
+// StreamOption configures ExportsAPI.StreamAssets and ExportsAPI.StreamVulns.
+type StreamOption func(*streamOptions)
+
+type streamOptions struct {
+	concurrency int
+	chunkRetry  base.RetryPolicy
+}
+
+func defaultStreamOptions() streamOptions {
+	return streamOptions{
+		concurrency: defaultStreamConcurrency,
+		chunkRetry: base.RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Second,
+			MaxBackoff:     30 * time.Second,
+			Multiplier:     2,
+			Jitter:         true,
+		},
+	}
+}
+
+// WithConcurrency sets how many export chunks are downloaded and decoded
+// in parallel. The default is 4.
+func WithConcurrency(n int) StreamOption {
+	return func(o *streamOptions) {
+		if n > 0 {
+			o.concurrency = n
+		}
+	}
+}
+
+// WithChunkRetry overrides the retry policy applied to a single chunk's
+// download-and-decode before the stream gives up and aborts. The default
+// retries a failed chunk twice more with full-jitter exponential backoff.
+func WithChunkRetry(policy base.RetryPolicy) StreamOption {
+	return func(o *streamOptions) {
+		o.chunkRetry = policy
+	}
+}
+
+// StreamAssets concurrently downloads exported assets and streams them
+// out as they are decoded, chunk by chunk, instead of unmarshalling each
+// chunk into a slice as AssetsIterator does. Each chunk's body is
+// downloaded in full before its items are decoded, so the memory held
+// per worker is one chunk's raw bytes. The returned
+// channels close once every chunk has been delivered, the first chunk
+// exhausts its WithChunkRetry attempts, or ctx is done; drain both until
+// they close.
+func (e *ExportsAPI) StreamAssets(ctx context.Context, req *ExportAssetsRequest, opts ...StreamOption) (<-chan ExportedAsset, <-chan error) {
+	out := make(chan ExportedAsset)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errs)
+
+		exportUUID, err := e.AssetsExport(ctx, req)
+		if err != nil {
+			sendErr(ctx, errs, err)
+			return
+		}
+		status, err := e.WaitForExport(ctx, "assets", exportUUID, 5*time.Second)
+		if err != nil {
+			sendErr(ctx, errs, err)
+			return
+		}
+
+		items, itemErrs := streamChunks[ExportedAsset](ctx, status.ChunksAvailable,
+			func(ctx context.Context, chunkID int) (io.Reader, error) {
+				return e.AssetsExportChunk(ctx, exportUUID, chunkID)
+			}, opts...)
+
+		relayStream(ctx, out, errs, items, itemErrs)
+	}()
+
+	return out, errs
+}
+
+// StreamVulns is the vulnerability-export equivalent of StreamAssets.
+func (e *ExportsAPI) StreamVulns(ctx context.Context, req *ExportVulnsRequest, opts ...StreamOption) (<-chan ExportedVuln, <-chan error) {
+	out := make(chan ExportedVuln)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errs)
+
+		exportUUID, err := e.VulnsExport(ctx, req)
+		if err != nil {
+			sendErr(ctx, errs, err)
+			return
+		}
+		status, err := e.WaitForExport(ctx, "vulns", exportUUID, 5*time.Second)
+		if err != nil {
+			sendErr(ctx, errs, err)
+			return
+		}
+
+		items, itemErrs := streamChunks[ExportedVuln](ctx, status.ChunksAvailable,
+			func(ctx context.Context, chunkID int) (io.Reader, error) {
+				return e.VulnsExportChunk(ctx, exportUUID, chunkID)
+			}, opts...)
+
+		relayStream(ctx, out, errs, items, itemErrs)
+	}()
+
+	return out, errs
+}
+
+// streamChunks fans chunkIDs out across opts' concurrency limit, each
+// worker pulling a chunk via fetchChunk, retrying it under opts' chunk
+// retry policy, and decoding it item by item with a streaming
+// json.Decoder, so decoded items are never collected into a slice; the
+// raw bytes a worker holds are whatever fetchChunk's reader holds (a
+// whole chunk, for AssetsExportChunk and VulnsExportChunk). The first
+// chunk to exhaust its retries cancels every other in-flight download; the
+// returned channels then close once all workers have unwound.
+func streamChunks[T any](ctx context.Context, chunkIDs []int, fetchChunk func(ctx context.Context, chunkID int) (io.Reader, error), opts ...StreamOption) (<-chan T, <-chan error) {
+	cfg := defaultStreamOptions()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	out := make(chan T, cfg.concurrency)
+	errs := make(chan error, 1)
+
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+		for _, id := range chunkIDs {
+			select {
+			case jobs <- id:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	var failOnce sync.Once
+	fail := func(err error) {
+		failOnce.Do(func() {
+			errs <- err
+			cancel()
+		})
+	}
+
+	wg.Add(cfg.concurrency)
+	for i := 0; i < cfg.concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for chunkID := range jobs {
+				if err := decodeChunkWithRetry(ctx, chunkID, fetchChunk, cfg.chunkRetry, out); err != nil {
+					fail(err)
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+		close(errs)
+		cancel()
+	}()
+
+	return out, errs
+}
+
+// decodeChunkWithRetry downloads and decodes one chunk, retrying the whole
+// download-plus-decode under policy if it fails, since a truncated decode
+// partway through leaves no safe resume point.
+func decodeChunkWithRetry[T any](ctx context.Context, chunkID int, fetchChunk func(ctx context.Context, chunkID int) (io.Reader, error), policy base.RetryPolicy, out chan<- T) error {
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			timer := time.NewTimer(chunkBackoff(policy, attempt-1))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+
+		reader, err := fetchChunk(ctx, chunkID)
+		if err == nil {
+			err = decodeChunkStream(ctx, reader, out)
+		}
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		lastErr = err
+	}
+	return fmt.Errorf("export chunk %d: %w", chunkID, lastErr)
+}
+
+// decodeChunkStream streams a chunk's JSON array body one item at a time
+// instead of buffering it whole, emitting each item on out as it parses.
+func decodeChunkStream[T any](ctx context.Context, r io.Reader, out chan<- T) error {
+	dec := json.NewDecoder(r)
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("failed to read opening array token: %w", err)
+	}
+	for dec.More() {
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return fmt.Errorf("failed to decode chunk item: %w", err)
+		}
+		select {
+		case out <- item:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("failed to read closing array token: %w", err)
+	}
+	return nil
+}
+
+// chunkBackoff computes the full-jitter exponential delay before the given
+// retry attempt (1-based) under policy.
+func chunkBackoff(policy base.RetryPolicy, attempt int) time.Duration {
+	initial := policy.InitialBackoff
+	if initial <= 0 {
+		initial = time.Second
+	}
+	maxWait := policy.MaxBackoff
+	if maxWait <= 0 {
+		maxWait = 30 * time.Second
+	}
+	mult := policy.Multiplier
+	if mult <= 0 {
+		mult = 2
+	}
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	capped := float64(initial) * math.Pow(mult, float64(attempt-1))
+	if capped > float64(maxWait) || capped < 0 {
+		capped = float64(maxWait)
+	}
+	if capped <= 0 {
+		return 0
+	}
+	if !policy.Jitter {
+		return time.Duration(capped)
+	}
+	return time.Duration(rand.Int63n(int64(capped) + 1))
+}
+
+// relayStream forwards items and errors from an inner streamChunks pair
+// onto the outer channels StreamAssets/StreamVulns return, stopping early
+// if ctx is done.
+func relayStream[T any](ctx context.Context, out chan<- T, errs chan<- error, items <-chan T, itemErrs <-chan error) {
+	for items != nil || itemErrs != nil {
+		select {
+		case item, ok := <-items:
+			if !ok {
+				items = nil
+				continue
+			}
+			select {
+			case out <- item:
+			case <-ctx.Done():
+				return
+			}
+		case err, ok := <-itemErrs:
+			if !ok {
+				itemErrs = nil
+				continue
+			}
+			sendErr(ctx, errs, err)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sendErr delivers err on errs without blocking forever if ctx ends first.
+func sendErr(ctx context.Context, errs chan<- error, err error) {
+	select {
+	case errs <- err:
+	case <-ctx.Done():
+	}
 }
 
 // ListExports lists all exports of a given type.
@@ -460,3 +1168,83 @@ func (e *ExportsAPI) ListExports(ctx context.Context, exportType string) ([]Expo
 	return result.Exports, nil
 }
 
+// ExportToDirectoryOption configures ExportsAPI.ExportToDirectory.
+type ExportToDirectoryOption func(*exportToDirectoryConfig)
+
+type exportToDirectoryConfig struct {
+	cipher base.ChunkCipher
+}
+
+// WithChunkEncryption makes ExportToDirectory seal every chunk with
+// cipher before writing it to disk, so exported PII/CVE data is
+// encrypted at rest between download and downstream processing. Read an
+// encrypted chunk back with OpenEncryptedChunk using the same cipher.
+func WithChunkEncryption(cipher base.ChunkCipher) ExportToDirectoryOption {
+	return func(c *exportToDirectoryConfig) {
+		c.cipher = cipher
+	}
+}
+
+// ExportToDirectory runs an assets export to completion and writes each
+// chunk to its own file in dir, named chunk-<id>.json, or
+// chunk-<id>.json.enc if WithChunkEncryption is set. It returns the paths
+// written, in chunk order.
+func (e *ExportsAPI) ExportToDirectory(ctx context.Context, dir string, req *ExportAssetsRequest, opts ...ExportToDirectoryOption) ([]string, error) {
+	var cfg exportToDirectoryConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	exportUUID, err := e.AssetsExport(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	status, err := e.WaitForExport(ctx, "assets", exportUUID, 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, chunkID := range status.ChunksAvailable {
+		reader, err := e.AssetsExportChunk(ctx, exportUUID, chunkID)
+		if err != nil {
+			return paths, err
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return paths, err
+		}
+
+		name := fmt.Sprintf("chunk-%d.json", chunkID)
+		if cfg.cipher != nil {
+			data, err = cfg.cipher.Encrypt(data)
+			if err != nil {
+				return paths, fmt.Errorf("encrypt chunk %d: %w", chunkID, err)
+			}
+			name += ".enc"
+		}
+
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// OpenEncryptedChunk opens and decrypts a chunk file written by
+// ExportToDirectory with WithChunkEncryption(cipher), returning a reader
+// over the plaintext.
+func OpenEncryptedChunk(path string, cipher base.ChunkCipher) (io.Reader, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := cipher.Decrypt(data)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt %s: %w", path, err)
+	}
+	return bytes.NewReader(plaintext), nil
+}
+
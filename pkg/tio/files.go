@@ -3,10 +3,15 @@ package tio
 import (
 	"bytes"
 	"context"
-	"fmt"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"io"
 	"mime/multipart"
 	"path/filepath"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/base"
 )
 
 // FilesAPI handles file upload operations.
@@ -14,26 +19,158 @@ type FilesAPI struct {
 	client *Client
 }
 
-// Upload uploads a file to Tenable.io.
-func (f *FilesAPI) Upload(ctx context.Context, filename string, data io.Reader, encrypted bool) (string, error) {
-	// Create multipart form
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
+// UploadProgressFunc reports upload progress as bytes are sent.
+type UploadProgressFunc func(bytesSent, totalBytes int64)
 
-	part, err := writer.CreateFormFile("Filedata", filepath.Base(filename))
+// UploadResult contains the outcome of a file upload.
+type UploadResult struct {
+	// Fileuploaded is the server-assigned filename, as returned by the API.
+	Fileuploaded string
+	// SHA256 is the hex-encoded digest of the uploaded content.
+	SHA256 string
+}
+
+// UploadOptions configures UploadLarge.
+type UploadOptions struct {
+	// PartSize splits the upload into chunks of this size. Zero disables
+	// chunking and sends the stream as a single multipart request.
+	PartSize int64
+	// MaxRetries is the number of retry attempts per chunk on 429/5xx.
+	// Zero uses a default of 3.
+	MaxRetries int
+	// Backoff is the initial delay between chunk retries; it doubles on
+	// each subsequent attempt. Zero uses a default of 1s.
+	Backoff time.Duration
+	// Progress, if set, is invoked after each chunk has been sent. It is
+	// not called when PartSize is zero (non-chunked mode).
+	Progress UploadProgressFunc
+}
+
+// Upload uploads a file to Tenable.io, streaming the multipart body so
+// memory usage stays proportional to a single read buffer rather than the
+// full file size.
+func (f *FilesAPI) Upload(ctx context.Context, filename string, data io.Reader, encrypted bool) (string, error) {
+	result, err := f.upload(ctx, filename, data, encrypted)
 	if err != nil {
 		return "", err
 	}
+	return result.Fileuploaded, nil
+}
 
-	if _, err := io.Copy(part, data); err != nil {
-		return "", err
+// UploadLarge uploads a file in chunks, retrying each chunk with
+// exponential backoff on 429/5xx and reporting progress as bytes are sent.
+// Each chunk is sent as its own file/upload request under filename, and
+// a new call starts again from the first chunk. In chunked mode the
+// returned UploadResult carries only SHA256; Fileuploaded is empty.
+// Pass size <= 0 if the total length is unknown; progress reporting will
+// then report 0 for totalBytes. The returned SHA256 digest covers the
+// entire stream as sent, regardless of chunking.
+func (f *FilesAPI) UploadLarge(ctx context.Context, filename string, size int64, data io.Reader, encrypted bool, opts *UploadOptions) (*UploadResult, error) {
+	if opts == nil {
+		opts = &UploadOptions{}
+	}
+	if opts.PartSize <= 0 {
+		return f.upload(ctx, filename, data, encrypted)
 	}
 
-	if err := writer.Close(); err != nil {
-		return "", err
+	maxRetries := opts.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = 3
+	}
+	backoff := opts.Backoff
+	if backoff <= 0 {
+		backoff = 1 * time.Second
+	}
+	if size < 0 {
+		size = 0
+	}
+
+	hasher := sha256.New()
+	tee := io.TeeReader(data, hasher)
+
+	var sent int64
+	buf := make([]byte, opts.PartSize)
+	for {
+		n, readErr := io.ReadFull(tee, buf)
+		if n > 0 {
+			chunk := buf[:n]
+			if err := f.uploadChunkWithRetry(ctx, filename, chunk, encrypted, maxRetries, backoff); err != nil {
+				return nil, err
+			}
+			sent += int64(n)
+			if opts.Progress != nil {
+				opts.Progress(sent, size)
+			}
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return nil, readErr
+		}
 	}
 
-	// Create the request
+	return &UploadResult{SHA256: hex.EncodeToString(hasher.Sum(nil))}, nil
+}
+
+// uploadChunkWithRetry uploads a single chunk, retrying on 429/5xx with
+// exponential backoff. Context cancellation aborts the retry loop.
+func (f *FilesAPI) uploadChunkWithRetry(ctx context.Context, filename string, chunk []byte, encrypted bool, maxRetries int, backoff time.Duration) error {
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		_, err := f.upload(ctx, filename, bytes.NewReader(chunk), encrypted)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		var apiErr *base.APIError
+		if errors.As(err, &apiErr) && apiErr.Retryable() {
+			continue
+		}
+		return err
+	}
+	return lastErr
+}
+
+// upload streams data as a multipart/form-data body through an io.Pipe so
+// the request body is never buffered in full, tees the stream through a
+// SHA-256 hasher, and cancels mid-flight if ctx is done.
+func (f *FilesAPI) upload(ctx context.Context, filename string, data io.Reader, encrypted bool) (*UploadResult, error) {
+	hasher := sha256.New()
+
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	go func() {
+		part, err := writer.CreateFormFile("Filedata", filepath.Base(filename))
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+
+		tee := io.TeeReader(data, hasher)
+		if _, err := copyWithContext(ctx, part, tee); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+
+		if err := writer.Close(); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		pw.Close()
+	}()
+
 	path := "file/upload"
 	if encrypted {
 		path = "file/upload?no_enc=1"
@@ -45,18 +182,40 @@ func (f *FilesAPI) Upload(ctx context.Context, filename string, data io.Reader,
 
 	req := f.client.Request(ctx).
 		SetHeader("Content-Type", writer.FormDataContentType()).
-		SetBody(body.Bytes()).
+		SetBody(pr).
 		SetResult(&result)
 
 	resp, err := req.Post(path)
 	if err != nil {
-		return "", err
+		return nil, &base.ConnectionError{URL: f.client.BaseURL(), Message: "upload failed", Err: err}
 	}
 
-	if resp.StatusCode() >= 400 {
-		return "", fmt.Errorf("upload failed with status %d", resp.StatusCode())
+	if err := f.client.CheckResponse(resp); err != nil {
+		return nil, err
 	}
 
-	return result.Fileuploaded, nil
+	return &UploadResult{
+		Fileuploaded: result.Fileuploaded,
+		SHA256:       hex.EncodeToString(hasher.Sum(nil)),
+	}, nil
 }
 
+// copyWithContext copies src to dst, aborting early if ctx is cancelled.
+func copyWithContext(ctx context.Context, dst io.Writer, src io.Reader) (int64, error) {
+	done := make(chan struct{})
+	var (
+		n   int64
+		err error
+	)
+	go func() {
+		n, err = io.Copy(dst, src)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return n, err
+	case <-ctx.Done():
+		return n, ctx.Err()
+	}
+}
@@ -0,0 +1,150 @@
+package tio
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// FilterBuilder builds and validates []WorkbenchFilter entries for
+// WorkbenchesAPI.Assets/Vulnerabilities/Export against the metadata
+// FiltersAPI returns (ScanFilters, VulnFilters, AssetFilters, ...): a
+// filter's Name must exist, its Operator must be one FiltersAPI says
+// that filter supports, and its Value must satisfy the filter's Control
+// (a dropdown's enumerated List or an entry's Regex; values for a date
+// control are not checked). This replaces hand-assembling the raw
+// fmt.Sprintf("filter.%d.filter", i) params WorkbenchOptions.Filters
+// expects, which offers no feedback until the API rejects the request.
+//
+// A zero FilterBuilder has no known filters and rejects every call;
+// construct one with NewFilterBuilder(filters) using the map FiltersAPI
+// returned for the endpoint being queried.
+type FilterBuilder struct {
+	filters map[string]Filter
+	entries []WorkbenchFilter
+	errs    []error
+}
+
+// NewFilterBuilder returns a FilterBuilder that validates against
+// filters, the map[string]Filter returned by e.g. FiltersAPI.AssetFilters.
+func NewFilterBuilder(filters map[string]Filter) *FilterBuilder {
+	return &FilterBuilder{filters: filters}
+}
+
+// Eq adds a "field equals value" filter.
+func (b *FilterBuilder) Eq(name, value string) *FilterBuilder { return b.add(name, "eq", value) }
+
+// Neq adds a "field does not equal value" filter.
+func (b *FilterBuilder) Neq(name, value string) *FilterBuilder { return b.add(name, "neq", value) }
+
+// Match adds a substring-match filter.
+func (b *FilterBuilder) Match(name, value string) *FilterBuilder { return b.add(name, "match", value) }
+
+// NotMatch adds a negated substring-match filter.
+func (b *FilterBuilder) NotMatch(name, value string) *FilterBuilder {
+	return b.add(name, "nmatch", value)
+}
+
+// Gt adds a "field greater than value" filter.
+func (b *FilterBuilder) Gt(name, value string) *FilterBuilder { return b.add(name, "gt", value) }
+
+// Lt adds a "field less than value" filter.
+func (b *FilterBuilder) Lt(name, value string) *FilterBuilder { return b.add(name, "lt", value) }
+
+// In adds a "field is one of values" filter, rendered as a single "eq"
+// filter with a comma-separated value, the convention Tenable's
+// dropdown/multi-select controls use.
+func (b *FilterBuilder) In(name string, values ...string) *FilterBuilder {
+	return b.add(name, "eq", strings.Join(values, ","))
+}
+
+// Between adds a date-range filter, emitting a "date-gt" and a
+// "date-lt" entry bounding (from, to), both ends exclusive.
+func (b *FilterBuilder) Between(name string, from, to time.Time) *FilterBuilder {
+	b.add(name, "date-gt", from.UTC().Format(time.RFC3339))
+	return b.add(name, "date-lt", to.UTC().Format(time.RFC3339))
+}
+
+// add validates name/operator/value against b.filters, recording any
+// validation failure rather than stopping the chain, so Eq("typo", ...)
+// doesn't lose the rest of the call chain: Build reports all errors at
+// once.
+func (b *FilterBuilder) add(name, operator, value string) *FilterBuilder {
+	if err := b.validate(name, operator, value); err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+	b.entries = append(b.entries, WorkbenchFilter{Name: name, Operator: operator, Value: value})
+	return b
+}
+
+func (b *FilterBuilder) validate(name, operator, value string) error {
+	f, ok := b.filters[name]
+	if !ok {
+		return fmt.Errorf("filter builder: unknown filter %q", name)
+	}
+	if !containsString(f.Operators, operator) {
+		return fmt.Errorf("filter builder: %q does not support operator %q (supported: %v)", name, operator, f.Operators)
+	}
+	return validateControlValue(f.Control, value)
+}
+
+// validateControlValue checks value against the constraints c describes:
+// a dropdown/list control must match one of c.List per comma-separated
+// candidate, and a regex control must match c.Regex. Controls without a
+// recognized constraint (e.g. a free-text "entry" with no Regex) pass
+// unchecked, since FiltersAPI doesn't always populate one.
+func validateControlValue(c Control, value string) error {
+	switch {
+	case len(c.List) > 0:
+		for _, candidate := range strings.Split(value, ",") {
+			if !containsString(c.List, candidate) {
+				return fmt.Errorf("filter builder: value %q is not one of %v", candidate, c.List)
+			}
+		}
+	case c.Regex != "":
+		re, err := regexp.Compile(c.Regex)
+		if err != nil {
+			return fmt.Errorf("filter builder: compile control regex %q: %w", c.Regex, err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("filter builder: value %q does not match %q", value, c.Regex)
+		}
+	}
+	return nil
+}
+
+// Build returns the accumulated []WorkbenchFilter, or the combined
+// validation errors recorded by Eq/Neq/.../Between if any call failed.
+func (b *FilterBuilder) Build() ([]WorkbenchFilter, error) {
+	if len(b.errs) > 0 {
+		msgs := make([]string, len(b.errs))
+		for i, err := range b.errs {
+			msgs[i] = err.Error()
+		}
+		return nil, fmt.Errorf("filter builder: %s", strings.Join(msgs, "; "))
+	}
+	return b.entries, nil
+}
+
+// String renders the accumulated filters as a human-readable Tenable
+// search string, e.g. `severity eq "high" and plugin.family eq "Web
+// Servers"`, for logging and debugging. It ignores validation errors;
+// call Build to find out whether the filters are actually usable.
+func (b *FilterBuilder) String() string {
+	parts := make([]string, len(b.entries))
+	for i, e := range b.entries {
+		parts[i] = fmt.Sprintf("%s %s %q", e.Name, e.Operator, e.Value)
+	}
+	return strings.Join(parts, " and ")
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
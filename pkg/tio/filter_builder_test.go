@@ -0,0 +1,91 @@
+package tio
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func testFilters() map[string]Filter {
+	return map[string]Filter{
+		"severity": {
+			Name:      "severity",
+			Operators: []string{"eq", "neq"},
+			Control:   Control{Type: "dropdown", List: []string{"critical", "high", "medium", "low", "info"}},
+		},
+		"plugin.family": {
+			Name:      "plugin.family",
+			Operators: []string{"eq"},
+			Control:   Control{Type: "dropdown", List: []string{"Web Servers", "Databases"}},
+		},
+		"hostname": {
+			Name:      "hostname",
+			Operators: []string{"match", "nmatch"},
+			Control:   Control{Type: "entry", Regex: `^[a-zA-Z0-9.-]+$`},
+		},
+		"last_seen": {
+			Name:      "last_seen",
+			Operators: []string{"date-gt", "date-lt"},
+			Control:   Control{Type: "date"},
+		},
+	}
+}
+
+func TestFilterBuilderBuildsValidFilters(t *testing.T) {
+	b := NewFilterBuilder(testFilters())
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	filters, err := b.Eq("severity", "high").In("plugin.family", "Web Servers", "Databases").Between("last_seen", from, to).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if len(filters) != 4 {
+		t.Fatalf("filters = %+v, want 4 entries (eq, eq-in, date-gt, date-lt)", filters)
+	}
+	if filters[1].Value != "Web Servers,Databases" {
+		t.Errorf("In() value = %q, want comma-joined", filters[1].Value)
+	}
+}
+
+func TestFilterBuilderRejectsUnknownFilter(t *testing.T) {
+	b := NewFilterBuilder(testFilters())
+	_, err := b.Eq("nope", "x").Build()
+	if err == nil || !strings.Contains(err.Error(), "unknown filter") {
+		t.Fatalf("Build() error = %v, want unknown filter error", err)
+	}
+}
+
+func TestFilterBuilderRejectsUnsupportedOperator(t *testing.T) {
+	b := NewFilterBuilder(testFilters())
+	_, err := b.Gt("severity", "high").Build()
+	if err == nil || !strings.Contains(err.Error(), "does not support operator") {
+		t.Fatalf("Build() error = %v, want unsupported operator error", err)
+	}
+}
+
+func TestFilterBuilderRejectsValueNotInDropdown(t *testing.T) {
+	b := NewFilterBuilder(testFilters())
+	_, err := b.Eq("severity", "catastrophic").Build()
+	if err == nil || !strings.Contains(err.Error(), "not one of") {
+		t.Fatalf("Build() error = %v, want dropdown value error", err)
+	}
+}
+
+func TestFilterBuilderRejectsValueFailingRegex(t *testing.T) {
+	b := NewFilterBuilder(testFilters())
+	_, err := b.Match("hostname", "bad host name!").Build()
+	if err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("Build() error = %v, want regex mismatch error", err)
+	}
+}
+
+func TestFilterBuilderString(t *testing.T) {
+	b := NewFilterBuilder(testFilters())
+	b.Eq("severity", "high").Match("hostname", "web01")
+
+	want := `severity eq "high" and hostname match "web01"`
+	if got := b.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,382 @@
+// Package httpapi exposes a subset of this module's read APIs (assets,
+// vulnerabilities, policies, access groups, and filter metadata) over
+// HTTP, so a downstream dashboard or aggregator can consume Tenable.io
+// data through a drop-in local proxy instead of embedding the SDK
+// itself. It reuses the same *tio.Client, base.Iterator, and
+// tio.FiltersAPI validation the Go callers of this module already rely
+// on, so the pagination and filter-passthrough logic only has to be
+// correct once.
+package httpapi
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/base"
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio"
+)
+
+// DefaultLimit is the page size used when a request omits "limit".
+const DefaultLimit = 100
+
+// MaxLimit bounds the "limit" query param so a caller can't force the
+// handler into fetching an unbounded number of pages from Tenable in a
+// single request.
+const MaxLimit = 1000
+
+// Handler is an http.Handler backed by a *tio.Client. Construct one with
+// NewHandler and mount it directly, or under a prefix via http.StripPrefix.
+type Handler struct {
+	client *tio.Client
+	mux    *http.ServeMux
+}
+
+// NewHandler returns a Handler serving read-only endpoints against
+// client: GET /v1/assets, /v1/vulnerabilities, /v1/policies,
+// /v1/access-groups, and /v1/filters/{scope}. Every response body is
+// JSON, gzip-encoded when the request sends "Accept-Encoding: gzip".
+func NewHandler(client *tio.Client) *Handler {
+	h := &Handler{client: client, mux: http.NewServeMux()}
+	h.mux.HandleFunc("/v1/assets", h.handleAssets)
+	h.mux.HandleFunc("/v1/vulnerabilities", h.handleVulnerabilities)
+	h.mux.HandleFunc("/v1/policies", h.handlePolicies)
+	h.mux.HandleFunc("/v1/access-groups", h.handleAccessGroups)
+	h.mux.HandleFunc("/v1/filters/", h.handleFilters)
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// pageParams is the decoded limit/offset pair shared by every listing
+// endpoint.
+type pageParams struct {
+	limit  int
+	offset int
+}
+
+func parsePageParams(r *http.Request) (pageParams, error) {
+	p := pageParams{limit: DefaultLimit}
+	q := r.URL.Query()
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return p, fmt.Errorf("invalid limit %q", v)
+		}
+		if n > MaxLimit {
+			n = MaxLimit
+		}
+		p.limit = n
+	}
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return p, fmt.Errorf("invalid offset %q", v)
+		}
+		p.offset = n
+	}
+	return p, nil
+}
+
+// parseFilters decodes the filter.N.filter/filter.N.quality/filter.N.value
+// triples a request sent, in index order, and validates each against
+// available — the map[string]tio.Filter a tio.FiltersAPI scope method
+// returned — so a typo'd filter name or an operator the filter doesn't
+// support is rejected here with a 400 rather than forwarded to Tenable.
+func parseFilters(r *http.Request, available map[string]tio.Filter) ([]tio.WorkbenchFilter, error) {
+	q := r.URL.Query()
+	var filters []tio.WorkbenchFilter
+	for i := 0; ; i++ {
+		name := q.Get(fmt.Sprintf("filter.%d.filter", i))
+		if name == "" {
+			break
+		}
+		operator := q.Get(fmt.Sprintf("filter.%d.quality", i))
+		value := q.Get(fmt.Sprintf("filter.%d.value", i))
+
+		f, ok := available[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown filter %q", name)
+		}
+		if !operatorSupported(f, operator) {
+			return nil, fmt.Errorf("filter %q does not support operator %q", name, operator)
+		}
+		filters = append(filters, tio.WorkbenchFilter{Name: name, Operator: operator, Value: value})
+	}
+	return filters, nil
+}
+
+func operatorSupported(f tio.Filter, operator string) bool {
+	for _, op := range f.Operators {
+		if op == operator {
+			return true
+		}
+	}
+	return false
+}
+
+// handleAssets serves GET /v1/assets, paginated via base.Iterator over
+// tio.AssetsAPI.List and filtered via tio.WorkbenchesAPI-style filter.N
+// triples validated against tio.FiltersAPI.AssetFilters.
+func (h *Handler) handleAssets(w http.ResponseWriter, r *http.Request) {
+	if !requireGet(w, r) {
+		return
+	}
+	ctx := r.Context()
+
+	page, err := parsePageParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	available, err := h.client.Filters.AssetFilters(ctx)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	filters, err := parseFilters(r, available)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	opts := &tio.AssetListOptions{}
+	if len(filters) > 0 {
+		opts.Filter = filterExprString(filters)
+	}
+
+	it := h.client.Assets.List(ctx, opts)
+	items, total, err := takePage[tio.Asset](it, page)
+	if err != nil {
+		writeError(w, classifyIteratorErr(err), err)
+		return
+	}
+	writeJSON(w, r, listResponse{Items: items, Total: total, Limit: page.limit, Offset: page.offset})
+}
+
+// handleVulnerabilities serves GET /v1/vulnerabilities via
+// tio.WorkbenchesAPI.Vulnerabilities, which already speaks the
+// filter.N.filter/quality/value convention natively. The workbench
+// endpoint doesn't support server-side limit/offset, so pagination is
+// applied to the result it returns.
+func (h *Handler) handleVulnerabilities(w http.ResponseWriter, r *http.Request) {
+	if !requireGet(w, r) {
+		return
+	}
+	ctx := r.Context()
+
+	page, err := parsePageParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	available, err := h.client.Filters.VulnFilters(ctx)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	filters, err := parseFilters(r, available)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	vulns, err := h.client.Workbenches.Vulnerabilities(ctx, &tio.WorkbenchOptions{Filters: filters})
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	items, total := paginateSlice(vulns, page)
+	writeJSON(w, r, listResponse{Items: items, Total: total, Limit: page.limit, Offset: page.offset})
+}
+
+// handlePolicies serves GET /v1/policies over tio.PoliciesAPI.List, which
+// Tenable doesn't paginate server-side either.
+func (h *Handler) handlePolicies(w http.ResponseWriter, r *http.Request) {
+	if !requireGet(w, r) {
+		return
+	}
+	page, err := parsePageParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	policies, err := h.client.Policies.List(r.Context())
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	items, total := paginateSlice(policies, page)
+	writeJSON(w, r, listResponse{Items: items, Total: total, Limit: page.limit, Offset: page.offset})
+}
+
+// handleAccessGroups serves GET /v1/access-groups over
+// tio.AccessControlAPI.List.
+func (h *Handler) handleAccessGroups(w http.ResponseWriter, r *http.Request) {
+	if !requireGet(w, r) {
+		return
+	}
+	page, err := parsePageParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	groups, err := h.client.AccessControl.List(r.Context())
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	items, total := paginateSlice(groups, page)
+	writeJSON(w, r, listResponse{Items: items, Total: total, Limit: page.limit, Offset: page.offset})
+}
+
+// filterScopes maps the {scope} path segment of /v1/filters/{scope} to
+// the tio.FiltersAPI method it proxies.
+var filterScopes = map[string]func(ctx context.Context, f *tio.FiltersAPI) (map[string]tio.Filter, error){
+	"scans":           func(ctx context.Context, f *tio.FiltersAPI) (map[string]tio.Filter, error) { return f.ScanFilters(ctx) },
+	"vulnerabilities": func(ctx context.Context, f *tio.FiltersAPI) (map[string]tio.Filter, error) { return f.VulnFilters(ctx) },
+	"assets": func(ctx context.Context, f *tio.FiltersAPI) (map[string]tio.Filter, error) {
+		return f.AssetFilters(ctx)
+	},
+	"credentials": func(ctx context.Context, f *tio.FiltersAPI) (map[string]tio.Filter, error) {
+		return f.CredentialFilters(ctx)
+	},
+}
+
+// handleFilters serves GET /v1/filters/{scope}, returning the
+// map[string]tio.Filter a FiltersAPI scope method returns as-is, so
+// callers can discover valid filter.N.filter names/operators before
+// querying /v1/assets or /v1/vulnerabilities.
+func (h *Handler) handleFilters(w http.ResponseWriter, r *http.Request) {
+	if !requireGet(w, r) {
+		return
+	}
+	scope := strings.TrimPrefix(r.URL.Path, "/v1/filters/")
+	fn, ok := filterScopes[scope]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown filter scope %q", scope))
+		return
+	}
+
+	filters, err := fn(r.Context(), h.client.Filters)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, r, filters)
+}
+
+func requireGet(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return false
+	}
+	return true
+}
+
+// listResponse is the envelope every listing endpoint returns.
+type listResponse struct {
+	Items  interface{} `json:"items"`
+	Total  int         `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+}
+
+// takePage drives it to page.offset (Iterator has no native seek, so
+// this skips items one at a time) and returns up to page.limit items
+// from there, along with the iterator's total.
+func takePage[T any](it *base.Iterator[T], page pageParams) ([]T, int, error) {
+	for i := 0; i < page.offset && it.Next(); i++ {
+	}
+	if err := it.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	items, err := it.Take(page.limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, it.Total(), nil
+}
+
+// paginateSlice applies page to a fully materialized slice, for the
+// endpoints backed by Tenable APIs that don't paginate server-side.
+func paginateSlice[T any](items []T, page pageParams) ([]T, int) {
+	total := len(items)
+	if page.offset >= total {
+		return []T{}, total
+	}
+	end := page.offset + page.limit
+	if end > total {
+		end = total
+	}
+	return items[page.offset:end], total
+}
+
+// filterExprString renders filters as the raw search-string form the
+// AssetsAPI.List Filter option expects, matching FilterBuilder.String's
+// `name operator "value"` convention.
+func filterExprString(filters []tio.WorkbenchFilter) string {
+	parts := make([]string, len(filters))
+	for i, f := range filters {
+		parts[i] = fmt.Sprintf("%s %s %q", f.Name, f.Operator, f.Value)
+	}
+	return strings.Join(parts, " and ")
+}
+
+// classifyIteratorErr maps an Iterator error to an HTTP status: a
+// context cancellation/deadline is the caller's doing (mapped to 499-ish
+// territory via 400), anything wrapping base.ErrNotFound/Unauthorized
+// mirrors the underlying APIError's status, and everything else is a
+// 502 since it came from the upstream Tenable API.
+func classifyIteratorErr(err error) int {
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusBadRequest
+	case errors.Is(err, base.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, base.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, base.ErrForbidden):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+// writeError writes a {"error": "..."} JSON body with the given status.
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+// writeJSON writes v as JSON, gzip-encoding the body when the request
+// advertises "Accept-Encoding: gzip".
+func writeJSON(w http.ResponseWriter, r *http.Request, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		_ = json.NewEncoder(w).Encode(v)
+		return
+	}
+
+	w.Header().Set("Content-Encoding", "gzip")
+	gz := gzip.NewWriter(w)
+	defer gz.Close()
+	_ = json.NewEncoder(gz).Encode(v)
+}
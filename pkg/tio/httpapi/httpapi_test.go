@@ -0,0 +1,165 @@
+package httpapi
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio"
+)
+
+// newFakeTenable returns an httptest.Server standing in for Tenable.io,
+// backing assets, asset filters, and access groups with enough fixture
+// data to exercise pagination and filter validation.
+func newFakeTenable(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/assets":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"assets": []tio.Asset{{ID: "a1"}, {ID: "a2"}},
+				"total":  2,
+			})
+		case r.URL.Path == "/filters/workbenches/assets":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"filters": []tio.Filter{
+					{Name: "severity", Operators: []string{"eq"}},
+				},
+			})
+		case r.URL.Path == "/access-groups":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_groups": []tio.AccessGroup{{UUID: "g1"}, {UUID: "g2"}, {UUID: "g3"}},
+			})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newTestHandler(t *testing.T) (*Handler, *httptest.Server) {
+	t.Helper()
+	srv := newFakeTenable(t)
+	client, err := tio.New(tio.WithURL(srv.URL), tio.WithAPIKeys("access", "secret"))
+	if err != nil {
+		t.Fatalf("tio.New() error = %v", err)
+	}
+	return NewHandler(client), srv
+}
+
+func decodeBody(t *testing.T, rec *httptest.ResponseRecorder) listResponse {
+	t.Helper()
+	body := io.Reader(rec.Body)
+	if rec.Header().Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(rec.Body)
+		if err != nil {
+			t.Fatalf("gzip.NewReader() error = %v", err)
+		}
+		defer gz.Close()
+		body = gz
+	}
+	var lr listResponse
+	if err := json.NewDecoder(body).Decode(&lr); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return lr
+}
+
+func TestHandleAssetsReturnsGzippedPage(t *testing.T) {
+	h, srv := newTestHandler(t)
+	defer srv.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/assets?limit=1", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", rec.Header().Get("Content-Encoding"))
+	}
+
+	lr := decodeBody(t, rec)
+	if lr.Total != 2 {
+		t.Errorf("Total = %d, want 2", lr.Total)
+	}
+}
+
+func TestHandleAssetsRejectsUnknownFilter(t *testing.T) {
+	h, srv := newTestHandler(t)
+	defer srv.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/assets?filter.0.filter=nope&filter.0.quality=eq&filter.0.value=x", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400, body = %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandleAssetsRejectsUnsupportedOperator(t *testing.T) {
+	h, srv := newTestHandler(t)
+	defer srv.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/assets?filter.0.filter=severity&filter.0.quality=match&filter.0.value=high", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400, body = %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandleAccessGroupsPaginatesSlice(t *testing.T) {
+	h, srv := newTestHandler(t)
+	defer srv.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/access-groups?limit=2&offset=1", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	lr := decodeBody(t, rec)
+	if lr.Total != 3 {
+		t.Errorf("Total = %d, want 3", lr.Total)
+	}
+	items, ok := lr.Items.([]interface{})
+	if !ok || len(items) != 2 {
+		t.Errorf("Items = %+v, want 2 entries", lr.Items)
+	}
+}
+
+func TestHandleFiltersUnknownScope(t *testing.T) {
+	h, srv := newTestHandler(t)
+	defer srv.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/filters/bogus", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+}
+
+func TestHandleAssetsMethodNotAllowed(t *testing.T) {
+	h, srv := newTestHandler(t)
+	defer srv.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/assets", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d, want 405", rec.Code)
+	}
+}
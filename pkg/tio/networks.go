@@ -3,7 +3,6 @@ package tio
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"strconv"
 	"time"
 
@@ -74,7 +73,7 @@ func (n *NetworksAPI) List(ctx context.Context) *base.Iterator[Network] {
 // Get retrieves a specific network.
 func (n *NetworksAPI) Get(ctx context.Context, networkUUID string) (*Network, error) {
 	var result Network
-	_, err := n.client.Get(ctx, fmt.Sprintf("networks/%s", networkUUID), &result)
+	_, err := n.client.NewRequest().Method("GET").Path("networks/%s", networkUUID).Do(ctx, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -92,7 +91,7 @@ func (n *NetworksAPI) Create(ctx context.Context, name, description string, asse
 	}
 
 	var result Network
-	_, err := n.client.Post(ctx, "networks", payload, &result)
+	_, err := n.client.NewRequest().Method("POST").Path("networks").Body(payload).Do(ctx, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +109,7 @@ func (n *NetworksAPI) Update(ctx context.Context, networkUUID, name, description
 	}
 
 	var result Network
-	_, err := n.client.Put(ctx, fmt.Sprintf("networks/%s", networkUUID), payload, &result)
+	_, err := n.client.NewRequest().Method("PUT").Path("networks/%s", networkUUID).Body(payload).Do(ctx, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +118,7 @@ func (n *NetworksAPI) Update(ctx context.Context, networkUUID, name, description
 
 // Delete removes a network.
 func (n *NetworksAPI) Delete(ctx context.Context, networkUUID string) error {
-	_, err := n.client.Delete(ctx, fmt.Sprintf("networks/%s", networkUUID))
+	_, err := n.client.NewRequest().Method("DELETE").Path("networks/%s", networkUUID).Do(ctx, nil)
 	return err
 }
 
@@ -128,20 +127,18 @@ func (n *NetworksAPI) AssignScanners(ctx context.Context, networkUUID string, sc
 	payload := map[string]interface{}{
 		"scanners": scannerUUIDs,
 	}
-	_, err := n.client.Post(ctx, fmt.Sprintf("networks/%s/scanners", networkUUID), payload, nil)
+	_, err := n.client.NewRequest().Method("POST").Path("networks/%s/scanners", networkUUID).Body(payload).Do(ctx, nil)
 	return err
 }
 
 // ListScanners retrieves scanners assigned to a network.
 func (n *NetworksAPI) ListScanners(ctx context.Context, networkUUID string) ([]Scanner, error) {
-	var result struct {
-		Scanners []Scanner `json:"scanners"`
-	}
-	_, err := n.client.Get(ctx, fmt.Sprintf("networks/%s/scanners", networkUUID), &result)
+	var result []Scanner
+	_, err := n.client.NewRequest().Method("GET").Path("networks/%s/scanners", networkUUID).Envelope("scanners").Do(ctx, &result)
 	if err != nil {
 		return nil, err
 	}
-	return result.Scanners, nil
+	return result, nil
 }
 
 // NetworkAssetCount represents asset counts in a network.
@@ -152,7 +149,7 @@ type NetworkAssetCount struct {
 // AssetCount retrieves the asset count for a network.
 func (n *NetworksAPI) AssetCount(ctx context.Context, networkUUID string) (int, error) {
 	var result NetworkAssetCount
-	_, err := n.client.Get(ctx, fmt.Sprintf("networks/%s/counts/assets", networkUUID), &result)
+	_, err := n.client.NewRequest().Method("GET").Path("networks/%s/counts/assets", networkUUID).Do(ctx, &result)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,34 @@
+// Package ecs defines the subset of Elastic Common Schema's
+// vulnerability.* and host.* fields that tio/normalize populates from
+// Tenable.io scan data. It is not a general-purpose ECS implementation —
+// only the fields tio/normalize can actually fill in from a Tenable.io
+// scan are modeled.
+package ecs
+
+// Document is a single ECS-shaped vulnerability record.
+type Document struct {
+	Timestamp     string        `json:"@timestamp"`
+	Host          Host          `json:"host"`
+	Vulnerability Vulnerability `json:"vulnerability"`
+}
+
+// Host is ECS's host.* field set.
+type Host struct {
+	Hostname string   `json:"hostname,omitempty"`
+	IP       []string `json:"ip,omitempty"`
+	MAC      []string `json:"mac,omitempty"`
+}
+
+// Vulnerability is ECS's vulnerability.* field set.
+type Vulnerability struct {
+	ID          string `json:"id,omitempty"`
+	Description string `json:"description,omitempty"`
+	Severity    string `json:"severity,omitempty"`
+	Reference   string `json:"reference,omitempty"`
+	Score       Score  `json:"score,omitempty"`
+}
+
+// Score is ECS's vulnerability.score.* field set.
+type Score struct {
+	Base float64 `json:"base,omitempty"`
+}
@@ -0,0 +1,187 @@
+// Package normalize converts Tenable.io scan findings (HostVuln entries
+// from a ScanHostDetails, enriched with ScanPluginDetails/PluginOutput
+// metadata) into vendor-neutral schemas — a minimal OCSF Vulnerability
+// Finding (package ocsf) and an Elastic ECS vulnerability.* document
+// (package ecs) — so downstream SIEM and vulnerability-management
+// pipelines can ingest Tenable data without a bespoke Tenable-specific
+// mapping.
+package normalize
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio"
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio/normalize/ecs"
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio/normalize/ocsf"
+)
+
+// severityNames maps the 0-4 scale ScanVuln, HostVuln, and
+// ScanPluginDetails all use for Severity to the textual severity both
+// target schemas expect.
+var severityNames = [...]string{"info", "low", "medium", "high", "critical"}
+
+func severityName(severity int) string {
+	if severity < 0 || severity >= len(severityNames) {
+		return "unknown"
+	}
+	return severityNames[severity]
+}
+
+// PluginMetadata supplies the extra detail a bare HostVuln doesn't
+// carry — description, CVEs, and CVSS score — sourced from a plugin's
+// ScanPluginDetails.PluginAttrs (as returned by ScansAPI.PluginOutput).
+// The zero PluginMetadata — what every finding gets when no lookup is
+// given, or the plugin carried none of these attributes — normalizes to
+// a finding with those fields simply omitted, not an error.
+type PluginMetadata struct {
+	Description string
+	CVEs        []string
+	CVSSScore   float64
+}
+
+// PluginMetadataFromAttributes extracts the PluginMetadata fields this
+// package knows how to map from a plugin's raw attribute list. Repeated
+// "cve" attributes all contribute; any other attribute is ignored.
+func PluginMetadataFromAttributes(attrs []tio.PluginAttribute) PluginMetadata {
+	var meta PluginMetadata
+	for _, a := range attrs {
+		switch a.Name {
+		case "description", "synopsis":
+			if meta.Description == "" {
+				meta.Description = a.Value
+			}
+		case "cve":
+			meta.CVEs = append(meta.CVEs, a.Value)
+		case "cvss3_base_score", "cvss_base_score":
+			if v, err := strconv.ParseFloat(a.Value, 64); err == nil {
+				meta.CVSSScore = v
+			}
+		}
+	}
+	return meta
+}
+
+// Host identifies the asset a HostVuln was found on, filled in from a
+// ScanHostDetails's Info block.
+type Host struct {
+	Hostname string
+	FQDN     string
+	IP       string
+	MAC      string
+}
+
+// HostFromInfo builds a Host from a ScanHostDetails's Info block and the
+// hostname it was listed under (ScanHostDetails itself doesn't carry the
+// host's name — that's a Scan-level ScanHost.Hostname).
+func HostFromInfo(hostname string, info tio.HostInfo) Host {
+	return Host{Hostname: hostname, FQDN: info.HostFQDN, IP: info.HostIP, MAC: info.MacAddress}
+}
+
+// MetadataLookup resolves the PluginMetadata for a plugin ID. Pass nil
+// to ToOCSF/ToECS/Convert to normalize using only HostVuln's own fields.
+type MetadataLookup func(pluginID int) (PluginMetadata, bool)
+
+// MetadataMap adapts a map[int]PluginMetadata, keyed by plugin ID, to a
+// MetadataLookup.
+func MetadataMap(m map[int]PluginMetadata) MetadataLookup {
+	return func(pluginID int) (PluginMetadata, bool) {
+		meta, ok := m[pluginID]
+		return meta, ok
+	}
+}
+
+func lookupMetadata(lookup MetadataLookup, pluginID int) PluginMetadata {
+	if lookup == nil {
+		return PluginMetadata{}
+	}
+	meta, _ := lookup(pluginID)
+	return meta
+}
+
+// Encoder converts a single enriched finding into a caller-chosen
+// output representation. ToOCSF and ToECS cover the two schemas this
+// package ships; implement Encoder against Convert to target a third
+// without reimplementing the plugin-metadata enrichment.
+type Encoder interface {
+	Encode(host Host, vuln tio.HostVuln, meta PluginMetadata, scanTime time.Time) interface{}
+}
+
+// EncoderFunc adapts a function to Encoder.
+type EncoderFunc func(host Host, vuln tio.HostVuln, meta PluginMetadata, scanTime time.Time) interface{}
+
+// Encode implements Encoder.
+func (f EncoderFunc) Encode(host Host, vuln tio.HostVuln, meta PluginMetadata, scanTime time.Time) interface{} {
+	return f(host, vuln, meta, scanTime)
+}
+
+// Convert runs every entry in vulns through enc, enriching each with
+// lookup(vuln.PluginID) first (lookup may be nil). A host with no
+// vulnerabilities — e.g. one reported for compliance findings only —
+// simply yields no results, not an error.
+func Convert(host Host, vulns []tio.HostVuln, lookup MetadataLookup, scanTime time.Time, enc Encoder) []interface{} {
+	out := make([]interface{}, 0, len(vulns))
+	for _, v := range vulns {
+		out = append(out, enc.Encode(host, v, lookupMetadata(lookup, v.PluginID), scanTime))
+	}
+	return out
+}
+
+// ToOCSF converts host's vulnerabilities into OCSF Vulnerability
+// Finding records, observed at scanTime.
+func ToOCSF(host Host, vulns []tio.HostVuln, lookup MetadataLookup, scanTime time.Time) []ocsf.VulnerabilityFinding {
+	findings := make([]ocsf.VulnerabilityFinding, len(vulns))
+	for i, v := range vulns {
+		meta := lookupMetadata(lookup, v.PluginID)
+		findings[i] = ocsf.VulnerabilityFinding{
+			ClassUID:    ocsf.ClassUIDVulnerabilityFinding,
+			CategoryUID: ocsf.CategoryUIDFindings,
+			ActivityID:  ocsf.ActivityIDCreate,
+			SeverityID:  v.Severity,
+			Severity:    severityName(v.Severity),
+			Time:        scanTime.Unix(),
+			Vulnerabilities: []ocsf.Vulnerability{{
+				Title:      v.PluginName,
+				Desc:       meta.Description,
+				CVE:        meta.CVEs,
+				CVSSScore:  meta.CVSSScore,
+				VendorName: v.PluginFamily,
+			}},
+			Device: ocsf.Device{Hostname: host.Hostname, IP: host.IP, MAC: host.MAC},
+		}
+	}
+	return findings
+}
+
+// ToECS converts host's vulnerabilities into ECS vulnerability.*
+// documents, observed at scanTime.
+func ToECS(host Host, vulns []tio.HostVuln, lookup MetadataLookup, scanTime time.Time) []ecs.Document {
+	docs := make([]ecs.Document, len(vulns))
+	for i, v := range vulns {
+		meta := lookupMetadata(lookup, v.PluginID)
+		var reference string
+		if len(meta.CVEs) > 0 {
+			reference = meta.CVEs[0]
+		}
+
+		doc := ecs.Document{
+			Timestamp: scanTime.UTC().Format(time.RFC3339),
+			Host:      ecs.Host{Hostname: host.Hostname},
+			Vulnerability: ecs.Vulnerability{
+				ID:          v.PluginName,
+				Description: meta.Description,
+				Severity:    severityName(v.Severity),
+				Reference:   reference,
+				Score:       ecs.Score{Base: meta.CVSSScore},
+			},
+		}
+		if host.IP != "" {
+			doc.Host.IP = []string{host.IP}
+		}
+		if host.MAC != "" {
+			doc.Host.MAC = []string{host.MAC}
+		}
+		docs[i] = doc
+	}
+	return docs
+}
@@ -0,0 +1,75 @@
+package normalize
+
+import (
+	"testing"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio"
+)
+
+func TestToOCSFWithMetadata(t *testing.T) {
+	host := HostFromInfo("web01", tio.HostInfo{HostFQDN: "web01.example.com", HostIP: "10.0.0.5", MacAddress: "aa:bb:cc:dd:ee:ff"})
+	vulns := []tio.HostVuln{{PluginID: 1, PluginName: "OpenSSL Vulnerability", PluginFamily: "General", Severity: 3}}
+	lookup := MetadataMap(map[int]PluginMetadata{
+		1: {Description: "OpenSSL is outdated", CVEs: []string{"CVE-2024-0001"}, CVSSScore: 7.5},
+	})
+	scanTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	findings := ToOCSF(host, vulns, lookup, scanTime)
+	if len(findings) != 1 {
+		t.Fatalf("len(findings) = %d, want 1", len(findings))
+	}
+	f := findings[0]
+	if f.Severity != "high" || f.SeverityID != 3 {
+		t.Errorf("Severity/SeverityID = %q/%d, want high/3", f.Severity, f.SeverityID)
+	}
+	if f.Device.IP != "10.0.0.5" || f.Device.MAC != "aa:bb:cc:dd:ee:ff" {
+		t.Errorf("Device = %+v, want IP 10.0.0.5 and MAC aa:bb:cc:dd:ee:ff", f.Device)
+	}
+	if len(f.Vulnerabilities) != 1 || f.Vulnerabilities[0].CVSSScore != 7.5 {
+		t.Errorf("Vulnerabilities = %+v, want a single entry with CVSSScore 7.5", f.Vulnerabilities)
+	}
+}
+
+func TestToOCSFMissingPluginAttributesOmitsFields(t *testing.T) {
+	host := HostFromInfo("web01", tio.HostInfo{HostIP: "10.0.0.5"})
+	vulns := []tio.HostVuln{{PluginID: 2, PluginName: "Unscored Finding", Severity: 0}}
+
+	findings := ToOCSF(host, vulns, nil, time.Now())
+	if len(findings) != 1 {
+		t.Fatalf("len(findings) = %d, want 1", len(findings))
+	}
+	v := findings[0].Vulnerabilities[0]
+	if v.CVSSScore != 0 || v.Desc != "" || v.CVE != nil {
+		t.Errorf("Vulnerability = %+v, want zero CVSSScore/Desc/CVE with no lookup", v)
+	}
+}
+
+func TestToECSComplianceOnlyHostYieldsNoDocuments(t *testing.T) {
+	host := HostFromInfo("compliance-only", tio.HostInfo{HostIP: "10.0.0.9"})
+
+	docs := ToECS(host, nil, nil, time.Now())
+	if len(docs) != 0 {
+		t.Errorf("len(docs) = %d, want 0 for a host with no vulnerability findings", len(docs))
+	}
+}
+
+func TestPluginMetadataFromAttributesCollectsMultipleCVEs(t *testing.T) {
+	attrs := []tio.PluginAttribute{
+		{Name: "cve", Value: "CVE-2024-0001"},
+		{Name: "cve", Value: "CVE-2024-0002"},
+		{Name: "cvss3_base_score", Value: "9.8"},
+		{Name: "description", Value: "Critical remote code execution"},
+	}
+
+	meta := PluginMetadataFromAttributes(attrs)
+	if len(meta.CVEs) != 2 {
+		t.Fatalf("len(meta.CVEs) = %d, want 2", len(meta.CVEs))
+	}
+	if meta.CVSSScore != 9.8 {
+		t.Errorf("meta.CVSSScore = %v, want 9.8", meta.CVSSScore)
+	}
+	if meta.Description != "Critical remote code execution" {
+		t.Errorf("meta.Description = %q, want %q", meta.Description, "Critical remote code execution")
+	}
+}
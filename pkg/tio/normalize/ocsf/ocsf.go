@@ -0,0 +1,44 @@
+// Package ocsf defines the subset of the Open Cybersecurity Schema
+// Framework's Vulnerability Finding class (2002) that tio/normalize
+// populates from Tenable.io scan data. It is not a general-purpose OCSF
+// implementation — only the fields tio/normalize can actually fill in
+// from a Tenable.io scan are modeled.
+package ocsf
+
+// OCSF class/category/activity identifiers for a Vulnerability Finding,
+// per the OCSF schema's Findings category.
+const (
+	CategoryUIDFindings          = 2
+	ClassUIDVulnerabilityFinding = 2002
+	ActivityIDCreate             = 1
+)
+
+// VulnerabilityFinding is an OCSF class 2002 (Vulnerability Finding)
+// record.
+type VulnerabilityFinding struct {
+	ClassUID        int             `json:"class_uid"`
+	CategoryUID     int             `json:"category_uid"`
+	ActivityID      int             `json:"activity_id"`
+	SeverityID      int             `json:"severity_id"`
+	Severity        string          `json:"severity"`
+	Time            int64           `json:"time"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+	Device          Device          `json:"device"`
+}
+
+// Vulnerability is one entry in a VulnerabilityFinding's
+// Vulnerabilities list.
+type Vulnerability struct {
+	Title      string   `json:"title"`
+	Desc       string   `json:"desc,omitempty"`
+	CVE        []string `json:"cve,omitempty"`
+	CVSSScore  float64  `json:"cvss_score,omitempty"`
+	VendorName string   `json:"vendor_name,omitempty"`
+}
+
+// Device identifies the asset a finding was observed on.
+type Device struct {
+	Hostname string `json:"hostname,omitempty"`
+	IP       string `json:"ip,omitempty"`
+	MAC      string `json:"mac,omitempty"`
+}
@@ -77,6 +77,17 @@ func (p *PluginsAPI) Get(ctx context.Context, pluginID int) (*PluginDetails, err
 	return &result, nil
 }
 
+// PluginFamilyRefreshedEvent is published on Client.Events once per
+// plugin family included in a page PluginsAPI.List fetches.
+type PluginFamilyRefreshedEvent struct {
+	FamilyID    int
+	FamilyName  string
+	PluginCount int
+}
+
+// EventType implements base.Event.
+func (PluginFamilyRefreshedEvent) EventType() string { return "plugin_family.refreshed" }
+
 // PluginListOptions contains options for listing plugins.
 type PluginListOptions struct {
 	Size        int
@@ -118,6 +129,11 @@ func (p *PluginsAPI) List(ctx context.Context, opts *PluginListOptions) *base.It
 				plugin.FamilyName = family.Name
 				plugins = append(plugins, plugin)
 			}
+			p.client.Events.Publish(PluginFamilyRefreshedEvent{
+				FamilyID:    family.ID,
+				FamilyName:  family.Name,
+				PluginCount: len(family.Plugins),
+			})
 		}
 
 		data, _ := json.Marshal(plugins)
@@ -132,5 +148,10 @@ func (p *PluginsAPI) List(ctx context.Context, opts *PluginListOptions) *base.It
 		return items, err
 	}
 
-	return base.NewIterator(ctx, fetcher, transformer)
+	var iterOpts []base.IteratorOption[Plugin]
+	if d := p.client.DefaultPageTimeout(); d > 0 {
+		iterOpts = append(iterOpts, base.WithPageTimeout[Plugin](d))
+	}
+
+	return base.NewIterator(ctx, fetcher, transformer, iterOpts...)
 }
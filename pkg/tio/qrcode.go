@@ -0,0 +1,414 @@
+package tio
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// qrVersionInfo holds the error-correction-level-L capacity figures for one
+// QR version (ISO/IEC 18004 Table 9). Only versions 1-6 are supported,
+// which is ample for an otpauth:// enrollment URI.
+type qrVersionInfo struct {
+	version         int
+	size            int
+	dataCapacity    int // byte-mode payload bytes this version can hold at level L
+	totalCodewords  int
+	ecPerBlock      int
+	numBlocks       int
+	dataPerBlock    int // data codewords in each block (blocks are equal-sized for v1-6)
+	alignmentCenter int // 0 if this version has no alignment pattern
+	remainderBits   int
+}
+
+var qrVersions = []qrVersionInfo{
+	{version: 1, size: 21, dataCapacity: 17, totalCodewords: 26, ecPerBlock: 7, numBlocks: 1, dataPerBlock: 19, alignmentCenter: 0, remainderBits: 0},
+	{version: 2, size: 25, dataCapacity: 32, totalCodewords: 44, ecPerBlock: 10, numBlocks: 1, dataPerBlock: 34, alignmentCenter: 18, remainderBits: 7},
+	{version: 3, size: 29, dataCapacity: 53, totalCodewords: 70, ecPerBlock: 15, numBlocks: 1, dataPerBlock: 55, alignmentCenter: 22, remainderBits: 7},
+	{version: 4, size: 33, dataCapacity: 78, totalCodewords: 100, ecPerBlock: 20, numBlocks: 1, dataPerBlock: 80, alignmentCenter: 26, remainderBits: 7},
+	{version: 5, size: 37, dataCapacity: 106, totalCodewords: 134, ecPerBlock: 26, numBlocks: 1, dataPerBlock: 108, alignmentCenter: 30, remainderBits: 7},
+	{version: 6, size: 41, dataCapacity: 134, totalCodewords: 172, ecPerBlock: 18, numBlocks: 2, dataPerBlock: 68, alignmentCenter: 34, remainderBits: 7},
+}
+
+// encodeQRCodePNG renders data (treated as a byte-mode QR payload) as a
+// PNG-encoded QR code at error correction level L, selecting the smallest
+// of the supported versions (1-6) that fits data. This is a from-scratch,
+// dependency-free implementation of just enough of ISO/IEC 18004 to encode
+// a TOTP otpauth:// URI: byte mode only, level L only, a fixed mask
+// pattern (0) rather than penalty-based mask selection.
+func encodeQRCodePNG(data []byte) ([]byte, error) {
+	var v *qrVersionInfo
+	for i := range qrVersions {
+		if qrVersions[i].dataCapacity >= len(data) {
+			v = &qrVersions[i]
+			break
+		}
+	}
+	if v == nil {
+		return nil, fmt.Errorf("qrcode: payload of %d bytes exceeds the largest supported version", len(data))
+	}
+
+	codewords := qrBuildCodewords(*v, data)
+	matrix := qrBuildMatrix(*v, codewords)
+	return qrRenderPNG(matrix, 4, 4)
+}
+
+// qrBuildCodewords assembles the mode/count/data bit stream, pads it to
+// the version's data capacity, then appends interleaved error-correction
+// codewords.
+func qrBuildCodewords(v qrVersionInfo, data []byte) []byte {
+	bits := newBitWriter()
+	bits.writeBits(0b0100, 4) // byte mode indicator
+	bits.writeBits(uint32(len(data)), 8)
+	for _, b := range data {
+		bits.writeBits(uint32(b), 8)
+	}
+
+	totalDataCodewords := v.numBlocks * v.dataPerBlock
+	// Terminator: up to 4 zero bits, but never past the data capacity.
+	bits.writeBits(0, minInt(4, totalDataCodewords*8-bits.len()))
+	bits.padToByte()
+
+	padBytes := [2]byte{0xEC, 0x11}
+	for i := 0; bits.len()/8 < totalDataCodewords; i++ {
+		bits.writeBits(uint32(padBytes[i%2]), 8)
+	}
+
+	dataCodewords := bits.bytes()[:totalDataCodewords]
+
+	blocks := make([][]byte, v.numBlocks)
+	ecBlocks := make([][]byte, v.numBlocks)
+	for i := 0; i < v.numBlocks; i++ {
+		blocks[i] = dataCodewords[i*v.dataPerBlock : (i+1)*v.dataPerBlock]
+		ecBlocks[i] = reedSolomonEncode(blocks[i], v.ecPerBlock)
+	}
+
+	var out []byte
+	for i := 0; i < v.dataPerBlock; i++ {
+		for b := 0; b < v.numBlocks; b++ {
+			out = append(out, blocks[b][i])
+		}
+	}
+	for i := 0; i < v.ecPerBlock; i++ {
+		for b := 0; b < v.numBlocks; b++ {
+			out = append(out, ecBlocks[b][i])
+		}
+	}
+	return out
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// bitWriter accumulates bits MSB-first into whole bytes.
+type bitWriter struct {
+	buf      []byte
+	bitCount int
+}
+
+func newBitWriter() *bitWriter { return &bitWriter{} }
+
+func (w *bitWriter) writeBits(value uint32, n int) {
+	for i := n - 1; i >= 0; i-- {
+		bit := byte((value >> uint(i)) & 1)
+		if w.bitCount%8 == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		w.buf[len(w.buf)-1] |= bit << uint(7-w.bitCount%8)
+		w.bitCount++
+	}
+}
+
+func (w *bitWriter) padToByte() {
+	for w.bitCount%8 != 0 {
+		w.writeBits(0, 1)
+	}
+}
+
+func (w *bitWriter) len() int      { return w.bitCount }
+func (w *bitWriter) bytes() []byte { return w.buf }
+
+// qrModule is one cell of the QR matrix.
+type qrModule struct {
+	black      bool
+	isFunction bool
+}
+
+// qrBuildMatrix places function patterns, data/ec codewords (zigzagged and
+// masked with the fixed mask pattern 0), and format information into a
+// version v matrix.
+func qrBuildMatrix(v qrVersionInfo, codewords []byte) [][]qrModule {
+	size := v.size
+	m := make([][]qrModule, size)
+	for i := range m {
+		m[i] = make([]qrModule, size)
+	}
+
+	placeFinder := func(row, col int) {
+		for r := -1; r <= 7; r++ {
+			for c := -1; c <= 7; c++ {
+				rr, cc := row+r, col+c
+				if rr < 0 || rr >= size || cc < 0 || cc >= size {
+					continue
+				}
+				black := false
+				if r >= 0 && r <= 6 && c >= 0 && c <= 6 {
+					if r == 0 || r == 6 || c == 0 || c == 6 {
+						black = true
+					} else if r >= 2 && r <= 4 && c >= 2 && c <= 4 {
+						black = true
+					}
+				}
+				m[rr][cc] = qrModule{black: black, isFunction: true}
+			}
+		}
+	}
+	placeFinder(0, 0)
+	placeFinder(0, size-7)
+	placeFinder(size-7, 0)
+
+	// Timing patterns.
+	for i := 8; i < size-8; i++ {
+		m[6][i] = qrModule{black: i%2 == 0, isFunction: true}
+		m[i][6] = qrModule{black: i%2 == 0, isFunction: true}
+	}
+
+	// Alignment pattern (versions 2-6 have exactly one, away from the finders).
+	if v.alignmentCenter != 0 {
+		center := v.alignmentCenter
+		for r := -2; r <= 2; r++ {
+			for c := -2; c <= 2; c++ {
+				black := r == -2 || r == 2 || c == -2 || c == 2 || (r == 0 && c == 0)
+				m[center+r][center+c] = qrModule{black: black, isFunction: true}
+			}
+		}
+	}
+
+	// Dark module, always black.
+	m[4*v.version+9][8] = qrModule{black: true, isFunction: true}
+
+	// Reserve format info areas (filled in below).
+	for i := 0; i < 9; i++ {
+		if i != 6 {
+			m[8][i].isFunction = true
+			m[i][8].isFunction = true
+		}
+	}
+	for i := 0; i < 8; i++ {
+		m[8][size-1-i].isFunction = true
+		m[size-1-i][8].isFunction = true
+	}
+
+	qrPlaceData(m, codewords)
+	qrApplyMask(m)
+	qrPlaceFormatInfo(m, size)
+
+	return m
+}
+
+// qrPlaceData zigzags codewords, two columns at a time from the
+// bottom-right, skipping the timing column and any function module.
+func qrPlaceData(m [][]qrModule, codewords []byte) {
+	size := len(m)
+	bitIndex := 0
+	totalBits := len(codewords) * 8
+	nextBit := func() bool {
+		if bitIndex >= totalBits {
+			return false
+		}
+		b := codewords[bitIndex/8]
+		bit := (b>>uint(7-bitIndex%8))&1 == 1
+		bitIndex++
+		return bit
+	}
+
+	col := size - 1
+	upward := true
+	for col > 0 {
+		if col == 6 {
+			col--
+		}
+		rows := make([]int, size)
+		for i := range rows {
+			if upward {
+				rows[i] = size - 1 - i
+			} else {
+				rows[i] = i
+			}
+		}
+		for _, row := range rows {
+			for _, c := range []int{col, col - 1} {
+				if m[row][c].isFunction {
+					continue
+				}
+				m[row][c] = qrModule{black: nextBit(), isFunction: false}
+			}
+		}
+		upward = !upward
+		col -= 2
+	}
+}
+
+// qrApplyMask XORs mask pattern 0 ((row+col)%2==0) over every non-function
+// module, per ISO/IEC 18004 section 7.8.
+func qrApplyMask(m [][]qrModule) {
+	for row := range m {
+		for col := range m[row] {
+			if m[row][col].isFunction {
+				continue
+			}
+			if (row+col)%2 == 0 {
+				m[row][col].black = !m[row][col].black
+			}
+		}
+	}
+}
+
+// qrFormatBits returns the 15-bit format information codeword for error
+// correction level L (bits 01) and mask pattern 0, computed via the
+// BCH(15,5) code and XOR mask specified in ISO/IEC 18004 Annex C.
+func qrFormatBits() uint32 {
+	const data uint32 = 0b01000 // level L (01) + mask pattern (000)
+	value := data << 10
+	generator := uint32(0b10100110111)
+	for i := 4; i >= 0; i-- {
+		if value&(1<<uint(i+10)) != 0 {
+			value ^= generator << uint(i)
+		}
+	}
+	format := (data << 10) | value
+	return format ^ 0b101010000010010
+}
+
+func qrPlaceFormatInfo(m [][]qrModule, size int) {
+	bits := qrFormatBits()
+	bit := func(i int) bool { return (bits>>uint(i))&1 == 1 }
+
+	// Top-left copy.
+	for i := 0; i <= 5; i++ {
+		m[i][8] = qrModule{black: bit(i), isFunction: true}
+	}
+	m[7][8] = qrModule{black: bit(6), isFunction: true}
+	m[8][8] = qrModule{black: bit(7), isFunction: true}
+	m[8][7] = qrModule{black: bit(8), isFunction: true}
+	for i := 9; i <= 14; i++ {
+		m[8][14-i] = qrModule{black: bit(i), isFunction: true}
+	}
+
+	// Top-right / bottom-left copy.
+	for i := 0; i <= 7; i++ {
+		m[8][size-1-i] = qrModule{black: bit(i), isFunction: true}
+	}
+	for i := 8; i <= 14; i++ {
+		m[size-15+i][8] = qrModule{black: bit(i), isFunction: true}
+	}
+}
+
+// qrRenderPNG rasterizes m at scale pixels per module with a quiet zone of
+// quietModules modules on every side, and PNG-encodes the result.
+func qrRenderPNG(m [][]qrModule, scale, quietModules int) ([]byte, error) {
+	size := len(m)
+	dim := (size + 2*quietModules) * scale
+
+	img := image.NewGray(image.Rect(0, 0, dim, dim))
+	for y := 0; y < dim; y++ {
+		for x := 0; x < dim; x++ {
+			img.SetGray(x, y, color.Gray{Y: 255})
+		}
+	}
+	for row := range m {
+		for col := range m[row] {
+			if !m[row][col].black {
+				continue
+			}
+			x0 := (col + quietModules) * scale
+			y0 := (row + quietModules) * scale
+			for y := y0; y < y0+scale; y++ {
+				for x := x0; x < x0+scale; x++ {
+					img.SetGray(x, y, color.Gray{Y: 0})
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("qrcode: encode png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// gfExp and gfLog are GF(256) exponent/log tables for the QR code field,
+// generated from primitive polynomial x^8+x^4+x^3+x^2+1 (0x11D) with
+// generator 2.
+var (
+	gfExp [512]byte
+	gfLog [256]byte
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		gfExp[i] = byte(x)
+		gfLog[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11D
+		}
+	}
+	for i := 255; i < 512; i++ {
+		gfExp[i] = gfExp[i-255]
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+// rsGeneratorPoly returns the Reed-Solomon generator polynomial for n
+// error-correction codewords, as coefficients from highest to lowest
+// degree (poly[0] is always 1, the leading coefficient of x^n).
+func rsGeneratorPoly(n int) []byte {
+	poly := []byte{1}
+	for i := 0; i < n; i++ {
+		next := make([]byte, len(poly)+1)
+		root := gfExp[i]
+		for j, coeff := range poly {
+			next[j] ^= coeff
+			next[j+1] ^= gfMul(coeff, root)
+		}
+		poly = next
+	}
+	return poly
+}
+
+// reedSolomonEncode returns the ecCount error-correction codewords for
+// data, computed via polynomial long division in GF(256) against the
+// Reed-Solomon generator polynomial, as QR codes require.
+func reedSolomonEncode(data []byte, ecCount int) []byte {
+	generator := rsGeneratorPoly(ecCount)
+
+	remainder := make([]byte, len(data)+ecCount)
+	copy(remainder, data)
+
+	for i := 0; i < len(data); i++ {
+		coeff := remainder[i]
+		if coeff == 0 {
+			continue
+		}
+		for j, g := range generator {
+			remainder[i+j] ^= gfMul(g, coeff)
+		}
+	}
+
+	return remainder[len(data):]
+}
@@ -0,0 +1,218 @@
+package tio
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/base"
+)
+
+// TokenBucketLimiter is the default base.RateLimiter: it permits burst
+// requests immediately and refills at rps tokens per second thereafter.
+// A throttled OnResult drains the bucket and holds it empty for the
+// server-reported retryAfter (or one second, lacking one), so a burst of
+// already-queued callers doesn't immediately retrigger the same 429. It
+// also implements base.TokenReporter, so installing one via WithRateLimit
+// makes base.Metrics.OnTokensAvailable fire.
+type TokenBucketLimiter struct {
+	mu           sync.Mutex
+	rps          float64
+	burst        float64
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+}
+
+// NewTokenBucketLimiter creates a token bucket limiter allowing rps
+// requests per second with the given burst capacity.
+func NewTokenBucketLimiter(rps float64, burst int) *TokenBucketLimiter {
+	if burst <= 0 {
+		burst = 1
+	}
+	return &TokenBucketLimiter{
+		rps:    rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (l *TokenBucketLimiter) Wait(ctx context.Context) error {
+	for {
+		wait := l.reserve()
+		if wait <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve consumes a token if available and returns zero, or returns the
+// duration the caller must wait for one to become available.
+func (l *TokenBucketLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.refillLocked(now)
+
+	if now.Before(l.blockedUntil) {
+		return l.blockedUntil.Sub(now)
+	}
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	if l.rps <= 0 {
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rps * float64(time.Second))
+}
+
+// refillLocked adds tokens for the time elapsed since the last refill.
+// Callers must hold l.mu.
+func (l *TokenBucketLimiter) refillLocked(now time.Time) {
+	elapsed := now.Sub(l.last)
+	l.last = now
+	if l.rps <= 0 {
+		return
+	}
+	l.tokens += elapsed.Seconds() * l.rps
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+// OnResult drains the bucket and holds it empty for retryAfter (or one
+// second, lacking one) when throttled, so a burst of already-queued
+// callers doesn't immediately retrigger the same 429. A non-throttled
+// result is a no-op.
+func (l *TokenBucketLimiter) OnResult(throttled bool, retryAfter time.Duration) {
+	if !throttled {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = 0
+	wait := retryAfter
+	if wait <= 0 {
+		wait = time.Second
+	}
+	l.blockedUntil = time.Now().Add(wait)
+}
+
+// Tokens returns the number of requests currently admissible immediately,
+// rounded down. base.Metrics.OnTokensAvailable reports this after each
+// request, via the base.TokenReporter interface.
+func (l *TokenBucketLimiter) Tokens() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refillLocked(time.Now())
+	return int(l.tokens)
+}
+
+// AdaptiveLimiter wraps a TokenBucketLimiter with AIMD behavior: it halves
+// its rate whenever a request comes back throttled, and ramps the rate
+// back up by a fixed increment after a run of consecutive successes.
+type AdaptiveLimiter struct {
+	bucket        *TokenBucketLimiter
+	minRPS        float64
+	maxRPS        float64
+	increment     float64
+	successStreak int
+	rampEverySucc int
+}
+
+// NewAdaptiveLimiter creates an AIMD rate limiter that starts at
+// initialRPS and stays within [minRPS, maxRPS].
+func NewAdaptiveLimiter(initialRPS, minRPS, maxRPS float64, burst int) *AdaptiveLimiter {
+	if minRPS <= 0 {
+		minRPS = 1
+	}
+	if maxRPS < minRPS {
+		maxRPS = minRPS
+	}
+	return &AdaptiveLimiter{
+		bucket:        NewTokenBucketLimiter(initialRPS, burst),
+		minRPS:        minRPS,
+		maxRPS:        maxRPS,
+		increment:     minRPS,
+		rampEverySucc: 20,
+	}
+}
+
+// Wait delegates to the underlying token bucket.
+func (l *AdaptiveLimiter) Wait(ctx context.Context) error {
+	return l.bucket.Wait(ctx)
+}
+
+// OnResult halves the rate on a throttled response (floored at minRPS) or,
+// after a streak of successes, ramps the rate back up by one increment
+// (capped at maxRPS).
+func (l *AdaptiveLimiter) OnResult(throttled bool, retryAfter time.Duration) {
+	l.bucket.mu.Lock()
+	defer l.bucket.mu.Unlock()
+
+	if throttled {
+		l.successStreak = 0
+		l.bucket.rps /= 2
+		if l.bucket.rps < l.minRPS {
+			l.bucket.rps = l.minRPS
+		}
+		return
+	}
+
+	l.successStreak++
+	if l.successStreak >= l.rampEverySucc {
+		l.successStreak = 0
+		l.bucket.rps += l.increment
+		if l.bucket.rps > l.maxRPS {
+			l.bucket.rps = l.maxRPS
+		}
+	}
+}
+
+// WithRateLimit configures a fixed-rate token bucket limiter on the
+// client, allowing rps requests per second with the given burst capacity.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(o *options) {
+		o.baseOpts = append(o.baseOpts, base.WithRateLimiter(NewTokenBucketLimiter(rps, burst)))
+	}
+}
+
+// WithAdaptiveRateLimit configures an AIMD rate limiter that starts at
+// initialRPS, halves on throttling, and ramps back up toward maxRPS on
+// sustained success, never dropping below minRPS.
+func WithAdaptiveRateLimit(initialRPS, minRPS, maxRPS float64, burst int) Option {
+	return func(o *options) {
+		o.baseOpts = append(o.baseOpts, base.WithRateLimiter(NewAdaptiveLimiter(initialRPS, minRPS, maxRPS, burst)))
+	}
+}
+
+// WithMaxConcurrency bounds the number of in-flight requests via a
+// weighted semaphore, so pagination fan-out and bulk operations (e.g.
+// AgentsAPI.BulkUnlink, large AgentsAPI.List scans) can't overwhelm the
+// tenant's request budget.
+func WithMaxConcurrency(n int) Option {
+	return func(o *options) {
+		o.baseOpts = append(o.baseOpts, base.WithMaxConcurrency(n))
+	}
+}
+
+// WithMetrics installs observability hooks (OnRequest, OnThrottle,
+// OnRetry) invoked around each request.
+func WithMetrics(m base.Metrics) Option {
+	return func(o *options) {
+		o.baseOpts = append(o.baseOpts, base.WithMetrics(m))
+	}
+}
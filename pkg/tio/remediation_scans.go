@@ -86,9 +86,23 @@ func (r *RemediationScansAPI) List(ctx context.Context) *base.Iterator[Remediati
 		return items, err
 	}
 
-	return base.NewIterator(ctx, fetcher, transformer)
+	var iterOpts []base.IteratorOption[RemediationScan]
+	if d := r.client.DefaultPageTimeout(); d > 0 {
+		iterOpts = append(iterOpts, base.WithPageTimeout[RemediationScan](d))
+	}
+
+	return base.NewIterator(ctx, fetcher, transformer, iterOpts...)
 }
 
+// RemediationScanCreatedEvent is published on Client.Events after
+// RemediationScansAPI.Create successfully creates a scan.
+type RemediationScanCreatedEvent struct {
+	Scan RemediationScan
+}
+
+// EventType implements base.Event.
+func (RemediationScanCreatedEvent) EventType() string { return "remediation_scan.created" }
+
 // Create creates a new remediation scan.
 func (r *RemediationScansAPI) Create(ctx context.Context, req *RemediationScanCreateRequest) (*RemediationScan, error) {
 	var result RemediationScan
@@ -96,6 +110,7 @@ func (r *RemediationScansAPI) Create(ctx context.Context, req *RemediationScanCr
 	if err != nil {
 		return nil, err
 	}
+	r.client.Events.Publish(RemediationScanCreatedEvent{Scan: result})
 	return &result, nil
 }
 
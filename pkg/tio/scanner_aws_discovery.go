@@ -0,0 +1,190 @@
+package tio
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// AWSInstance is the subset of an EC2 instance's description
+// DiscoverAWSScanners needs to recognize a Nessus scanner and report
+// drift against Tenable's registered scanner list.
+type AWSInstance struct {
+	InstanceID       string
+	State            string
+	VPCID            string
+	AvailabilityZone string
+	Tags             map[string]string
+}
+
+// AWSClient is the subset of an EC2 client DiscoverAWSScanners needs.
+// It's an interface, rather than a direct dependency on aws-sdk-go, so
+// this package doesn't force that (large) dependency on callers who don't
+// use AWS discovery; wrap whichever SDK you use (aws-sdk-go-v2, a custom
+// HTTP client, or a test double) to satisfy it.
+type AWSClient interface {
+	// DescribeInstances returns every EC2 instance matching the given tag
+	// filters (e.g. {"Role": "nessus-scanner"}).
+	DescribeInstances(ctx context.Context, tagFilters map[string]string) ([]AWSInstance, error)
+}
+
+// DiscoveryOptions configures DiscoverAWSScanners.
+type DiscoveryOptions struct {
+	// TagFilters selects which EC2 instances are considered candidate
+	// scanners, e.g. {"Role": "nessus-scanner"}.
+	TagFilters map[string]string
+	// NameTagKey is the EC2 tag key DiscoverAWSScanners reads to match an
+	// instance against a registered Scanner by name. Defaults to "Name".
+	NameTagKey string
+}
+
+func (o DiscoveryOptions) withDefaults() DiscoveryOptions {
+	if o.NameTagKey == "" {
+		o.NameTagKey = "Name"
+	}
+	return o
+}
+
+// DiscoveredScanner pairs an EC2 instance tagged as a Nessus scanner with
+// the Tenable-registered Scanner it matches, if any.
+type DiscoveredScanner struct {
+	Instance AWSInstance
+	// Scanner is the matching registered scanner, or nil if the instance
+	// is running but hasn't (or no longer) registered with Tenable.
+	Scanner *Scanner
+}
+
+// AWSScannerDrift reports registered scanners with no corresponding
+// running instance, and running instances with no corresponding
+// registered scanner.
+type AWSScannerDrift struct {
+	// Terminated is registered scanners whose EC2 instance is gone or no
+	// longer running.
+	Terminated []Scanner
+	// Unregistered is running, scanner-tagged EC2 instances that haven't
+	// registered with Tenable.
+	Unregistered []AWSInstance
+}
+
+// DiscoverAWSScanners queries aws for EC2 instances matching
+// opts.TagFilters, cross-references them against the Tenable-registered
+// scanner list by matching each instance's InstanceID to
+// ScannerDetails.AWSInstanceID (or, failing that, its opts.NameTagKey tag
+// to the registered Scanner's Name), and returns the matched pairs alongside the drift between the two sets.
+func (s *ScannersAPI) DiscoverAWSScanners(ctx context.Context, aws AWSClient, opts DiscoveryOptions) ([]DiscoveredScanner, *AWSScannerDrift, error) {
+	opts = opts.withDefaults()
+
+	instances, err := aws.DescribeInstances(ctx, opts.TagFilters)
+	if err != nil {
+		return nil, nil, fmt.Errorf("discover aws scanners: describe instances: %w", err)
+	}
+
+	registered, err := s.List(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("discover aws scanners: list scanners: %w", err)
+	}
+
+	byInstanceID := make(map[string]*Scanner, len(registered))
+	byName := make(map[string]*Scanner, len(registered))
+	for i := range registered {
+		byName[registered[i].Name] = &registered[i]
+		details, err := s.Get(ctx, registered[i].ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("discover aws scanners: get scanner %d: %w", registered[i].ID, err)
+		}
+		if details.AWSInstanceID != "" {
+			byInstanceID[details.AWSInstanceID] = &registered[i]
+		}
+	}
+
+	var discovered []DiscoveredScanner
+	matchedInstanceIDs := make(map[string]bool, len(instances))
+	for _, inst := range instances {
+		d := DiscoveredScanner{Instance: inst}
+		if scanner, ok := byInstanceID[inst.InstanceID]; ok {
+			d.Scanner = scanner
+			matchedInstanceIDs[inst.InstanceID] = true
+		} else if scanner, ok := byName[inst.Tags[opts.NameTagKey]]; ok && inst.Tags[opts.NameTagKey] != "" {
+			d.Scanner = scanner
+			matchedInstanceIDs[inst.InstanceID] = true
+		}
+		discovered = append(discovered, d)
+	}
+
+	drift := &AWSScannerDrift{}
+	for instanceID, scanner := range byInstanceID {
+		running := false
+		for _, inst := range instances {
+			if inst.InstanceID == instanceID && inst.State == "running" {
+				running = true
+				break
+			}
+		}
+		if !running {
+			drift.Terminated = append(drift.Terminated, *scanner)
+		}
+	}
+	for _, inst := range instances {
+		if inst.State == "running" && !matchedInstanceIDs[inst.InstanceID] {
+			drift.Unregistered = append(drift.Unregistered, inst)
+		}
+	}
+
+	return discovered, drift, nil
+}
+
+// AutoRefreshAWSTargets periodically calls GetAWSTargets for scannerID and
+// pushes any change back via Edit, so a scanner tracking an autoscaling
+// group's ever-changing instance list stays current without an operator
+// manually editing its target list. It blocks until ctx is canceled or a
+// refresh fails, and returns that error.
+func (s *ScannersAPI) AutoRefreshAWSTargets(ctx context.Context, scannerID int, interval time.Duration) error {
+	var lastTargets []string
+
+	refresh := func() error {
+		targets, err := s.GetAWSTargets(ctx, scannerID)
+		if err != nil {
+			return fmt.Errorf("auto refresh aws targets: %w", err)
+		}
+		if stringSlicesEqual(targets, lastTargets) {
+			return nil
+		}
+		if err := s.Edit(ctx, scannerID, map[string]interface{}{"aws_targets": targets}); err != nil {
+			return fmt.Errorf("auto refresh aws targets: %w", err)
+		}
+		lastTargets = targets
+		return nil
+	}
+
+	if err := refresh(); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			if err := refresh(); err != nil {
+				return err
+			}
+			timer.Reset(interval)
+		}
+	}
+}
+
+// stringSlicesEqual reports whether a and b contain the same elements in
+// the same order.
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
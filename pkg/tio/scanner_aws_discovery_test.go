@@ -0,0 +1,134 @@
+package tio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type fakeAWSClient struct {
+	instances []AWSInstance
+}
+
+func (c *fakeAWSClient) DescribeInstances(ctx context.Context, tagFilters map[string]string) ([]AWSInstance, error) {
+	return c.instances, nil
+}
+
+// awsDiscoveryFixtureTransport serves one registered scanner backed by
+// EC2 instance "i-registered".
+func awsDiscoveryFixtureTransport() http.RoundTripper {
+	return roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		var body interface{}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/scanners":
+			body = map[string]interface{}{
+				"scanners": []Scanner{{ID: 1, Name: "aws-scanner-1"}},
+			}
+		case r.Method == http.MethodGet && r.URL.Path == "/scanners/1":
+			body = ScannerDetails{
+				Scanner:       Scanner{ID: 1, Name: "aws-scanner-1"},
+				AWSInstanceID: "i-registered",
+			}
+		default:
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewReader(nil)), Header: http.Header{}}, nil
+		}
+
+		encoded, _ := json.Marshal(body)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(bytes.NewReader(encoded)),
+		}, nil
+	})
+}
+
+func TestDiscoverAWSScannersReportsDrift(t *testing.T) {
+	client, err := New(
+		WithAPIKeys("access", "secret"),
+		WithHTTPTransport(awsDiscoveryFixtureTransport()),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	aws := &fakeAWSClient{instances: []AWSInstance{
+		{InstanceID: "i-registered", State: "running"},
+		{InstanceID: "i-unregistered", State: "running"},
+	}}
+
+	discovered, drift, err := client.Scanners.DiscoverAWSScanners(context.Background(), aws, DiscoveryOptions{})
+	if err != nil {
+		t.Fatalf("DiscoverAWSScanners() error = %v", err)
+	}
+
+	if len(discovered) != 2 {
+		t.Fatalf("len(discovered) = %d, want 2", len(discovered))
+	}
+	for _, d := range discovered {
+		if d.Instance.InstanceID == "i-registered" && d.Scanner == nil {
+			t.Errorf("expected i-registered to match a Scanner")
+		}
+		if d.Instance.InstanceID == "i-unregistered" && d.Scanner != nil {
+			t.Errorf("expected i-unregistered to have no matching Scanner")
+		}
+	}
+
+	if len(drift.Terminated) != 0 {
+		t.Errorf("Terminated = %+v, want none (registered instance is running)", drift.Terminated)
+	}
+	if len(drift.Unregistered) != 1 || drift.Unregistered[0].InstanceID != "i-unregistered" {
+		t.Errorf("Unregistered = %+v, want [i-unregistered]", drift.Unregistered)
+	}
+}
+
+func TestDiscoverAWSScannersReportsTerminatedRegisteredScanner(t *testing.T) {
+	client, err := New(
+		WithAPIKeys("access", "secret"),
+		WithHTTPTransport(awsDiscoveryFixtureTransport()),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// The registered scanner's instance is absent entirely from the
+	// describe-instances response, as if it had been terminated.
+	aws := &fakeAWSClient{}
+
+	_, drift, err := client.Scanners.DiscoverAWSScanners(context.Background(), aws, DiscoveryOptions{})
+	if err != nil {
+		t.Fatalf("DiscoverAWSScanners() error = %v", err)
+	}
+
+	if len(drift.Terminated) != 1 || drift.Terminated[0].Name != "aws-scanner-1" {
+		t.Errorf("Terminated = %+v, want [aws-scanner-1]", drift.Terminated)
+	}
+}
+
+func TestAutoRefreshAWSTargetsStopsOnContextCancel(t *testing.T) {
+	client, err := New(
+		WithAPIKeys("access", "secret"),
+		WithHTTPTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			body, _ := json.Marshal(map[string]interface{}{"targets": []string{"10.0.0.1"}})
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		})),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err = client.Scanners.AutoRefreshAWSTargets(ctx, 1, 5*time.Millisecond)
+	if err != context.DeadlineExceeded {
+		t.Errorf("AutoRefreshAWSTargets() error = %v, want context.DeadlineExceeded", err)
+	}
+}
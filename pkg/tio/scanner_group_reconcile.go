@@ -0,0 +1,414 @@
+package tio
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/base"
+)
+
+// GroupSpec declares the desired state of a single scanner group: its
+// name, type, the scanners that should be members (by name), and the
+// routes it should have.
+type GroupSpec struct {
+	Name     string
+	Type     string
+	Scanners []string
+	Routes   []string
+}
+
+// ReconcileActionType identifies the kind of change Reconcile plans or
+// applies against a scanner group.
+type ReconcileActionType string
+
+const (
+	ActionCreateGroup   ReconcileActionType = "create_group"
+	ActionDeleteGroup   ReconcileActionType = "delete_group"
+	ActionAddScanner    ReconcileActionType = "add_scanner"
+	ActionRemoveScanner ReconcileActionType = "remove_scanner"
+	ActionAddRoute      ReconcileActionType = "add_route"
+	ActionDeleteRoute   ReconcileActionType = "delete_route"
+)
+
+// ReconcileAction is a single change Reconcile plans to make, or has
+// made, to bring a scanner group's live state in line with its GroupSpec.
+type ReconcileAction struct {
+	Type        ReconcileActionType
+	GroupName   string
+	GroupID     int
+	GroupType   string
+	ScannerName string
+	Route       string
+	// Err is set on an applied action that failed. It's always nil on
+	// planned (not-yet-applied) actions.
+	Err error
+}
+
+// ReconcileReport summarizes a Reconcile run: everything that was
+// planned, and — unless DryRun was set — everything that was actually
+// applied, each with its outcome.
+type ReconcileReport struct {
+	PlannedActions []ReconcileAction
+	AppliedActions []ReconcileAction
+}
+
+// Failed returns the applied actions that errored.
+func (r *ReconcileReport) Failed() []ReconcileAction {
+	var failed []ReconcileAction
+	for _, a := range r.AppliedActions {
+		if a.Err != nil {
+			failed = append(failed, a)
+		}
+	}
+	return failed
+}
+
+// ReconcileOptions configures Reconcile.
+type ReconcileOptions struct {
+	// Concurrency bounds how many actions run at once within a given
+	// dependency phase. Defaults to 4.
+	Concurrency int
+	// Retry governs how many times, and with what backoff, a single
+	// action is retried before it's reported as failed. Defaults to
+	// base.DefaultRetryPolicy.
+	Retry base.RetryPolicy
+	// DryRun, when true, only populates ReconcileReport.PlannedActions;
+	// nothing is applied.
+	DryRun bool
+}
+
+func (o ReconcileOptions) withDefaults() ReconcileOptions {
+	if o.Concurrency <= 0 {
+		o.Concurrency = 4
+	}
+	if o.Retry.MaxAttempts <= 0 {
+		o.Retry = base.DefaultRetryPolicy
+	}
+	return o
+}
+
+// Reconcile brings the live scanner groups in line with desired: groups
+// present in desired but missing live are created, groups present live
+// but absent from desired are deleted, and matching groups have their
+// scanner membership and routes diffed and patched up to match. Actions
+// are applied in dependency order — a group is created before anything
+// is added to it, and deleted only after its membership/route changes —
+// with each phase's actions run concurrently across a worker pool bounded
+// by opts.Concurrency, each retried independently under opts.Retry.
+// Setting opts.DryRun computes and returns the plan without applying it,
+// so operators can preview changes before committing to them.
+func (g *ScannerGroupsAPI) Reconcile(ctx context.Context, desired []GroupSpec, opts ReconcileOptions) (*ReconcileReport, error) {
+	opts = opts.withDefaults()
+
+	plan, err := g.plan(ctx, desired)
+	if err != nil {
+		return nil, fmt.Errorf("reconcile scanner groups: %w", err)
+	}
+
+	report := &ReconcileReport{PlannedActions: plan}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	var creates, mutations, deletes []ReconcileAction
+	for _, a := range plan {
+		switch a.Type {
+		case ActionCreateGroup:
+			creates = append(creates, a)
+		case ActionDeleteGroup:
+			deletes = append(deletes, a)
+		default:
+			mutations = append(mutations, a)
+		}
+	}
+
+	applied := g.applyPhase(ctx, creates, opts)
+	report.AppliedActions = append(report.AppliedActions, applied...)
+
+	// Creations resolve a group's ID; later phases reference groups
+	// created this run by name, so patch those IDs in before mutating.
+	created := map[string]int{}
+	for _, a := range applied {
+		if a.Type == ActionCreateGroup && a.Err == nil {
+			created[a.GroupName] = a.GroupID
+		}
+	}
+	for i := range mutations {
+		if mutations[i].GroupID == 0 {
+			if id, ok := created[mutations[i].GroupName]; ok {
+				mutations[i].GroupID = id
+			}
+		}
+	}
+
+	applied = g.applyPhase(ctx, mutations, opts)
+	report.AppliedActions = append(report.AppliedActions, applied...)
+
+	applied = g.applyPhase(ctx, deletes, opts)
+	report.AppliedActions = append(report.AppliedActions, applied...)
+
+	return report, nil
+}
+
+// plan diffs desired against live state and returns the actions needed
+// to reconcile them, in dependency order (creates, then membership/route
+// mutations, then deletes).
+func (g *ScannerGroupsAPI) plan(ctx context.Context, desired []GroupSpec) ([]ReconcileAction, error) {
+	liveGroups, err := g.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list scanner groups: %w", err)
+	}
+	liveByName := make(map[string]ScannerGroup, len(liveGroups))
+	for _, grp := range liveGroups {
+		liveByName[grp.Name] = grp
+	}
+
+	desiredByName := make(map[string]GroupSpec, len(desired))
+	for _, spec := range desired {
+		desiredByName[spec.Name] = spec
+	}
+
+	var creates, mutations, deletes []ReconcileAction
+
+	for _, spec := range desired {
+		live, exists := liveByName[spec.Name]
+		if !exists {
+			creates = append(creates, ReconcileAction{Type: ActionCreateGroup, GroupName: spec.Name, GroupType: spec.Type})
+			// A freshly planned group has no live members or routes yet,
+			// so every desired scanner/route becomes an add.
+			for _, scanner := range spec.Scanners {
+				mutations = append(mutations, ReconcileAction{Type: ActionAddScanner, GroupName: spec.Name, ScannerName: scanner})
+			}
+			for _, route := range spec.Routes {
+				mutations = append(mutations, ReconcileAction{Type: ActionAddRoute, GroupName: spec.Name, Route: route})
+			}
+			continue
+		}
+
+		scannerActions, err := g.diffScanners(ctx, live, spec)
+		if err != nil {
+			return nil, err
+		}
+		mutations = append(mutations, scannerActions...)
+
+		routeActions, err := g.diffRoutes(ctx, live, spec)
+		if err != nil {
+			return nil, err
+		}
+		mutations = append(mutations, routeActions...)
+	}
+
+	for _, live := range liveGroups {
+		if _, wanted := desiredByName[live.Name]; !wanted {
+			deletes = append(deletes, ReconcileAction{Type: ActionDeleteGroup, GroupName: live.Name, GroupID: live.ID})
+		}
+	}
+
+	plan := make([]ReconcileAction, 0, len(creates)+len(mutations)+len(deletes))
+	plan = append(plan, creates...)
+	plan = append(plan, mutations...)
+	plan = append(plan, deletes...)
+	return plan, nil
+}
+
+func (g *ScannerGroupsAPI) diffScanners(ctx context.Context, live ScannerGroup, spec GroupSpec) ([]ReconcileAction, error) {
+	liveScanners, err := g.ListScanners(ctx, live.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list scanners for group %q: %w", live.Name, err)
+	}
+
+	liveByName := make(map[string]Scanner, len(liveScanners))
+	for _, sc := range liveScanners {
+		liveByName[sc.Name] = sc
+	}
+	desiredSet := make(map[string]bool, len(spec.Scanners))
+	for _, name := range spec.Scanners {
+		desiredSet[name] = true
+	}
+
+	var actions []ReconcileAction
+	for _, name := range spec.Scanners {
+		if _, ok := liveByName[name]; !ok {
+			actions = append(actions, ReconcileAction{Type: ActionAddScanner, GroupName: live.Name, GroupID: live.ID, ScannerName: name})
+		}
+	}
+	for name, sc := range liveByName {
+		if !desiredSet[name] {
+			actions = append(actions, ReconcileAction{Type: ActionRemoveScanner, GroupName: live.Name, GroupID: live.ID, ScannerName: fmt.Sprintf("%d", sc.ID)})
+		}
+	}
+	return actions, nil
+}
+
+func (g *ScannerGroupsAPI) diffRoutes(ctx context.Context, live ScannerGroup, spec GroupSpec) ([]ReconcileAction, error) {
+	liveRoutes, err := g.ListRoutes(ctx, live.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list routes for group %q: %w", live.Name, err)
+	}
+
+	liveSet := make(map[string]bool, len(liveRoutes))
+	for _, r := range liveRoutes {
+		liveSet[r.Route] = true
+	}
+	desiredSet := make(map[string]bool, len(spec.Routes))
+	for _, r := range spec.Routes {
+		desiredSet[r] = true
+	}
+
+	var actions []ReconcileAction
+	for _, r := range spec.Routes {
+		if !liveSet[r] {
+			actions = append(actions, ReconcileAction{Type: ActionAddRoute, GroupName: live.Name, GroupID: live.ID, Route: r})
+		}
+	}
+	for r := range liveSet {
+		if !desiredSet[r] {
+			actions = append(actions, ReconcileAction{Type: ActionDeleteRoute, GroupName: live.Name, GroupID: live.ID, Route: r})
+		}
+	}
+	return actions, nil
+}
+
+// applyPhase runs actions concurrently across a worker pool bounded by
+// opts.Concurrency, retrying each independently under opts.Retry, and
+// returns the applied actions with their outcomes (Err set on failure).
+// Actions within a phase are assumed independent of one another; ordering
+// between phases is the caller's responsibility.
+func (g *ScannerGroupsAPI) applyPhase(ctx context.Context, actions []ReconcileAction, opts ReconcileOptions) []ReconcileAction {
+	if len(actions) == 0 {
+		return nil
+	}
+
+	jobs := make(chan ReconcileAction)
+	go func() {
+		defer close(jobs)
+		for _, a := range actions {
+			select {
+			case jobs <- a:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var results []ReconcileAction
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	wg.Add(opts.Concurrency)
+	for i := 0; i < opts.Concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for a := range jobs {
+				applied := g.applyAction(ctx, a, opts.Retry)
+				mu.Lock()
+				results = append(results, applied)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// applyAction applies a single ReconcileAction, retrying it under retry,
+// and returns the action with Err set if every attempt failed.
+func (g *ScannerGroupsAPI) applyAction(ctx context.Context, action ReconcileAction, retry base.RetryPolicy) ReconcileAction {
+	maxAttempts := retry.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			timer := time.NewTimer(chunkBackoff(retry, attempt-1))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				action.Err = ctx.Err()
+				return action
+			}
+		}
+
+		if action.Type == ActionCreateGroup {
+			created, err := g.Create(ctx, action.GroupName, action.GroupType)
+			if err == nil {
+				action.GroupID = created.ID
+				return action
+			}
+			lastErr = err
+		} else {
+			lastErr = g.runAction(ctx, action)
+			if lastErr == nil {
+				return action
+			}
+		}
+
+		if ctx.Err() != nil {
+			action.Err = ctx.Err()
+			return action
+		}
+	}
+
+	action.Err = fmt.Errorf("%s %s: %w", action.Type, action.GroupName, lastErr)
+	return action
+}
+
+// runAction dispatches a single non-create ReconcileAction to the
+// matching ScannerGroupsAPI method.
+func (g *ScannerGroupsAPI) runAction(ctx context.Context, action ReconcileAction) error {
+	switch action.Type {
+	case ActionDeleteGroup:
+		return g.Delete(ctx, action.GroupID)
+	case ActionAddScanner:
+		scannerID, err := g.resolveScannerID(ctx, action.ScannerName)
+		if err != nil {
+			return err
+		}
+		return g.AddScanner(ctx, action.GroupID, scannerID)
+	case ActionRemoveScanner:
+		scannerID, err := g.resolveScannerID(ctx, action.ScannerName)
+		if err != nil {
+			return err
+		}
+		return g.RemoveScanner(ctx, action.GroupID, scannerID)
+	case ActionAddRoute:
+		return g.AddRoute(ctx, action.GroupID, action.Route)
+	case ActionDeleteRoute:
+		return g.DeleteRoute(ctx, action.GroupID, action.Route)
+	default:
+		return fmt.Errorf("unknown reconcile action type %q", action.Type)
+	}
+}
+
+// resolveScannerID resolves a scanner name or numeric ID string to its
+// numeric ID via ScannersAPI.List, since AddScanner/RemoveScanner take an
+// ID but GroupSpec and diffScanners work in names.
+func (g *ScannerGroupsAPI) resolveScannerID(ctx context.Context, nameOrID string) (int, error) {
+	if id, err := parseScannerID(nameOrID); err == nil {
+		return id, nil
+	}
+
+	scanners, err := g.client.Scanners.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("resolve scanner %q: %w", nameOrID, err)
+	}
+	for _, sc := range scanners {
+		if sc.Name == nameOrID {
+			return sc.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("resolve scanner %q: no such scanner", nameOrID)
+}
+
+// parseScannerID parses nameOrID as a numeric scanner ID, as produced by
+// diffScanners for scanners slated for removal (which only has the live
+// Scanner's ID, not its name, to go on).
+func parseScannerID(nameOrID string) (int, error) {
+	return strconv.Atoi(nameOrID)
+}
@@ -0,0 +1,139 @@
+package tio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// reconcileFixtureTransport serves a fixed scanner-groups/scanners
+// topology: one group "prod" with scanner "scanner-a" and route "prod.*",
+// matching the handful of endpoints Reconcile's plan phase reads from.
+func reconcileFixtureTransport() http.RoundTripper {
+	return roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		var body interface{}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/scanner-groups":
+			body = map[string]interface{}{
+				"scanner_pools": []ScannerGroup{{ID: 1, Name: "prod", Type: "load_balancing"}},
+			}
+		case r.Method == http.MethodGet && r.URL.Path == "/scanner-groups/1/scanners":
+			body = map[string]interface{}{
+				"scanners": []Scanner{{ID: 10, Name: "scanner-a"}},
+			}
+		case r.Method == http.MethodGet && r.URL.Path == "/scanner-groups/1/routes":
+			body = map[string]interface{}{
+				"routes": []ScanRoute{{Route: "prod.*"}},
+			}
+		default:
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewReader(nil)), Header: http.Header{}}, nil
+		}
+
+		encoded, _ := json.Marshal(body)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(bytes.NewReader(encoded)),
+		}, nil
+	})
+}
+
+func reconcileTestClient(t *testing.T) *Client {
+	t.Helper()
+	client, err := New(
+		WithAPIKeys("access", "secret"),
+		WithHTTPTransport(reconcileFixtureTransport()),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return client
+}
+
+func TestReconcilePlansCreateForMissingGroup(t *testing.T) {
+	client := reconcileTestClient(t)
+
+	desired := []GroupSpec{
+		{Name: "prod", Type: "load_balancing", Scanners: []string{"scanner-a"}, Routes: []string{"prod.*"}},
+		{Name: "staging", Type: "load_balancing", Scanners: []string{"scanner-b"}},
+	}
+
+	report, err := client.ScannerGroups.Reconcile(context.Background(), desired, ReconcileOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	var sawCreateStaging, sawAddScannerB bool
+	for _, a := range report.PlannedActions {
+		if a.Type == ActionCreateGroup && a.GroupName == "staging" {
+			sawCreateStaging = true
+		}
+		if a.Type == ActionAddScanner && a.GroupName == "staging" && a.ScannerName == "scanner-b" {
+			sawAddScannerB = true
+		}
+		if a.GroupName == "prod" && (a.Type == ActionCreateGroup || a.Type == ActionAddScanner || a.Type == ActionAddRoute) {
+			t.Errorf("unexpected action for already-converged group prod: %+v", a)
+		}
+	}
+	if !sawCreateStaging {
+		t.Errorf("expected a create_group action for missing group staging, planned = %+v", report.PlannedActions)
+	}
+	if !sawAddScannerB {
+		t.Errorf("expected an add_scanner action for staging's scanner-b, planned = %+v", report.PlannedActions)
+	}
+	if len(report.AppliedActions) != 0 {
+		t.Errorf("DryRun should not apply anything, got %d applied actions", len(report.AppliedActions))
+	}
+}
+
+func TestReconcilePlansDeleteForUndesiredGroup(t *testing.T) {
+	client := reconcileTestClient(t)
+
+	report, err := client.ScannerGroups.Reconcile(context.Background(), nil, ReconcileOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	var sawDelete bool
+	for _, a := range report.PlannedActions {
+		if a.Type == ActionDeleteGroup && a.GroupName == "prod" {
+			sawDelete = true
+		}
+	}
+	if !sawDelete {
+		t.Errorf("expected a delete_group action for prod when desired is empty, planned = %+v", report.PlannedActions)
+	}
+}
+
+func TestReconcilePlansScannerAndRouteDiff(t *testing.T) {
+	client := reconcileTestClient(t)
+
+	desired := []GroupSpec{
+		{Name: "prod", Type: "load_balancing", Scanners: []string{"scanner-c"}, Routes: []string{"other.*"}},
+	}
+
+	report, err := client.ScannerGroups.Reconcile(context.Background(), desired, ReconcileOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	want := map[ReconcileActionType]bool{
+		ActionAddScanner:    false,
+		ActionRemoveScanner: false,
+		ActionAddRoute:      false,
+		ActionDeleteRoute:   false,
+	}
+	for _, a := range report.PlannedActions {
+		if _, ok := want[a.Type]; ok {
+			want[a.Type] = true
+		}
+	}
+	for actionType, seen := range want {
+		if !seen {
+			t.Errorf("expected a planned action of type %q, planned = %+v", actionType, report.PlannedActions)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package tio
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ScannerEventType identifies the kind of state transition a ScannerMonitor
+// detected between two polls of ScannersAPI.List.
+type ScannerEventType string
+
+const (
+	ScannerEventLinked           ScannerEventType = "linked"
+	ScannerEventUnlinked         ScannerEventType = "unlinked"
+	ScannerEventOn               ScannerEventType = "on"
+	ScannerEventOff              ScannerEventType = "off"
+	ScannerEventPluginSetChanged ScannerEventType = "plugin_set_changed"
+	ScannerEventLicenseExpiring  ScannerEventType = "license_expiring"
+)
+
+// ScannerEvent is a single detected state transition for one scanner.
+type ScannerEvent struct {
+	Type      ScannerEventType `json:"type"`
+	ScannerID int              `json:"scanner_id"`
+	Name      string           `json:"name"`
+	Detail    string           `json:"detail,omitempty"`
+	Timestamp time.Time        `json:"timestamp"`
+}
+
+// ScannerHealth summarizes a scanner's observed state as of the most recent
+// ScannerMonitor poll.
+type ScannerHealth struct {
+	ScannerID      int       `json:"scanner_id"`
+	Name           string    `json:"name"`
+	Status         string    `json:"status"`
+	Linked         bool      `json:"linked"`
+	LastSeen       time.Time `json:"last_seen"`
+	ScanCountDelta int       `json:"scan_count_delta"`
+}
+
+// ScannerMonitorOptions configures a ScannerMonitor.
+type ScannerMonitorOptions struct {
+	// Interval is how often the monitor polls ScannersAPI.List. Zero uses
+	// a default of 1 minute.
+	Interval time.Duration
+	// LicenseExpiryWindow, if non-zero, makes the monitor emit a
+	// ScannerEventLicenseExpiring event the first time a scanner's
+	// license expiration falls within this window of the current time.
+	LicenseExpiryWindow time.Duration
+	// Notifiers are notified, in order, of every ScannerEvent the monitor
+	// emits, in addition to it being sent on the Subscribe channel.
+	Notifiers []Notifier
+}
+
+func (o ScannerMonitorOptions) withDefaults() ScannerMonitorOptions {
+	if o.Interval <= 0 {
+		o.Interval = time.Minute
+	}
+	return o
+}
+
+// scannerState is the last-observed state of one scanner, used to detect
+// transitions between polls.
+type scannerState struct {
+	name             string
+	status           string
+	linked           bool
+	pluginSet        string
+	scanCount        int
+	scanCountDelta   int
+	lastSeen         time.Time
+	licenseExpiry    int64
+	notifiedExpiring bool
+}
+
+// ScannerMonitor periodically polls ScannersAPI.List, tracks
+// state transitions (linked/unlinked, on/off, plugin-set drift, license
+// expiration approaching), and emits a ScannerEvent for each one, both on
+// a subscribable channel and to any configured Notifiers.
+type ScannerMonitor struct {
+	scanners *ScannersAPI
+	opts     ScannerMonitorOptions
+
+	mu    sync.Mutex
+	state map[int]*scannerState
+
+	events chan ScannerEvent
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewScannerMonitor creates a ScannerMonitor over scanners. Call Start to
+// begin polling.
+func NewScannerMonitor(scanners *ScannersAPI, opts ScannerMonitorOptions) *ScannerMonitor {
+	return &ScannerMonitor{
+		scanners: scanners,
+		opts:     opts.withDefaults(),
+		state:    make(map[int]*scannerState),
+		events:   make(chan ScannerEvent, 64),
+	}
+}
+
+// Start begins polling on a background goroutine, at opts.Interval, until
+// ctx is canceled or Stop is called.
+func (m *ScannerMonitor) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	m.cancel = cancel
+	m.done = make(chan struct{})
+
+	go func() {
+		defer close(m.done)
+		timer := time.NewTimer(m.opts.Interval)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				m.poll(ctx)
+				timer.Reset(m.opts.Interval)
+			}
+		}
+	}()
+}
+
+// Stop cancels the background poll loop started by Start and waits for it
+// to exit.
+func (m *ScannerMonitor) Stop() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	if m.done != nil {
+		<-m.done
+	}
+}
+
+// Subscribe returns the channel ScannerEvents are published on. The
+// channel is never closed by ScannerMonitor.
+func (m *ScannerMonitor) Subscribe() <-chan ScannerEvent {
+	return m.events
+}
+
+// HealthSummary returns the most recently observed health of every scanner
+// the monitor has polled at least once, ordered by ScannerID.
+func (m *ScannerMonitor) HealthSummary() []ScannerHealth {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]ScannerHealth, 0, len(m.state))
+	for id, st := range m.state {
+		out = append(out, ScannerHealth{
+			ScannerID:      id,
+			Name:           st.name,
+			Status:         st.status,
+			Linked:         st.linked,
+			LastSeen:       st.lastSeen,
+			ScanCountDelta: st.scanCountDelta,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ScannerID < out[j].ScannerID })
+	return out
+}
+
+// poll fetches the current scanner list and diffs it against the last
+// observed state, emitting a ScannerEvent for every transition found.
+// Fetch failures are swallowed: a transient List error shouldn't crash the
+// poll loop, and the next poll will simply compare against the same
+// baseline again.
+func (m *ScannerMonitor) poll(ctx context.Context) {
+	scanners, err := m.scanners.List(ctx)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, sc := range scanners {
+		prev, seen := m.state[sc.ID]
+		linked := sc.Linked != 0
+
+		if seen {
+			m.diffTransitions(sc, prev, linked, now)
+		}
+
+		if !seen {
+			prev = &scannerState{}
+			m.state[sc.ID] = prev
+		} else {
+			prev.scanCountDelta = sc.ScanCount - prev.scanCount
+		}
+		prev.name = sc.Name
+		prev.status = sc.Status
+		prev.linked = linked
+		prev.pluginSet = sc.LoadedPluginSet
+		prev.scanCount = sc.ScanCount
+		prev.lastSeen = now
+		if sc.License != nil {
+			prev.licenseExpiry = sc.License.Expiration
+		}
+
+		if m.opts.LicenseExpiryWindow > 0 && sc.License != nil && !prev.notifiedExpiring {
+			expiresAt := time.Unix(sc.License.Expiration, 0)
+			if expiresAt.After(now) && expiresAt.Before(now.Add(m.opts.LicenseExpiryWindow)) {
+				prev.notifiedExpiring = true
+				m.emit(ScannerEvent{
+					Type:      ScannerEventLicenseExpiring,
+					ScannerID: sc.ID,
+					Name:      sc.Name,
+					Detail:    "license expires " + expiresAt.Format(time.RFC3339),
+					Timestamp: now,
+				})
+			}
+		}
+	}
+}
+
+func (m *ScannerMonitor) diffTransitions(sc Scanner, prev *scannerState, linked bool, now time.Time) {
+	if linked != prev.linked {
+		eventType := ScannerEventUnlinked
+		if linked {
+			eventType = ScannerEventLinked
+		}
+		m.emit(ScannerEvent{Type: eventType, ScannerID: sc.ID, Name: sc.Name, Timestamp: now})
+	}
+	if sc.Status != prev.status && (sc.Status == "on" || sc.Status == "off") {
+		eventType := ScannerEventOff
+		if sc.Status == "on" {
+			eventType = ScannerEventOn
+		}
+		m.emit(ScannerEvent{Type: eventType, ScannerID: sc.ID, Name: sc.Name, Timestamp: now})
+	}
+	if sc.LoadedPluginSet != prev.pluginSet {
+		m.emit(ScannerEvent{
+			Type:      ScannerEventPluginSetChanged,
+			ScannerID: sc.ID,
+			Name:      sc.Name,
+			Detail:    prev.pluginSet + " -> " + sc.LoadedPluginSet,
+			Timestamp: now,
+		})
+	}
+}
+
+// emit publishes ev on the Subscribe channel (dropping it if the channel
+// is full, so a slow subscriber can't stall the poll loop) and notifies
+// every configured Notifier.
+func (m *ScannerMonitor) emit(ev ScannerEvent) {
+	select {
+	case m.events <- ev:
+	default:
+	}
+	for _, n := range m.opts.Notifiers {
+		n.Notify(ev)
+	}
+}
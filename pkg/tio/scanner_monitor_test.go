@@ -0,0 +1,79 @@
+package tio
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingNotifier struct {
+	mu     sync.Mutex
+	events []ScannerEvent
+}
+
+func (n *recordingNotifier) Notify(event ScannerEvent) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.events = append(n.events, event)
+	return nil
+}
+
+func (n *recordingNotifier) snapshot() []ScannerEvent {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	out := make([]ScannerEvent, len(n.events))
+	copy(out, n.events)
+	return out
+}
+
+func TestScannerMonitorDetectsLinkAndStatusTransitions(t *testing.T) {
+	notifier := &recordingNotifier{}
+	monitor := NewScannerMonitor(nil, ScannerMonitorOptions{Notifiers: []Notifier{notifier}})
+
+	now := time.Now()
+	monitor.state[1] = &scannerState{name: "scanner-1", status: "off", linked: false, pluginSet: "100"}
+
+	monitor.diffTransitions(Scanner{ID: 1, Name: "scanner-1", Status: "on", LoadedPluginSet: "101"}, monitor.state[1], true, now)
+
+	events := notifier.snapshot()
+	if len(events) != 3 {
+		t.Fatalf("len(events) = %d, want 3 (linked, on, plugin set changed)", len(events))
+	}
+
+	var gotTypes []ScannerEventType
+	for _, e := range events {
+		gotTypes = append(gotTypes, e.Type)
+	}
+	wantTypes := []ScannerEventType{ScannerEventLinked, ScannerEventOn, ScannerEventPluginSetChanged}
+	for i, want := range wantTypes {
+		if gotTypes[i] != want {
+			t.Errorf("events[%d].Type = %q, want %q", i, gotTypes[i], want)
+		}
+	}
+}
+
+func TestScannerMonitorNoTransitionsWhenStateUnchanged(t *testing.T) {
+	notifier := &recordingNotifier{}
+	monitor := NewScannerMonitor(nil, ScannerMonitorOptions{Notifiers: []Notifier{notifier}})
+
+	prev := &scannerState{name: "scanner-1", status: "on", linked: true, pluginSet: "101"}
+	monitor.diffTransitions(Scanner{ID: 1, Name: "scanner-1", Status: "on", LoadedPluginSet: "101"}, prev, true, time.Now())
+
+	if events := notifier.snapshot(); len(events) != 0 {
+		t.Errorf("len(events) = %d, want 0 for unchanged state", len(events))
+	}
+}
+
+func TestScannerMonitorHealthSummaryOrdersByID(t *testing.T) {
+	monitor := NewScannerMonitor(nil, ScannerMonitorOptions{})
+	monitor.state[5] = &scannerState{name: "scanner-5", status: "on"}
+	monitor.state[2] = &scannerState{name: "scanner-2", status: "off"}
+
+	summary := monitor.HealthSummary()
+	if len(summary) != 2 {
+		t.Fatalf("len(summary) = %d, want 2", len(summary))
+	}
+	if summary[0].ScannerID != 2 || summary[1].ScannerID != 5 {
+		t.Errorf("summary = %+v, want ordered by ScannerID", summary)
+	}
+}
@@ -0,0 +1,113 @@
+package tio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+)
+
+// Notifier receives ScannerEvents from a ScannerMonitor. Implementations
+// must be safe to call from a single goroutine at a time; ScannerMonitor
+// never calls Notify concurrently.
+type Notifier interface {
+	Notify(event ScannerEvent) error
+}
+
+// NotifierFunc adapts a plain function to the Notifier interface.
+type NotifierFunc func(event ScannerEvent) error
+
+// Notify implements Notifier.
+func (f NotifierFunc) Notify(event ScannerEvent) error { return f(event) }
+
+// WebhookNotifier posts each ScannerEvent as a JSON body to URL.
+type WebhookNotifier struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+// Notify implements Notifier by issuing an HTTP POST of the JSON-encoded
+// event to n.URL.
+func (n *WebhookNotifier) Notify(event ScannerEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("webhook notifier: %w", err)
+	}
+
+	client := n.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Post(n.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook notifier: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook notifier: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// LogNotifier writes each ScannerEvent as a single log line through the
+// standard library "log" package, analogous to base.LogProgressReporter.
+type LogNotifier struct {
+	// Prefix is prepended to every log line, e.g. "scanner monitor: ".
+	Prefix string
+}
+
+// Notify implements Notifier.
+func (n *LogNotifier) Notify(event ScannerEvent) error {
+	log.Printf("%s%s scanner %d (%s)%s", n.Prefix, event.Type, event.ScannerID, event.Name, detailSuffix(event.Detail))
+	return nil
+}
+
+func detailSuffix(detail string) string {
+	if detail == "" {
+		return ""
+	}
+	return ": " + detail
+}
+
+// FileNotifier appends each ScannerEvent as a JSON line to a file, creating
+// it if it doesn't exist.
+type FileNotifier struct {
+	Path string
+
+	file io.WriteCloser
+}
+
+// Notify implements Notifier, opening n.Path for append on first use and
+// keeping it open for subsequent calls.
+func (n *FileNotifier) Notify(event ScannerEvent) error {
+	if n.file == nil {
+		f, err := os.OpenFile(n.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("file notifier: %w", err)
+		}
+		n.file = f
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("file notifier: %w", err)
+	}
+	body = append(body, '\n')
+	if _, err := n.file.Write(body); err != nil {
+		return fmt.Errorf("file notifier: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying file, if it was opened.
+func (n *FileNotifier) Close() error {
+	if n.file == nil {
+		return nil
+	}
+	return n.file.Close()
+}
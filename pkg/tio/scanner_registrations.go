@@ -0,0 +1,154 @@
+package tio
+
+import (
+	"context"
+	"fmt"
+)
+
+// ScannerAuthType identifies how a registered scanner adapter authenticates
+// against its own API.
+type ScannerAuthType string
+
+const (
+	ScannerAuthNone   ScannerAuthType = "none"
+	ScannerAuthBasic  ScannerAuthType = "basic"
+	ScannerAuthBearer ScannerAuthType = "bearer"
+	ScannerAuthAPIKey ScannerAuthType = "apikey"
+)
+
+// ScannerAdapterMetadata is the capability set a scanner adapter reports from
+// its own metadata endpoint, analogous to a Harbor scanner adapter's
+// /api/v1/metadata response.
+type ScannerAdapterMetadata struct {
+	Vendor         string   `json:"vendor,omitempty"`
+	Version        string   `json:"version,omitempty"`
+	SupportedMIMEs []string `json:"supported_mime_types,omitempty"`
+	Produces       []string `json:"produces,omitempty"`
+	Consumes       []string `json:"consumes,omitempty"`
+}
+
+// ScannerRegistration describes a third-party scanner adapter registered so
+// that scan requests can be routed to it alongside native Nessus scanners.
+type ScannerRegistration struct {
+	ID               string                  `json:"id,omitempty"`
+	Name             string                  `json:"name"`
+	URL              string                  `json:"url"`
+	Auth             ScannerAuthType         `json:"auth"`
+	AccessCredential string                  `json:"access_credential,omitempty"`
+	SkipCertVerify   bool                    `json:"skip_cert_verify"`
+	UseInternalAddr  bool                    `json:"use_internal_addr"`
+	Disabled         bool                    `json:"disabled"`
+	IsDefault        bool                    `json:"is_default"`
+	Metadata         *ScannerAdapterMetadata `json:"metadata,omitempty"`
+}
+
+// ScannerRegistrationsAPI manages third-party scanner adapter registrations,
+// mirroring Harbor's scanner-registration model so adapters for scanners
+// other than Nessus (e.g. Trivy, Clair-style engines) can be plugged into
+// scan workflows through the same Go API surface.
+type ScannerRegistrationsAPI struct {
+	client *Client
+}
+
+// Register creates a new scanner adapter registration.
+func (r *ScannerRegistrationsAPI) Register(ctx context.Context, reg ScannerRegistration) (*ScannerRegistration, error) {
+	var result ScannerRegistration
+	_, err := r.client.Post(ctx, "scanner-registrations", reg, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListRegistrations retrieves all scanner adapter registrations.
+func (r *ScannerRegistrationsAPI) ListRegistrations(ctx context.Context) ([]ScannerRegistration, error) {
+	var result struct {
+		Registrations []ScannerRegistration `json:"registrations"`
+	}
+	_, err := r.client.Get(ctx, "scanner-registrations", &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Registrations, nil
+}
+
+// GetRegistration retrieves a single scanner adapter registration.
+func (r *ScannerRegistrationsAPI) GetRegistration(ctx context.Context, id string) (*ScannerRegistration, error) {
+	var result ScannerRegistration
+	_, err := r.client.Get(ctx, fmt.Sprintf("scanner-registrations/%s", id), &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateRegistration updates an existing scanner adapter registration.
+func (r *ScannerRegistrationsAPI) UpdateRegistration(ctx context.Context, id string, reg ScannerRegistration) error {
+	_, err := r.client.Put(ctx, fmt.Sprintf("scanner-registrations/%s", id), reg, nil)
+	return err
+}
+
+// DeleteRegistration removes a scanner adapter registration.
+func (r *ScannerRegistrationsAPI) DeleteRegistration(ctx context.Context, id string) error {
+	_, err := r.client.Delete(ctx, fmt.Sprintf("scanner-registrations/%s", id))
+	return err
+}
+
+// SetAsDefault marks a scanner adapter registration as the default adapter
+// used for scan requests that don't specify one explicitly.
+func (r *ScannerRegistrationsAPI) SetAsDefault(ctx context.Context, id string) error {
+	payload := map[string]bool{"is_default": true}
+	_, err := r.client.Put(ctx, fmt.Sprintf("scanner-registrations/%s", id), payload, nil)
+	return err
+}
+
+// Route selects the registered, non-disabled adapter best suited to consume
+// mimeType, preferring the default adapter when more than one can consume
+// it. It returns an error if no registered adapter advertises support for
+// mimeType, so callers can fail a scan dispatch early rather than sending it
+// to an incompatible adapter.
+func (r *ScannerRegistrationsAPI) Route(ctx context.Context, mimeType string) (*ScannerRegistration, error) {
+	regs, err := r.ListRegistrations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("route scan: %w", err)
+	}
+
+	var candidate *ScannerRegistration
+	for i := range regs {
+		reg := &regs[i]
+		if reg.Disabled || reg.Metadata == nil || !consumesMIME(reg.Metadata.Consumes, mimeType) {
+			continue
+		}
+		if reg.IsDefault {
+			return reg, nil
+		}
+		if candidate == nil {
+			candidate = reg
+		}
+	}
+	if candidate == nil {
+		return nil, fmt.Errorf("route scan: no registered scanner adapter consumes %q", mimeType)
+	}
+	return candidate, nil
+}
+
+func consumesMIME(consumes []string, mimeType string) bool {
+	for _, c := range consumes {
+		if c == mimeType {
+			return true
+		}
+	}
+	return false
+}
+
+// PingRegistration probes a registered adapter's metadata endpoint and
+// returns its reported capabilities, verifying the adapter is reachable and
+// compatible before it is relied on to service scan requests.
+func (r *ScannerRegistrationsAPI) PingRegistration(ctx context.Context, id string) (*ScannerAdapterMetadata, error) {
+	var result ScannerAdapterMetadata
+	_, err := r.client.Get(ctx, fmt.Sprintf("scanner-registrations/%s/metadata", id), &result)
+	if err != nil {
+		return nil, fmt.Errorf("ping scanner registration %s: %w", id, err)
+	}
+	return &result, nil
+}
@@ -401,8 +401,30 @@ func (s *ScansAPI) DeleteHistory(ctx context.Context, scanID, historyID int) err
 	return err
 }
 
-// Export initiates a scan export.
+// Export initiates a scan export and waits for it to become available,
+// polling via waitForExportReady, which shares Wait's backoff
+// (scanWaitSleep).
 func (s *ScansAPI) Export(ctx context.Context, scanID int, format string, historyID *int, chapters []string) (io.Reader, error) {
+	fileID, err := s.initiateExport(ctx, scanID, format, historyID, chapters)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.waitForExportReady(ctx, scanID, fileID, WaitOptions{Interval: 2500 * time.Millisecond, BackoffFactor: 1}); err != nil {
+		return nil, err
+	}
+
+	data, err := s.client.Download(ctx, fmt.Sprintf("scans/%d/export/%d/download", scanID, fileID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &bytesReader{data: data}, nil
+}
+
+// initiateExport starts an export of scanID in format, returning the
+// file ID to poll and download.
+func (s *ScansAPI) initiateExport(ctx context.Context, scanID int, format string, historyID *int, chapters []string) (int, error) {
 	params := make(map[string]string)
 	if historyID != nil {
 		params["history_id"] = strconv.Itoa(*historyID)
@@ -415,52 +437,27 @@ func (s *ScansAPI) Export(ctx context.Context, scanID int, format string, histor
 		payload["chapters"] = chapters
 	}
 
-	// Initiate export
 	var exportResp struct {
 		File int `json:"file"`
 	}
 	_, err := s.client.PostWithParams(ctx, fmt.Sprintf("scans/%d/export", scanID), params, payload, &exportResp)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
+	return exportResp.File, nil
+}
 
-	fileID := exportResp.File
-
-	// Wait for export to be ready
-	for {
-		var statusResp struct {
-			Status string `json:"status"`
-		}
-		_, err := s.client.Get(ctx, fmt.Sprintf("scans/%d/export/%d/status", scanID, fileID), &statusResp)
-		if err != nil {
-			return nil, err
-		}
-
-		if statusResp.Status == "ready" {
-			break
-		}
-		if statusResp.Status == "error" {
-			return nil, &base.FileDownloadError{
-				Resource:   "scans",
-				ResourceID: strconv.Itoa(scanID),
-				Filename:   strconv.Itoa(fileID),
-			}
-		}
-
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-time.After(2500 * time.Millisecond):
-		}
+// exportStatus retrieves fileID's current export status ("loading",
+// "ready", or "error").
+func (s *ScansAPI) exportStatus(ctx context.Context, scanID, fileID int) (string, error) {
+	var statusResp struct {
+		Status string `json:"status"`
 	}
-
-	// Download the file
-	data, err := s.client.Download(ctx, fmt.Sprintf("scans/%d/export/%d/download", scanID, fileID))
+	_, err := s.client.Get(ctx, fmt.Sprintf("scans/%d/export/%d/status", scanID, fileID), &statusResp)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-
-	return &bytesReader{data: data}, nil
+	return statusResp.Status, nil
 }
 
 // bytesReader wraps bytes to implement io.Reader.
@@ -609,3 +606,18 @@ func (s *ScansAPI) Schedule(ctx context.Context, scanID int, enabled bool) error
 	_, err := s.client.Put(ctx, fmt.Sprintf("scans/%d/schedule", scanID), payload, nil)
 	return err
 }
+
+// ScheduleRaw enables scanID's schedule with the given raw RRULE and
+// timezone, via the same scans/{id}/schedule endpoint Schedule uses. It
+// does no validation of its own — see the tio/schedule package for a
+// builder and a ScheduleScan helper that validates tz against
+// Timezones before calling this.
+func (s *ScansAPI) ScheduleRaw(ctx context.Context, scanID int, rrules, timezone string) error {
+	payload := map[string]interface{}{
+		"enabled":  true,
+		"rrules":   rrules,
+		"timezone": timezone,
+	}
+	_, err := s.client.Put(ctx, fmt.Sprintf("scans/%d/schedule", scanID), payload, nil)
+	return err
+}
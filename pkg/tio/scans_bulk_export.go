@@ -0,0 +1,159 @@
+package tio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/base"
+)
+
+// BulkExportRequest is one scan's export request within a BulkExport
+// call.
+type BulkExportRequest struct {
+	ScanID    int
+	Format    string
+	HistoryID *int
+	Chapters  []string
+}
+
+// BulkExportResult is the outcome of one BulkExportRequest.
+type BulkExportResult struct {
+	ScanID int
+	Data   io.Reader
+	Bytes  int64
+	Err    error
+}
+
+// BulkExportObserver receives progress events as BulkExport runs. Any
+// field may be left nil.
+type BulkExportObserver struct {
+	OnStarted   func(scanID int)
+	OnCompleted func(scanID int, bytes int64)
+	OnFailed    func(scanID int, err error)
+}
+
+// BulkExportOptions configures BulkExport.
+type BulkExportOptions struct {
+	// Concurrency bounds how many exports run at once. Defaults to 4.
+	Concurrency int
+	// Retry governs how many times, and with what backoff, a single
+	// scan's export is retried before it's reported as failed. Defaults
+	// to base.DefaultRetryPolicy. The underlying *Client's own
+	// RateLimiter (installed via base.WithRateLimiter) still governs
+	// the pace of the individual HTTP calls each export issues, so
+	// BulkExport doesn't need a rate limiter of its own to respect
+	// Tenable's API quotas.
+	Retry base.RetryPolicy
+	// Observer, if set, is notified as each scan's export starts,
+	// completes, or fails.
+	Observer BulkExportObserver
+}
+
+func (o BulkExportOptions) withDefaults() BulkExportOptions {
+	if o.Concurrency <= 0 {
+		o.Concurrency = 4
+	}
+	if o.Retry.MaxAttempts <= 0 {
+		o.Retry = base.DefaultRetryPolicy
+	}
+	return o
+}
+
+// BulkExport runs an Export for every entry in requests concurrently,
+// bounded by opts.Concurrency, retrying each scan's export
+// independently under opts.Retry. Results are streamed out as each
+// scan's export finishes — in whatever order they complete, not the
+// order requests was given in — so a caller exporting dozens of scans
+// nightly doesn't wait for the slowest one before starting on the rest.
+// One scan's export failing doesn't cancel the others; it's reported in
+// that scan's BulkExportResult.Err. Canceling ctx aborts every pending
+// initiate/poll/download and stops dispatching new requests; the
+// returned channel still closes once every in-flight worker unwinds.
+func (s *ScansAPI) BulkExport(ctx context.Context, requests []BulkExportRequest, opts BulkExportOptions) <-chan BulkExportResult {
+	opts = opts.withDefaults()
+
+	out := make(chan BulkExportResult, opts.Concurrency)
+
+	jobs := make(chan BulkExportRequest)
+	go func() {
+		defer close(jobs)
+		for _, r := range requests {
+			select {
+			case jobs <- r:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(opts.Concurrency)
+	for i := 0; i < opts.Concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for req := range jobs {
+				out <- s.runBulkExport(ctx, req, opts)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// runBulkExport runs a single BulkExportRequest's export, retrying it
+// under opts.Retry, and turns the outcome into a BulkExportResult,
+// notifying opts.Observer throughout.
+func (s *ScansAPI) runBulkExport(ctx context.Context, req BulkExportRequest, opts BulkExportOptions) BulkExportResult {
+	if opts.Observer.OnStarted != nil {
+		opts.Observer.OnStarted(req.ScanID)
+	}
+
+	maxAttempts := opts.Retry.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			timer := time.NewTimer(chunkBackoff(opts.Retry, attempt-1))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return BulkExportResult{ScanID: req.ScanID, Err: ctx.Err()}
+			}
+		}
+
+		reader, err := s.Export(ctx, req.ScanID, req.Format, req.HistoryID, req.Chapters)
+		if err == nil {
+			var data []byte
+			data, err = io.ReadAll(reader)
+			if err == nil {
+				if opts.Observer.OnCompleted != nil {
+					opts.Observer.OnCompleted(req.ScanID, int64(len(data)))
+				}
+				return BulkExportResult{ScanID: req.ScanID, Data: bytes.NewReader(data), Bytes: int64(len(data))}
+			}
+		}
+		if ctx.Err() != nil {
+			return BulkExportResult{ScanID: req.ScanID, Err: ctx.Err()}
+		}
+		lastErr = err
+	}
+
+	err := fmt.Errorf("bulk export scan %d: %w", req.ScanID, lastErr)
+	if opts.Observer.OnFailed != nil {
+		opts.Observer.OnFailed(req.ScanID, err)
+	}
+	return BulkExportResult{ScanID: req.ScanID, Err: err}
+}
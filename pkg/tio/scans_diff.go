@@ -0,0 +1,236 @@
+package tio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DetailsAtHistory retrieves a scan's details as they were for a
+// specific run, identified by historyID (see ScansAPI.History), rather
+// than the latest run Details returns.
+func (s *ScansAPI) DetailsAtHistory(ctx context.Context, scanID, historyID int) (*ScanDetails, error) {
+	params := map[string]string{"history_id": strconv.Itoa(historyID)}
+
+	var result ScanDetails
+	_, err := s.client.GetWithParams(ctx, fmt.Sprintf("scans/%d", scanID), params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DiffFormat selects ScanDiff.Render's output format.
+type DiffFormat string
+
+const (
+	DiffFormatMarkdown DiffFormat = "markdown"
+	DiffFormatJSON     DiffFormat = "json"
+)
+
+// DiffOptions filters the findings Diff includes in a ScanDiff. The
+// zero value includes everything.
+type DiffOptions struct {
+	// MinSeverity drops any finding below this severity (on the 0-4
+	// scale ScanVuln uses).
+	MinSeverity int
+	// PluginFamily, if set, restricts findings to this plugin family.
+	PluginFamily string
+	// CVEsByPluginID optionally supplies each plugin's CVE IDs (e.g.
+	// from ScansAPI.PluginOutput's PluginAttrs), letting CVEs filter
+	// findings even though ScanVuln itself carries no CVE field.
+	CVEsByPluginID map[int][]string
+	// CVEs, if non-empty, restricts findings to plugins whose
+	// CVEsByPluginID entry contains at least one of these IDs. Plugins
+	// with no CVEsByPluginID entry are excluded once this filter is
+	// set.
+	CVEs []string
+}
+
+func (o DiffOptions) include(v ScanVuln) bool {
+	if v.SeverityIndex < o.MinSeverity {
+		return false
+	}
+	if o.PluginFamily != "" && v.PluginFamily != o.PluginFamily {
+		return false
+	}
+	if len(o.CVEs) > 0 {
+		cves := o.CVEsByPluginID[v.PluginID]
+		if !anyMatch(cves, o.CVEs) {
+			return false
+		}
+	}
+	return true
+}
+
+func anyMatch(haystack, needles []string) bool {
+	for _, n := range needles {
+		for _, h := range haystack {
+			if h == n {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DiffEntry is a single plugin finding in a ScanDiff.
+type DiffEntry struct {
+	PluginID     int    `json:"plugin_id"`
+	PluginName   string `json:"plugin_name"`
+	PluginFamily string `json:"plugin_family"`
+	Severity     int    `json:"severity"`
+}
+
+// HostDelta records a host that appeared or disappeared between the two
+// histories a ScanDiff compares.
+type HostDelta struct {
+	Hostname string `json:"hostname"`
+	Added    bool   `json:"added"`
+}
+
+// ScanDiff is the result of comparing two historical runs of the same
+// scan.
+type ScanDiff struct {
+	ScanID          int         `json:"scan_id"`
+	HistoryIDA      int         `json:"history_id_a"`
+	HistoryIDB      int         `json:"history_id_b"`
+	NewVulns        []DiffEntry `json:"new_vulns,omitempty"`
+	ResolvedVulns   []DiffEntry `json:"resolved_vulns,omitempty"`
+	PersistingVulns []DiffEntry `json:"persisting_vulns,omitempty"`
+	HostChanges     []HostDelta `json:"host_changes,omitempty"`
+}
+
+// Diff compares scanID's findings between two historical runs,
+// historyIDA and historyIDB (A is treated as the earlier baseline, B as
+// the later run), by fetching each run's ScanDetails and comparing
+// plugin IDs and host lists. opts filters which findings are considered
+// at all, for both sides of the comparison.
+func (s *ScansAPI) Diff(ctx context.Context, scanID, historyIDA, historyIDB int, opts DiffOptions) (*ScanDiff, error) {
+	a, err := s.DetailsAtHistory(ctx, scanID, historyIDA)
+	if err != nil {
+		return nil, fmt.Errorf("diff scan %d: fetch history %d: %w", scanID, historyIDA, err)
+	}
+	b, err := s.DetailsAtHistory(ctx, scanID, historyIDB)
+	if err != nil {
+		return nil, fmt.Errorf("diff scan %d: fetch history %d: %w", scanID, historyIDB, err)
+	}
+
+	aVulns := filteredVulnsByPlugin(a.Vulnerabilities, opts)
+	bVulns := filteredVulnsByPlugin(b.Vulnerabilities, opts)
+
+	diff := &ScanDiff{ScanID: scanID, HistoryIDA: historyIDA, HistoryIDB: historyIDB}
+	for pluginID, v := range bVulns {
+		if _, ok := aVulns[pluginID]; ok {
+			diff.PersistingVulns = append(diff.PersistingVulns, toDiffEntry(v))
+		} else {
+			diff.NewVulns = append(diff.NewVulns, toDiffEntry(v))
+		}
+	}
+	for pluginID, v := range aVulns {
+		if _, ok := bVulns[pluginID]; !ok {
+			diff.ResolvedVulns = append(diff.ResolvedVulns, toDiffEntry(v))
+		}
+	}
+	sortDiffEntries(diff.NewVulns)
+	sortDiffEntries(diff.ResolvedVulns)
+	sortDiffEntries(diff.PersistingVulns)
+
+	diff.HostChanges = diffHosts(a.Hosts, b.Hosts)
+
+	return diff, nil
+}
+
+func filteredVulnsByPlugin(vulns []ScanVuln, opts DiffOptions) map[int]ScanVuln {
+	out := make(map[int]ScanVuln, len(vulns))
+	for _, v := range vulns {
+		if opts.include(v) {
+			out[v.PluginID] = v
+		}
+	}
+	return out
+}
+
+func toDiffEntry(v ScanVuln) DiffEntry {
+	return DiffEntry{PluginID: v.PluginID, PluginName: v.PluginName, PluginFamily: v.PluginFamily, Severity: v.SeverityIndex}
+}
+
+func sortDiffEntries(entries []DiffEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Severity != entries[j].Severity {
+			return entries[i].Severity > entries[j].Severity
+		}
+		return entries[i].PluginID < entries[j].PluginID
+	})
+}
+
+func diffHosts(a, b []ScanHost) []HostDelta {
+	aHosts := make(map[string]bool, len(a))
+	for _, h := range a {
+		aHosts[h.Hostname] = true
+	}
+	bHosts := make(map[string]bool, len(b))
+	for _, h := range b {
+		bHosts[h.Hostname] = true
+	}
+
+	var deltas []HostDelta
+	for hostname := range bHosts {
+		if !aHosts[hostname] {
+			deltas = append(deltas, HostDelta{Hostname: hostname, Added: true})
+		}
+	}
+	for hostname := range aHosts {
+		if !bHosts[hostname] {
+			deltas = append(deltas, HostDelta{Hostname: hostname, Added: false})
+		}
+	}
+	sort.Slice(deltas, func(i, j int) bool { return deltas[i].Hostname < deltas[j].Hostname })
+	return deltas
+}
+
+// Render formats d in the given format, suitable for ticket automation:
+// DiffFormatMarkdown produces a changelog-style summary, DiffFormatJSON
+// marshals d directly.
+func (d *ScanDiff) Render(format DiffFormat) (string, error) {
+	if format == DiffFormatJSON {
+		data, err := json.Marshal(d)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Scan %d: history %d -> %d\n\n", d.ScanID, d.HistoryIDA, d.HistoryIDB)
+	renderDiffSection(&b, "New vulnerabilities", d.NewVulns)
+	renderDiffSection(&b, "Resolved vulnerabilities", d.ResolvedVulns)
+	renderDiffSection(&b, "Persisting vulnerabilities", d.PersistingVulns)
+
+	if len(d.HostChanges) > 0 {
+		fmt.Fprintf(&b, "## Host changes\n\n")
+		for _, h := range d.HostChanges {
+			if h.Added {
+				fmt.Fprintf(&b, "- + %s\n", h.Hostname)
+			} else {
+				fmt.Fprintf(&b, "- - %s\n", h.Hostname)
+			}
+		}
+	}
+
+	return b.String(), nil
+}
+
+func renderDiffSection(b *strings.Builder, title string, entries []DiffEntry) {
+	if len(entries) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## %s\n\n", title)
+	for _, e := range entries {
+		fmt.Fprintf(b, "- [%d] %s (%s, severity %d)\n", e.PluginID, e.PluginName, e.PluginFamily, e.Severity)
+	}
+	fmt.Fprintln(b)
+}
@@ -0,0 +1,132 @@
+package tio
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilteredVulnsByPluginAppliesMinSeverity(t *testing.T) {
+	vulns := []ScanVuln{
+		{PluginID: 1, SeverityIndex: 1},
+		{PluginID: 2, SeverityIndex: 3},
+	}
+	got := filteredVulnsByPlugin(vulns, DiffOptions{MinSeverity: 2})
+	if _, ok := got[1]; ok {
+		t.Errorf("plugin 1 should have been filtered out below MinSeverity")
+	}
+	if _, ok := got[2]; !ok {
+		t.Errorf("plugin 2 should have passed MinSeverity")
+	}
+}
+
+func TestDiffOptionsIncludeFiltersByCVE(t *testing.T) {
+	opts := DiffOptions{
+		CVEsByPluginID: map[int][]string{
+			10: {"CVE-2024-0001"},
+		},
+		CVEs: []string{"CVE-2024-0001"},
+	}
+	if !opts.include(ScanVuln{PluginID: 10}) {
+		t.Errorf("plugin 10 has a matching CVE and should be included")
+	}
+	if opts.include(ScanVuln{PluginID: 11}) {
+		t.Errorf("plugin 11 has no CVEsByPluginID entry and should be excluded")
+	}
+}
+
+func TestDiffDetectsNewResolvedAndPersistingVulns(t *testing.T) {
+	a := []ScanVuln{
+		{PluginID: 1, PluginName: "Old Finding", SeverityIndex: 2},
+		{PluginID: 2, PluginName: "Still There", SeverityIndex: 3},
+	}
+	b := []ScanVuln{
+		{PluginID: 2, PluginName: "Still There", SeverityIndex: 3},
+		{PluginID: 3, PluginName: "New Finding", SeverityIndex: 4},
+	}
+
+	aVulns := filteredVulnsByPlugin(a, DiffOptions{})
+	bVulns := filteredVulnsByPlugin(b, DiffOptions{})
+
+	var newVulns, resolvedVulns, persistingVulns []DiffEntry
+	for pluginID, v := range bVulns {
+		if _, ok := aVulns[pluginID]; ok {
+			persistingVulns = append(persistingVulns, toDiffEntry(v))
+		} else {
+			newVulns = append(newVulns, toDiffEntry(v))
+		}
+	}
+	for pluginID, v := range aVulns {
+		if _, ok := bVulns[pluginID]; !ok {
+			resolvedVulns = append(resolvedVulns, toDiffEntry(v))
+		}
+	}
+
+	if len(newVulns) != 1 || newVulns[0].PluginID != 3 {
+		t.Errorf("newVulns = %+v, want just plugin 3", newVulns)
+	}
+	if len(resolvedVulns) != 1 || resolvedVulns[0].PluginID != 1 {
+		t.Errorf("resolvedVulns = %+v, want just plugin 1", resolvedVulns)
+	}
+	if len(persistingVulns) != 1 || persistingVulns[0].PluginID != 2 {
+		t.Errorf("persistingVulns = %+v, want just plugin 2", persistingVulns)
+	}
+}
+
+func TestSortDiffEntriesOrdersBySeverityThenPluginID(t *testing.T) {
+	entries := []DiffEntry{
+		{PluginID: 5, Severity: 2},
+		{PluginID: 2, Severity: 4},
+		{PluginID: 1, Severity: 4},
+	}
+	sortDiffEntries(entries)
+
+	want := []int{1, 2, 5}
+	for i, pluginID := range want {
+		if entries[i].PluginID != pluginID {
+			t.Errorf("entries[%d].PluginID = %d, want %d", i, entries[i].PluginID, pluginID)
+		}
+	}
+}
+
+func TestDiffHostsReportsAddedAndRemoved(t *testing.T) {
+	a := []ScanHost{{Hostname: "host-a"}, {Hostname: "host-b"}}
+	b := []ScanHost{{Hostname: "host-b"}, {Hostname: "host-c"}}
+
+	deltas := diffHosts(a, b)
+	if len(deltas) != 2 {
+		t.Fatalf("len(deltas) = %d, want 2", len(deltas))
+	}
+	if deltas[0].Hostname != "host-a" || deltas[0].Added {
+		t.Errorf("deltas[0] = %+v, want host-a removed", deltas[0])
+	}
+	if deltas[1].Hostname != "host-c" || !deltas[1].Added {
+		t.Errorf("deltas[1] = %+v, want host-c added", deltas[1])
+	}
+}
+
+func TestScanDiffRenderJSON(t *testing.T) {
+	d := &ScanDiff{ScanID: 42, HistoryIDA: 1, HistoryIDB: 2}
+	out, err := d.Render(DiffFormatJSON)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if out == "" {
+		t.Errorf("Render(DiffFormatJSON) returned empty string")
+	}
+}
+
+func TestScanDiffRenderMarkdownIncludesSections(t *testing.T) {
+	d := &ScanDiff{
+		ScanID:     42,
+		HistoryIDA: 1,
+		HistoryIDB: 2,
+		NewVulns:   []DiffEntry{{PluginID: 100, PluginName: "Example", Severity: 3}},
+	}
+	out, err := d.Render(DiffFormatMarkdown)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(out, "New vulnerabilities") {
+		t.Errorf("Render(DiffFormatMarkdown) = %q, want it to mention new vulnerabilities", out)
+	}
+}
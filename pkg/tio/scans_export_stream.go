@@ -0,0 +1,163 @@
+package tio
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/base"
+)
+
+// ExportStream behaves like Export but streams the scan's CSV export as
+// typed ScanVuln records delivered on a channel, instead of buffering
+// the whole file in memory. Only format "csv" can be streamed this way
+// — Nessus/HTML/PDF exports are blob formats with no natural per-record
+// boundary, so they remain available only through Export. Sends on the
+// returned channel respect ctx cancellation, so a consumer that stops
+// reading (or whose ctx is cancelled) lets ExportStream unwind instead
+// of blocking forever.
+func (s *ScansAPI) ExportStream(ctx context.Context, scanID int, historyID *int) (<-chan ScanVuln, <-chan error) {
+	out := make(chan ScanVuln)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errs)
+
+		body, err := s.startExportAndDownloadStream(ctx, scanID, "csv", historyID)
+		if err != nil {
+			errs <- err
+			return
+		}
+		defer body.Close()
+
+		if err := decodeScanVulnCSV(ctx, body, out); err != nil {
+			errs <- err
+		}
+	}()
+
+	return out, errs
+}
+
+// startExportAndDownloadStream runs the same initiate-export/poll-status
+// flow as Export, then returns the downloaded file as a stream instead
+// of buffering it in memory.
+func (s *ScansAPI) startExportAndDownloadStream(ctx context.Context, scanID int, format string, historyID *int) (io.ReadCloser, error) {
+	params := make(map[string]string)
+	if historyID != nil {
+		params["history_id"] = strconv.Itoa(*historyID)
+	}
+
+	var exportResp struct {
+		File int `json:"file"`
+	}
+	if _, err := s.client.PostWithParams(ctx, fmt.Sprintf("scans/%d/export", scanID), params, map[string]interface{}{"format": format}, &exportResp); err != nil {
+		return nil, err
+	}
+	fileID := exportResp.File
+
+	for {
+		var statusResp struct {
+			Status string `json:"status"`
+		}
+		if _, err := s.client.Get(ctx, fmt.Sprintf("scans/%d/export/%d/status", scanID, fileID), &statusResp); err != nil {
+			return nil, err
+		}
+		if statusResp.Status == "ready" {
+			break
+		}
+		if statusResp.Status == "error" {
+			return nil, &base.FileDownloadError{
+				Resource:   "scans",
+				ResourceID: strconv.Itoa(scanID),
+				Filename:   strconv.Itoa(fileID),
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(2500 * time.Millisecond):
+		}
+	}
+
+	return s.client.DownloadStream(ctx, fmt.Sprintf("scans/%d/export/%d/download", scanID, fileID))
+}
+
+// scanVulnCSVColumns maps the Tenable.io scan CSV export's header names
+// to the ScanVuln field they populate. A column missing from this map is
+// ignored, so a forward-compatible column addition doesn't break
+// decoding.
+var scanVulnCSVColumns = map[string]func(*ScanVuln, string){
+	"Plugin ID": func(v *ScanVuln, s string) { v.PluginID, _ = strconv.Atoi(s) },
+	"Name":      func(v *ScanVuln, s string) { v.PluginName = s },
+	"Family":    func(v *ScanVuln, s string) { v.PluginFamily = s },
+	"Risk":      func(v *ScanVuln, s string) { v.SeverityIndex = riskToSeverityIndex(s) },
+}
+
+// riskToSeverityIndex maps the CSV export's "Risk" column (Tenable's
+// textual severity) to the numeric severity index used elsewhere in this
+// package.
+func riskToSeverityIndex(risk string) int {
+	switch risk {
+	case "Critical":
+		return 4
+	case "High":
+		return 3
+	case "Medium":
+		return 2
+	case "Low":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// decodeScanVulnCSV streams r's CSV body row by row, mapping each row
+// into a ScanVuln via scanVulnCSVColumns and sending it on out. It stops
+// early, returning ctx.Err(), if ctx is cancelled before out accepts the
+// next record — that's ExportStream's backpressure: a slow or abandoned
+// consumer halts decoding instead of buffering unboundedly.
+func decodeScanVulnCSV(ctx context.Context, r io.Reader, out chan<- ScanVuln) error {
+	cr := csv.NewReader(r)
+	cr.LazyQuotes = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return fmt.Errorf("read export CSV header: %w", err)
+	}
+
+	setters := make([]func(*ScanVuln, string), len(header))
+	for i, name := range header {
+		setters[i] = scanVulnCSVColumns[name]
+	}
+
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read export CSV row: %w", err)
+		}
+
+		var vuln ScanVuln
+		for i, value := range row {
+			if i < len(setters) && setters[i] != nil {
+				setters[i](&vuln, value)
+			}
+		}
+
+		select {
+		case out <- vuln:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package tio
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/base"
+)
+
+// WaitOptions configures ScansAPI.Wait. The zero value is valid: Wait
+// fills in reasonable defaults for any unset field.
+type WaitOptions struct {
+	// Interval is the base of the exponential backoff applied between
+	// polls; the first poll is made immediately. Defaults to 5s.
+	Interval time.Duration
+	// MaxInterval caps the backed-off delay between polls. Defaults to
+	// 60s.
+	MaxInterval time.Duration
+	// BackoffFactor is the exponential growth factor applied to
+	// Interval after each poll. 1 disables backoff (every poll waits
+	// Interval, with jitter). Defaults to 2.
+	BackoffFactor float64
+	// TerminalStates are the scan statuses that end the wait. Defaults
+	// to "completed", "aborted", "canceled", "stopped", and "empty" —
+	// the statuses Tenable.io uses for a scan that has finished running,
+	// one way or another.
+	TerminalStates []string
+	// OnProgress, if set, is called with each observed status before
+	// Wait decides whether to keep polling.
+	OnProgress func(status string)
+}
+
+func (o WaitOptions) withDefaults() WaitOptions {
+	if o.Interval <= 0 {
+		o.Interval = 5 * time.Second
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = 60 * time.Second
+	}
+	if o.BackoffFactor <= 0 {
+		o.BackoffFactor = 2
+	}
+	if len(o.TerminalStates) == 0 {
+		o.TerminalStates = []string{"completed", "aborted", "canceled", "stopped", "empty"}
+	}
+	return o
+}
+
+func (o WaitOptions) isTerminal(status string) bool {
+	for _, s := range o.TerminalStates {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Wait polls scanID's status (via Status) until it reaches one of
+// opts.TerminalStates, returning the last observed status. It backs off
+// exponentially between polls, with full jitter, up to opts.MaxInterval,
+// and honors ctx for both cancellation and deadlines (pair it with
+// base.WithDeadline for a bounded wait). If ctx's deadline is exceeded
+// before a terminal state is observed, Wait returns a
+// *base.ScanTimeoutError recording the last status seen; any other ctx
+// error (e.g. explicit cancellation) is returned as-is.
+//
+// This consolidates the ad-hoc polling loop Export used to hardcode
+// (2500ms sleep + status check) — Export and WaitForExport now share the
+// same wait-and-backoff machinery via waitForExportReady. ExportStream
+// does not: it polls the export status with its own fixed 2500ms loop.
+func (s *ScansAPI) Wait(ctx context.Context, scanID int, opts WaitOptions) (string, error) {
+	opts = opts.withDefaults()
+
+	lastStatus := ""
+	attempt := 0
+	for {
+		status, err := s.Status(ctx, scanID)
+		if err != nil {
+			return lastStatus, err
+		}
+		lastStatus = status
+
+		if opts.OnProgress != nil {
+			opts.OnProgress(status)
+		}
+		if opts.isTerminal(status) {
+			return status, nil
+		}
+
+		if err := scanWaitSleep(ctx, opts, attempt); err != nil {
+			if err == context.DeadlineExceeded {
+				return lastStatus, &base.ScanTimeoutError{ScanID: scanID, Status: lastStatus}
+			}
+			return lastStatus, err
+		}
+		attempt++
+	}
+}
+
+// WaitForExport initiates a scanID export in format and waits for it to
+// become ready, using the same backoff Wait applies to a scan's own
+// status, then downloads the whole result into memory. It's the
+// counterpart to Export for callers who want control over the poll cadence (e.g. a
+// longer interval for a large export, or an OnProgress callback).
+func (s *ScansAPI) WaitForExport(ctx context.Context, scanID int, format string, historyID *int, chapters []string, opts WaitOptions) (io.Reader, error) {
+	fileID, err := s.initiateExport(ctx, scanID, format, historyID, chapters)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.waitForExportReady(ctx, scanID, fileID, opts); err != nil {
+		return nil, err
+	}
+
+	data, err := s.client.Download(ctx, fmt.Sprintf("scans/%d/export/%d/download", scanID, fileID))
+	if err != nil {
+		return nil, err
+	}
+	return &bytesReader{data: data}, nil
+}
+
+// waitForExportReady polls fileID's export status until it's "ready",
+// returning the terminal status. It's shared by Export (with a fixed,
+// jittered 2500ms interval, to preserve its historical cadence) and
+// WaitForExport (with caller-chosen WaitOptions).
+func (s *ScansAPI) waitForExportReady(ctx context.Context, scanID, fileID int, opts WaitOptions) (string, error) {
+	opts = opts.withDefaults()
+
+	attempt := 0
+	for {
+		status, err := s.exportStatus(ctx, scanID, fileID)
+		if err != nil {
+			return "", err
+		}
+		if opts.OnProgress != nil {
+			opts.OnProgress(status)
+		}
+		if status == "ready" {
+			return status, nil
+		}
+		if status == "error" {
+			return "", &base.FileDownloadError{
+				Resource:   "scans",
+				ResourceID: strconv.Itoa(scanID),
+				Filename:   strconv.Itoa(fileID),
+			}
+		}
+
+		if err := scanWaitSleep(ctx, opts, attempt); err != nil {
+			if err == context.DeadlineExceeded {
+				return "", &base.ScanTimeoutError{ScanID: scanID, Status: status}
+			}
+			return "", err
+		}
+		attempt++
+	}
+}
+
+// scanWaitSleep waits out the backed-off delay for the given poll
+// attempt (0-based), or returns early with ctx.Err() if ctx ends first.
+func scanWaitSleep(ctx context.Context, opts WaitOptions, attempt int) error {
+	timer := time.NewTimer(scanWaitBackoff(opts, attempt))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// scanWaitBackoff computes the full-jitter delay before the given poll
+// attempt (0-based), growing opts.Interval by opts.BackoffFactor each
+// attempt and capping at opts.MaxInterval.
+func scanWaitBackoff(opts WaitOptions, attempt int) time.Duration {
+	capped := float64(opts.Interval) * math.Pow(opts.BackoffFactor, float64(attempt))
+	if capped > float64(opts.MaxInterval) || capped < 0 {
+		capped = float64(opts.MaxInterval)
+	}
+	if capped <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(capped) + 1))
+}
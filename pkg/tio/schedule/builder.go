@@ -0,0 +1,282 @@
+// Package schedule builds and expands RFC 5545 RRULE recurrence rules
+// for exclusion schedules. ScheduleBuilder produces the raw RRULE string
+// that tio.CreateSchedule and the exclusion CRUD endpoints expect in
+// Schedule.RRules; NextOccurrences and Conflicts expand that string back
+// into concrete occurrence windows.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Freq is the FREQ component of an RRULE.
+type Freq string
+
+const (
+	Daily   Freq = "DAILY"
+	Weekly  Freq = "WEEKLY"
+	Monthly Freq = "MONTHLY"
+	Yearly  Freq = "YEARLY"
+)
+
+// weekdayCodes maps RRULE's two-letter BYDAY weekday codes to time.Weekday.
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// RRule is a parsed recurrence rule, as produced by ScheduleBuilder or
+// ParseRRule.
+type RRule struct {
+	Freq       Freq
+	Interval   int
+	ByDay      []string // RRULE BYDAY tokens, e.g. "MO" or "1MO" or "-1FR"
+	ByMonthDay []int
+	Count      int
+	Until      time.Time // zero if unset
+}
+
+// ScheduleBuilder constructs a validated RRULE string via a fluent API,
+// so callers no longer have to hand-craft one.
+type ScheduleBuilder struct {
+	rule RRule
+	err  error
+}
+
+// NewScheduleBuilder starts building a recurrence rule with the given
+// frequency.
+func NewScheduleBuilder(freq Freq) *ScheduleBuilder {
+	return &ScheduleBuilder{rule: RRule{Freq: freq, Interval: 1}}
+}
+
+// Freq overrides the recurrence frequency.
+func (b *ScheduleBuilder) Freq(freq Freq) *ScheduleBuilder {
+	b.rule.Freq = freq
+	return b
+}
+
+// Interval sets the recurrence interval (every n Freq units). n must be
+// at least 1.
+func (b *ScheduleBuilder) Interval(n int) *ScheduleBuilder {
+	if n < 1 {
+		b.setErr(fmt.Errorf("schedule: interval must be at least 1, got %d", n))
+		return b
+	}
+	b.rule.Interval = n
+	return b
+}
+
+// ByDay sets which weekdays the rule recurs on, as RRULE BYDAY tokens
+// (e.g. "MO", "TU"). Under FREQ=MONTHLY or FREQ=YEARLY a token may carry
+// a signed ordinal prefix, e.g. "1MO" for the first Monday or "-1FR" for
+// the last Friday of the period.
+func (b *ScheduleBuilder) ByDay(days ...string) *ScheduleBuilder {
+	for _, d := range days {
+		if _, _, err := parseByDay(d); err != nil {
+			b.setErr(err)
+			return b
+		}
+	}
+	b.rule.ByDay = append(b.rule.ByDay, days...)
+	return b
+}
+
+// ByMonthDay sets which days of the month the rule recurs on. Only valid
+// for FREQ=MONTHLY or FREQ=YEARLY. A negative value counts back from the
+// end of the month, e.g. -1 is the last day of the month.
+func (b *ScheduleBuilder) ByMonthDay(days ...int) *ScheduleBuilder {
+	for _, d := range days {
+		if d == 0 || d < -31 || d > 31 {
+			b.setErr(fmt.Errorf("schedule: invalid BYMONTHDAY value %d", d))
+			return b
+		}
+	}
+	b.rule.ByMonthDay = append(b.rule.ByMonthDay, days...)
+	return b
+}
+
+// Count terminates the series after n occurrences. Mutually exclusive
+// with Until.
+func (b *ScheduleBuilder) Count(n int) *ScheduleBuilder {
+	if n < 1 {
+		b.setErr(fmt.Errorf("schedule: count must be at least 1, got %d", n))
+		return b
+	}
+	b.rule.Count = n
+	return b
+}
+
+// Until terminates the series at t (inclusive). Mutually exclusive with
+// Count.
+func (b *ScheduleBuilder) Until(t time.Time) *ScheduleBuilder {
+	b.rule.Until = t
+	return b
+}
+
+func (b *ScheduleBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Build validates the accumulated rule and renders it as an RRULE
+// string, e.g. "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10".
+func (b *ScheduleBuilder) Build() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+	if err := b.rule.Validate(); err != nil {
+		return "", err
+	}
+	return b.rule.String(), nil
+}
+
+// Validate checks that the rule is an internally consistent combination
+// of fields — e.g. rejecting an ordinal BYDAY (like "1MO") under
+// FREQ=DAILY, or both Count and Until being set.
+func (r *RRule) Validate() error {
+	switch r.Freq {
+	case Daily, Weekly, Monthly, Yearly:
+	default:
+		return fmt.Errorf("schedule: invalid FREQ %q", r.Freq)
+	}
+
+	for _, d := range r.ByDay {
+		ordinal, _, err := parseByDay(d)
+		if err != nil {
+			return err
+		}
+		if ordinal != 0 && r.Freq != Monthly && r.Freq != Yearly {
+			return fmt.Errorf("schedule: BYDAY=%s with an ordinal prefix requires FREQ=MONTHLY or FREQ=YEARLY, got FREQ=%s", d, r.Freq)
+		}
+	}
+
+	if len(r.ByMonthDay) > 0 && r.Freq != Monthly && r.Freq != Yearly {
+		return fmt.Errorf("schedule: BYMONTHDAY requires FREQ=MONTHLY or FREQ=YEARLY, got FREQ=%s", r.Freq)
+	}
+
+	if r.Count > 0 && !r.Until.IsZero() {
+		return fmt.Errorf("schedule: COUNT and UNTIL are mutually exclusive")
+	}
+
+	return nil
+}
+
+// String renders the rule as an RRULE value.
+func (r *RRule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		parts = append(parts, "BYDAY="+strings.Join(r.ByDay, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, d := range r.ByMonthDay {
+			days[i] = strconv.Itoa(d)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// ParseRRule parses a raw RRULE value, as stored in a schedule's RRules
+// field, into an RRule.
+func ParseRRule(s string) (*RRule, error) {
+	rule := &RRule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("schedule: malformed RRULE component %q", part)
+		}
+		key, value := kv[0], kv[1]
+		switch key {
+		case "FREQ":
+			rule.Freq = Freq(value)
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("schedule: invalid INTERVAL %q", value)
+			}
+			rule.Interval = n
+		case "BYDAY":
+			rule.ByDay = strings.Split(value, ",")
+		case "BYMONTHDAY":
+			for _, d := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(d)
+				if err != nil {
+					return nil, fmt.Errorf("schedule: invalid BYMONTHDAY %q", d)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("schedule: invalid COUNT %q", value)
+			}
+			rule.Count = n
+		case "UNTIL":
+			t, err := parseUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = t
+		default:
+			// Unknown RRULE component: ignore rather than fail, so a
+			// forward-compatible addition from the API doesn't break
+			// parsing of an otherwise-valid schedule.
+		}
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// parseUntil parses an RRULE UNTIL value, which RFC 5545 allows as
+// either a UTC date-time ("...Z") or a local date-time.
+func parseUntil(value string) (time.Time, error) {
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse("20060102T150405Z", value)
+	}
+	return time.Parse("20060102T150405", value)
+}
+
+// parseByDay splits a BYDAY token into its optional signed ordinal and
+// two-letter weekday code, validating the weekday code.
+func parseByDay(token string) (ordinal int, day time.Weekday, err error) {
+	i := 0
+	for i < len(token) && (token[i] == '-' || token[i] == '+' || (token[i] >= '0' && token[i] <= '9')) {
+		i++
+	}
+	code := token[i:]
+	day, ok := weekdayCodes[code]
+	if !ok {
+		return 0, 0, fmt.Errorf("schedule: invalid BYDAY weekday code %q", token)
+	}
+	if i > 0 {
+		ordinal, err = strconv.Atoi(token[:i])
+		if err != nil || ordinal == 0 {
+			return 0, 0, fmt.Errorf("schedule: invalid BYDAY ordinal in %q", token)
+		}
+	}
+	return ordinal, day, nil
+}
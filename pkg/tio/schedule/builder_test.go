@@ -0,0 +1,48 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleBuilderBuild(t *testing.T) {
+	rrule, err := NewScheduleBuilder(Weekly).Interval(2).ByDay("MO", "WE").Count(10).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	want := "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10"
+	if rrule != want {
+		t.Errorf("Build() = %q, want %q", rrule, want)
+	}
+}
+
+func TestScheduleBuilderRejectsOrdinalByDayUnderDaily(t *testing.T) {
+	_, err := NewScheduleBuilder(Daily).ByDay("1MO").Build()
+	if err == nil {
+		t.Fatal("expected error for ordinal BYDAY under FREQ=DAILY, got nil")
+	}
+}
+
+func TestScheduleBuilderRejectsCountAndUntil(t *testing.T) {
+	_, err := NewScheduleBuilder(Monthly).ByMonthDay(-1).Count(5).Until(time.Now()).Build()
+	if err == nil {
+		t.Fatal("expected error for COUNT and UNTIL both set, got nil")
+	}
+}
+
+func TestParseRRuleRoundTrip(t *testing.T) {
+	raw := "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3"
+	rule, err := ParseRRule(raw)
+	if err != nil {
+		t.Fatalf("ParseRRule() error = %v", err)
+	}
+	if rule.String() != raw {
+		t.Errorf("round-trip = %q, want %q", rule.String(), raw)
+	}
+}
+
+func TestParseRRuleRejectsMalformed(t *testing.T) {
+	if _, err := ParseRRule("FREQ"); err == nil {
+		t.Fatal("expected error for malformed RRULE component, got nil")
+	}
+}
@@ -0,0 +1,44 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+)
+
+// NextN expands rule against tz, returning up to n occurrence start
+// times on or after from. Unlike NextOccurrences, it works directly from
+// an *RRule and a timezone name rather than a tio.ExclusionSchedule, so
+// callers previewing a schedule for something other than an exclusion
+// (e.g. a scan, via ScheduleScan) don't need to fabricate one.
+func NextN(rule *RRule, tz string, from time.Time, n int) ([]time.Time, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("schedule: load timezone %q: %w", tz, err)
+	}
+	from = from.In(loc)
+
+	times := make([]time.Time, 0, n)
+	seen := 0
+	err = walk(rule, from, func(occ time.Time) (bool, error) {
+		seen++
+		if rule.Count > 0 && seen > rule.Count {
+			return false, nil
+		}
+		if !rule.Until.IsZero() && occ.After(rule.Until) {
+			return false, nil
+		}
+		if occ.Before(from) {
+			return true, nil
+		}
+		times = append(times, occ)
+		return len(times) < n, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return times, nil
+}
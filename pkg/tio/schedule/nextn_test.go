@@ -0,0 +1,33 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextNCountTerminated(t *testing.T) {
+	rule := &RRule{Freq: Daily, Interval: 1, Count: 3}
+
+	times, err := NextN(rule, "UTC", time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), 10)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	if len(times) != 3 {
+		t.Fatalf("len(times) = %d, want 3", len(times))
+	}
+	if !times[2].Equal(time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("times[2] = %v, want 2024-01-03T09:00:00Z", times[2])
+	}
+}
+
+func TestNextNLimitsToN(t *testing.T) {
+	rule := &RRule{Freq: Weekly, Interval: 1, ByDay: []string{"MO", "WE", "FR"}}
+
+	times, err := NextN(rule, "UTC", time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), 2)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	if len(times) != 2 {
+		t.Fatalf("len(times) = %d, want 2", len(times))
+	}
+}
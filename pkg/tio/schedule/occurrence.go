@@ -0,0 +1,363 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio"
+)
+
+// scheduleTimeLayout matches the format tio.CreateSchedule writes
+// StartTime/EndTime in.
+const scheduleTimeLayout = "2006-01-02T15:04:05"
+
+// maxPeriods bounds how many FREQ periods (days, weeks, months, or
+// years) walk will step through looking for occurrences, so a rule that
+// can never satisfy its caller (e.g. a BYMONTHDAY that doesn't exist in
+// any month) terminates instead of looping forever.
+const maxPeriods = 10000
+
+// TimeRange is an occurrence window, inclusive of Start and exclusive of
+// End.
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// NextOccurrences expands schedule's RRULE against its StartTime,
+// EndTime, and Timezone, returning up to n occurrence windows starting
+// on or after from. The Timezone is loaded with time.LoadLocation, so
+// occurrences fall on the correct wall-clock time across DST
+// transitions.
+func NextOccurrences(schedule *tio.ExclusionSchedule, from time.Time, n int) ([]TimeRange, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("schedule: load timezone %q: %w", schedule.Timezone, err)
+	}
+
+	start, err := time.ParseInLocation(scheduleTimeLayout, schedule.StartTime, loc)
+	if err != nil {
+		return nil, fmt.Errorf("schedule: parse start time %q: %w", schedule.StartTime, err)
+	}
+	end, err := time.ParseInLocation(scheduleTimeLayout, schedule.EndTime, loc)
+	if err != nil {
+		return nil, fmt.Errorf("schedule: parse end time %q: %w", schedule.EndTime, err)
+	}
+	duration := end.Sub(start)
+	if duration < 0 {
+		duration = 0
+	}
+
+	rule, err := ParseRRule(schedule.RRules)
+	if err != nil {
+		return nil, err
+	}
+
+	from = from.In(loc)
+	occurrences := make([]TimeRange, 0, n)
+	seen := 0
+	err = walk(rule, start, func(occ time.Time) (bool, error) {
+		seen++
+		if rule.Count > 0 && seen > rule.Count {
+			return false, nil
+		}
+		if !rule.Until.IsZero() && occ.After(rule.Until) {
+			return false, nil
+		}
+		if occ.Before(from) {
+			return true, nil
+		}
+		occurrences = append(occurrences, TimeRange{Start: occ, End: occ.Add(duration)})
+		return len(occurrences) < n, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return occurrences, nil
+}
+
+// Conflicts returns the windows in which a and b's occurrences overlap,
+// considering only occurrences starting within horizon of now.
+func Conflicts(a, b *tio.ExclusionSchedule, horizon time.Duration) ([]TimeRange, error) {
+	now := time.Now()
+	const occurrenceCap = 1000
+
+	aOccs, err := occurrencesWithin(a, now, horizon, occurrenceCap)
+	if err != nil {
+		return nil, err
+	}
+	bOccs, err := occurrencesWithin(b, now, horizon, occurrenceCap)
+	if err != nil {
+		return nil, err
+	}
+
+	var overlaps []TimeRange
+	for _, x := range aOccs {
+		for _, y := range bOccs {
+			if x.Start.Before(y.End) && y.Start.Before(x.End) {
+				start, end := x.Start, x.End
+				if y.Start.After(start) {
+					start = y.Start
+				}
+				if y.End.Before(end) {
+					end = y.End
+				}
+				overlaps = append(overlaps, TimeRange{Start: start, End: end})
+			}
+		}
+	}
+	return overlaps, nil
+}
+
+// occurrencesWithin returns schedule's occurrences starting in
+// [from, from+horizon], capped at n occurrences inspected.
+func occurrencesWithin(schedule *tio.ExclusionSchedule, from time.Time, horizon time.Duration, n int) ([]TimeRange, error) {
+	all, err := NextOccurrences(schedule, from, n)
+	if err != nil {
+		return nil, err
+	}
+	until := from.Add(horizon)
+	within := make([]TimeRange, 0, len(all))
+	for _, occ := range all {
+		if occ.Start.After(until) {
+			break
+		}
+		within = append(within, occ)
+	}
+	return within, nil
+}
+
+// walk enumerates rule's occurrences in chronological order starting at
+// start, calling visit for each. visit returns whether walk should keep
+// going; walk stops early once visit returns false, once maxPeriods FREQ
+// periods have been scanned, or on error.
+func walk(rule *RRule, start time.Time, visit func(time.Time) (bool, error)) error {
+	switch rule.Freq {
+	case Daily:
+		return walkDaily(rule, start, visit)
+	case Weekly:
+		return walkWeekly(rule, start, visit)
+	case Monthly:
+		return walkMonthly(rule, start, visit)
+	case Yearly:
+		return walkYearly(rule, start, visit)
+	default:
+		return fmt.Errorf("schedule: unsupported FREQ %q", rule.Freq)
+	}
+}
+
+func walkDaily(rule *RRule, start time.Time, visit func(time.Time) (bool, error)) error {
+	cursor := start
+	for i := 0; i < maxPeriods; i++ {
+		cont, err := visit(cursor)
+		if err != nil {
+			return err
+		}
+		if !cont {
+			return nil
+		}
+		cursor = cursor.AddDate(0, 0, rule.Interval)
+	}
+	return nil
+}
+
+func walkWeekly(rule *RRule, start time.Time, visit func(time.Time) (bool, error)) error {
+	days := rule.ByDay
+	if len(days) == 0 {
+		days = []string{weekdayToCode(start.Weekday())}
+	}
+	weekdays := make([]time.Weekday, 0, len(days))
+	for _, d := range days {
+		_, wd, err := parseByDay(d)
+		if err != nil {
+			return err
+		}
+		weekdays = append(weekdays, wd)
+	}
+	sort.Slice(weekdays, func(i, j int) bool { return weekdays[i] < weekdays[j] })
+
+	weekStart := startOfWeek(start)
+	for i := 0; i < maxPeriods; i++ {
+		for _, wd := range weekdays {
+			occ := dateAtWeekday(weekStart, wd, start)
+			if occ.Before(start) {
+				continue
+			}
+			cont, err := visit(occ)
+			if err != nil {
+				return err
+			}
+			if !cont {
+				return nil
+			}
+		}
+		weekStart = weekStart.AddDate(0, 0, 7*rule.Interval)
+	}
+	return nil
+}
+
+func walkMonthly(rule *RRule, start time.Time, visit func(time.Time) (bool, error)) error {
+	monthCursor := time.Date(start.Year(), start.Month(), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	for i := 0; i < maxPeriods; i++ {
+		occs, err := monthOccurrences(rule, monthCursor, start)
+		if err != nil {
+			return err
+		}
+		sort.Slice(occs, func(i, j int) bool { return occs[i].Before(occs[j]) })
+		for _, occ := range occs {
+			if occ.Before(start) {
+				continue
+			}
+			cont, err := visit(occ)
+			if err != nil {
+				return err
+			}
+			if !cont {
+				return nil
+			}
+		}
+		monthCursor = monthCursor.AddDate(0, rule.Interval, 0)
+	}
+	return nil
+}
+
+func walkYearly(rule *RRule, start time.Time, visit func(time.Time) (bool, error)) error {
+	yearCursor := time.Date(start.Year(), start.Month(), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	for i := 0; i < maxPeriods; i++ {
+		occs, err := monthOccurrences(rule, yearCursor, start)
+		if err != nil {
+			return err
+		}
+		sort.Slice(occs, func(i, j int) bool { return occs[i].Before(occs[j]) })
+		for _, occ := range occs {
+			if occ.Before(start) {
+				continue
+			}
+			cont, err := visit(occ)
+			if err != nil {
+				return err
+			}
+			if !cont {
+				return nil
+			}
+		}
+		yearCursor = yearCursor.AddDate(rule.Interval, 0, 0)
+	}
+	return nil
+}
+
+// monthOccurrences returns monthStart's month's occurrences matching
+// rule's BYMONTHDAY or BYDAY, or — if neither is set — the single
+// occurrence on template's day of month. It's shared by walkMonthly
+// (where monthStart advances a month at a time) and walkYearly (where it
+// advances a year at a time but always lands back on template's month).
+func monthOccurrences(rule *RRule, monthStart, template time.Time) ([]time.Time, error) {
+	var occs []time.Time
+	switch {
+	case len(rule.ByMonthDay) > 0:
+		for _, d := range rule.ByMonthDay {
+			day := resolveMonthDay(monthStart, d)
+			if day == 0 {
+				continue
+			}
+			occs = append(occs, time.Date(monthStart.Year(), monthStart.Month(), day, template.Hour(), template.Minute(), template.Second(), template.Nanosecond(), template.Location()))
+		}
+	case len(rule.ByDay) > 0:
+		for _, token := range rule.ByDay {
+			tokenOccs, err := monthlyByDayOccurrences(monthStart, token, template)
+			if err != nil {
+				return nil, err
+			}
+			occs = append(occs, tokenOccs...)
+		}
+	default:
+		occs = append(occs, time.Date(monthStart.Year(), monthStart.Month(), template.Day(), template.Hour(), template.Minute(), template.Second(), template.Nanosecond(), template.Location()))
+	}
+	return occs, nil
+}
+
+// monthlyByDayOccurrences resolves a single BYDAY token (e.g. "MO",
+// "1MO", or "-1FR") against monthStart's month: an unordinaled token
+// matches every such weekday in the month, a positive ordinal picks the
+// nth from the start, and a negative ordinal picks the nth from the end.
+func monthlyByDayOccurrences(monthStart time.Time, token string, template time.Time) ([]time.Time, error) {
+	ordinal, wd, err := parseByDay(token)
+	if err != nil {
+		return nil, err
+	}
+
+	daysInMonth := daysIn(monthStart.Year(), monthStart.Month())
+	var matches []int
+	for day := 1; day <= daysInMonth; day++ {
+		d := time.Date(monthStart.Year(), monthStart.Month(), day, 0, 0, 0, 0, monthStart.Location())
+		if d.Weekday() == wd {
+			matches = append(matches, day)
+		}
+	}
+
+	var days []int
+	switch {
+	case ordinal == 0:
+		days = matches
+	case ordinal > 0:
+		if ordinal <= len(matches) {
+			days = []int{matches[ordinal-1]}
+		}
+	default:
+		idx := len(matches) + ordinal
+		if idx >= 0 {
+			days = []int{matches[idx]}
+		}
+	}
+
+	occs := make([]time.Time, 0, len(days))
+	for _, day := range days {
+		occs = append(occs, time.Date(monthStart.Year(), monthStart.Month(), day, template.Hour(), template.Minute(), template.Second(), template.Nanosecond(), template.Location()))
+	}
+	return occs, nil
+}
+
+// resolveMonthDay turns a BYMONTHDAY value (possibly negative, counting
+// back from month-end) into a day-of-month number, or 0 if monthStart's
+// month doesn't have that many days.
+func resolveMonthDay(monthStart time.Time, d int) int {
+	daysInMonth := daysIn(monthStart.Year(), monthStart.Month())
+	if d > 0 {
+		if d > daysInMonth {
+			return 0
+		}
+		return d
+	}
+	day := daysInMonth + d + 1
+	if day < 1 {
+		return 0
+	}
+	return day
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func startOfWeek(t time.Time) time.Time {
+	return t.AddDate(0, 0, -int(t.Weekday()))
+}
+
+func dateAtWeekday(weekStart time.Time, wd time.Weekday, template time.Time) time.Time {
+	day := weekStart.AddDate(0, 0, int(wd)-int(weekStart.Weekday()))
+	return time.Date(day.Year(), day.Month(), day.Day(), template.Hour(), template.Minute(), template.Second(), template.Nanosecond(), template.Location())
+}
+
+func weekdayToCode(wd time.Weekday) string {
+	for code, w := range weekdayCodes {
+		if w == wd {
+			return code
+		}
+	}
+	return ""
+}
@@ -0,0 +1,119 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio"
+)
+
+func TestNextOccurrencesCountTerminated(t *testing.T) {
+	sched := &tio.ExclusionSchedule{
+		Enabled:   true,
+		StartTime: "2024-01-01T09:00:00",
+		EndTime:   "2024-01-01T10:00:00",
+		Timezone:  "UTC",
+		RRules:    "FREQ=DAILY;COUNT=3",
+	}
+
+	occs, err := NextOccurrences(sched, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 10)
+	if err != nil {
+		t.Fatalf("NextOccurrences() error = %v", err)
+	}
+	if len(occs) != 3 {
+		t.Fatalf("len(occs) = %d, want 3", len(occs))
+	}
+	if !occs[2].Start.Equal(time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("occs[2].Start = %v, want 2024-01-03T09:00:00Z", occs[2].Start)
+	}
+}
+
+func TestNextOccurrencesUntilInLocalTimezone(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	sched := &tio.ExclusionSchedule{
+		Enabled:   true,
+		StartTime: "2024-01-01T09:00:00",
+		EndTime:   "2024-01-01T10:00:00",
+		Timezone:  "America/New_York",
+		RRules:    "FREQ=DAILY;UNTIL=20240103T140000Z",
+	}
+
+	occs, err := NextOccurrences(sched, time.Date(2024, 1, 1, 0, 0, 0, 0, loc), 10)
+	if err != nil {
+		t.Fatalf("NextOccurrences() error = %v", err)
+	}
+	// Jan 1 and Jan 2 occurrences (09:00 EST = 14:00 UTC) fall on or
+	// before UNTIL; Jan 3's occurs at the same instant as UNTIL, which
+	// is inclusive, so all three should appear.
+	if len(occs) != 3 {
+		t.Fatalf("len(occs) = %d, want 3", len(occs))
+	}
+}
+
+func TestNextOccurrencesAcrossDSTTransition(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	// 2024-03-10 is the US spring-forward date; the occurrence on and
+	// after it should still land at 09:00 local wall-clock time.
+	sched := &tio.ExclusionSchedule{
+		Enabled:   true,
+		StartTime: "2024-03-09T09:00:00",
+		EndTime:   "2024-03-09T10:00:00",
+		Timezone:  "America/New_York",
+		RRules:    "FREQ=DAILY;COUNT=3",
+	}
+
+	occs, err := NextOccurrences(sched, time.Date(2024, 3, 9, 0, 0, 0, 0, loc), 10)
+	if err != nil {
+		t.Fatalf("NextOccurrences() error = %v", err)
+	}
+	if len(occs) != 3 {
+		t.Fatalf("len(occs) = %d, want 3", len(occs))
+	}
+	for i, occ := range occs {
+		if h, m := occ.Start.Hour(), occ.Start.Minute(); h != 9 || m != 0 {
+			t.Errorf("occs[%d].Start = %v, want 09:00 local", i, occ.Start)
+		}
+	}
+}
+
+func TestConflictsOverlappingSchedules(t *testing.T) {
+	// Conflicts scans forward from time.Now() across the given horizon,
+	// so the schedules' start dates must be ahead of "now" but still
+	// within the 30-day horizon passed to Conflicts below.
+	future := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	a := &tio.ExclusionSchedule{
+		Enabled:   true,
+		StartTime: future + "T09:00:00",
+		EndTime:   future + "T11:00:00",
+		Timezone:  "UTC",
+		RRules:    "FREQ=DAILY;COUNT=2",
+	}
+	b := &tio.ExclusionSchedule{
+		Enabled:   true,
+		StartTime: future + "T10:00:00",
+		EndTime:   future + "T12:00:00",
+		Timezone:  "UTC",
+		RRules:    "FREQ=DAILY;COUNT=2",
+	}
+
+	overlaps, err := Conflicts(a, b, 30*24*time.Hour)
+	if err != nil {
+		t.Fatalf("Conflicts() error = %v", err)
+	}
+	if len(overlaps) != 2 {
+		t.Fatalf("len(overlaps) = %d, want 2", len(overlaps))
+	}
+	for _, o := range overlaps {
+		if o.Start.Hour() != 10 || o.End.Hour() != 11 {
+			t.Errorf("overlap = %+v, want start 10:00, end 11:00", o)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio"
+)
+
+// ScheduleScan validates rule and tz, then applies them to scanID via
+// ScansAPI.ScheduleRaw, so callers building recurring scans get the same
+// RRULE validation exclusion schedules get from ScheduleBuilder, plus a
+// check that tz is one of the timezones Tenable.io actually accepts.
+func ScheduleScan(ctx context.Context, scans *tio.ScansAPI, scanID int, rule *RRule, tz string) error {
+	if err := rule.Validate(); err != nil {
+		return fmt.Errorf("schedule scan: %w", err)
+	}
+
+	tzs, err := scans.Timezones(ctx)
+	if err != nil {
+		return fmt.Errorf("schedule scan: %w", err)
+	}
+	valid := false
+	for _, candidate := range tzs {
+		if candidate == tz {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("schedule scan: %q is not a valid Tenable.io timezone", tz)
+	}
+
+	if err := scans.ScheduleRaw(ctx, scanID, rule.String(), tz); err != nil {
+		return fmt.Errorf("schedule scan: %w", err)
+	}
+	return nil
+}
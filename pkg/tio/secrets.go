@@ -0,0 +1,82 @@
+package tio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SecretResolver dereferences a secret reference URI (minus its scheme,
+// e.g. "secret/data/nessus#password" for a "vault://" ref) to its
+// plaintext value.
+type SecretResolver interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// SecretRef marks a CredentialCreateRequest.Settings value as a reference
+// to resolve just-in-time through a registered SecretResolver, instead of
+// a literal value that gets sent to the API as-is. For example:
+//
+//	Settings: map[string]interface{}{
+//	    "password": SecretRef{URI: "vault://secret/data/nessus#password"},
+//	}
+type SecretRef struct {
+	URI string
+}
+
+// scheme returns the URI scheme of a SecretRef, e.g. "vault" for
+// "vault://secret/data/nessus#password".
+func (r SecretRef) scheme() (scheme, ref string, ok bool) {
+	parts := strings.SplitN(r.URI, "://", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// resolveSettings walks settings, resolving any SecretRef values via the
+// client's registered resolvers and leaving every other value untouched.
+// It returns a new map; the caller's original settings are never mutated.
+func (c *CredentialsAPI) resolveSettings(ctx context.Context, settings map[string]interface{}) (map[string]interface{}, error) {
+	if settings == nil {
+		return nil, nil
+	}
+
+	resolved := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		ref, isRef := value.(SecretRef)
+		if !isRef {
+			resolved[key] = value
+			continue
+		}
+
+		scheme, rest, ok := ref.scheme()
+		if !ok {
+			return nil, fmt.Errorf("settings.%s: malformed secret ref %q, expected scheme://...", key, ref.URI)
+		}
+
+		resolver := c.client.secretResolvers[scheme]
+		if resolver == nil {
+			return nil, fmt.Errorf("settings.%s: no secret resolver registered for scheme %q", key, scheme)
+		}
+
+		secret, err := resolver.Resolve(ctx, rest)
+		if err != nil {
+			return nil, fmt.Errorf("settings.%s: resolve secret ref %q: %w", key, ref.URI, err)
+		}
+		resolved[key] = secret
+	}
+	return resolved, nil
+}
+
+// zeroPlaintextSettings overwrites resolved string values in place once a
+// request has been sent. Go's GC makes this a best-effort measure rather
+// than a guarantee the bytes are scrubbed from memory, but it at least
+// keeps the plaintext out of this map past the point it's needed.
+func zeroPlaintextSettings(settings map[string]interface{}) {
+	for key, value := range settings {
+		if _, ok := value.(string); ok {
+			settings[key] = ""
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package tio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// EnvSecretResolver resolves "env://NAME" refs from the process
+// environment.
+type EnvSecretResolver struct{}
+
+// Resolve implements SecretResolver.
+func (EnvSecretResolver) Resolve(_ context.Context, ref string) (string, error) {
+	value, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("environment variable %q is not set", ref)
+	}
+	return value, nil
+}
+
+// FileSecretResolver resolves "file:///path/to/secret" refs by reading the
+// file's contents, trimming a single trailing newline if present.
+type FileSecretResolver struct{}
+
+// Resolve implements SecretResolver.
+func (FileSecretResolver) Resolve(_ context.Context, ref string) (string, error) {
+	data, err := os.ReadFile(ref)
+	if err != nil {
+		return "", fmt.Errorf("read secret file %q: %w", ref, err)
+	}
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+// VaultSecretResolver resolves "vault://<path>#<field>" refs against a
+// HashiCorp Vault KV secrets engine, supporting both KV v1 (data returned
+// flat) and KV v2 (data nested one level under "data").
+type VaultSecretResolver struct {
+	// Address is the Vault server address, e.g. "https://vault:8200".
+	Address string
+	// Token is the Vault token sent as X-Vault-Token.
+	Token string
+	// HTTPClient is used for requests; http.DefaultClient if nil.
+	HTTPClient *http.Client
+}
+
+// Resolve implements SecretResolver.
+func (r *VaultSecretResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	path, field, ok := strings.Cut(ref, "#")
+	if !ok {
+		return "", fmt.Errorf("vault secret ref %q is missing a #field", ref)
+	}
+
+	url := strings.TrimRight(r.Address, "/") + "/v1/" + strings.TrimLeft(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", r.Token)
+
+	client := r.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault request for %q: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("vault request for %q returned status %d", path, resp.StatusCode)
+	}
+
+	var body struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decode vault response for %q: %w", path, err)
+	}
+
+	// KV v2 nests the actual secret under data.data; KV v1 returns it
+	// flat under data. Try v2 first, then fall back to v1.
+	var v2 struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body.Data, &v2); err == nil && v2.Data != nil {
+		if value, ok := v2.Data[field]; ok {
+			return fmt.Sprintf("%v", value), nil
+		}
+	}
+
+	var v1 map[string]interface{}
+	if err := json.Unmarshal(body.Data, &v1); err == nil {
+		if value, ok := v1[field]; ok {
+			return fmt.Sprintf("%v", value), nil
+		}
+	}
+
+	return "", fmt.Errorf("field %q not found in vault secret %q", field, path)
+}
+
+// AWSSecretsManagerResolver resolves "aws://<secret-id>[#jsonKey]" refs
+// through a caller-supplied GetSecretValue function, so this package
+// doesn't need to depend on the AWS SDK. Wire it up with, for example,
+// secretsmanager.Client.GetSecretValueWithContext.
+type AWSSecretsManagerResolver struct {
+	GetSecretValue func(ctx context.Context, secretID string) (string, error)
+}
+
+// Resolve implements SecretResolver. If ref carries a "#field" suffix,
+// the secret value is parsed as JSON and the named field is returned.
+func (r *AWSSecretsManagerResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	if r.GetSecretValue == nil {
+		return "", fmt.Errorf("aws secrets manager resolver: GetSecretValue is not configured")
+	}
+
+	secretID, field, hasField := strings.Cut(ref, "#")
+	value, err := r.GetSecretValue(ctx, secretID)
+	if err != nil {
+		return "", fmt.Errorf("get aws secret %q: %w", secretID, err)
+	}
+	if !hasField {
+		return value, nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		return "", fmt.Errorf("aws secret %q is not a JSON object, cannot extract field %q", secretID, field)
+	}
+	fieldValue, ok := fields[field]
+	if !ok {
+		return "", fmt.Errorf("field %q not found in aws secret %q", field, secretID)
+	}
+	return fmt.Sprintf("%v", fieldValue), nil
+}
+
+// StaticSecretResolver is a test double that resolves refs from an
+// in-memory map, useful for exercising CredentialsAPI.Create/Update
+// without a live Vault/AWS/file dependency.
+type StaticSecretResolver map[string]string
+
+// Resolve implements SecretResolver.
+func (r StaticSecretResolver) Resolve(_ context.Context, ref string) (string, error) {
+	value, ok := r[ref]
+	if !ok {
+		return "", fmt.Errorf("no static secret registered for ref %q", ref)
+	}
+	return value, nil
+}
@@ -2,6 +2,9 @@ package tio
 
 import (
 	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
 )
 
 // SessionAPI handles session operations.
@@ -11,19 +14,54 @@ type SessionAPI struct {
 
 // SessionInfo represents the current session information.
 type SessionInfo struct {
-	ID            int    `json:"id"`
-	UUID          string `json:"uuid"`
-	Username      string `json:"username"`
-	Name          string `json:"name"`
-	Email         string `json:"email"`
-	Type          string `json:"type"`
-	ContainerID   int    `json:"container_id"`
-	ContainerUUID string `json:"container_uuid"`
-	ContainerName string `json:"container_name"`
-	Permissions   int    `json:"permissions"`
-	Groups        []int  `json:"groups"`
-	Lockout       bool   `json:"lockout"`
-	Enabled       bool   `json:"enabled"`
+	ID            int      `json:"id"`
+	UUID          string   `json:"uuid"`
+	Username      string   `json:"username"`
+	Name          string   `json:"name"`
+	Email         string   `json:"email"`
+	Type          string   `json:"type"`
+	ContainerID   int      `json:"container_id"`
+	ContainerUUID string   `json:"container_uuid"`
+	ContainerName string   `json:"container_name"`
+	Permissions   int      `json:"permissions"`
+	Groups        []int    `json:"groups"`
+	Lockout       bool     `json:"lockout"`
+	Enabled       bool     `json:"enabled"`
+	MFAMethods    []string `json:"mfa_methods,omitempty"`
+}
+
+// LoginWithCertificate verifies the current session using the TLS client
+// certificate configured via WithClientCertificate or
+// WithClientCertificateFile (alongside any API keys configured with
+// WithAPIKeys, which are still sent), and confirms the
+// session's container identity matches the certificate's subject common
+// name, guarding against a client holding a cert for one container being
+// handed back a session for another.
+func (s *SessionAPI) LoginWithCertificate(ctx context.Context) (*SessionInfo, error) {
+	cert, ok := s.client.ClientCertificate()
+	if !ok {
+		return nil, fmt.Errorf("login with certificate: no client certificate configured")
+	}
+
+	info, err := s.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("login with certificate: %w", err)
+	}
+
+	leaf := cert.Leaf
+	if leaf == nil {
+		leaf, err = x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return nil, fmt.Errorf("login with certificate: parse certificate: %w", err)
+		}
+	}
+
+	subject := leaf.Subject.CommonName
+	if !strings.EqualFold(subject, info.ContainerName) && !strings.EqualFold(subject, info.ContainerUUID) {
+		return nil, fmt.Errorf("login with certificate: session container %q does not match certificate subject %q", info.ContainerName, subject)
+	}
+
+	return info, nil
 }
 
 // Get retrieves the current session information.
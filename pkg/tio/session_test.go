@@ -0,0 +1,118 @@
+package tio
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// generateTestCertPEM returns a self-signed certificate/key pair, PEM
+// encoded, with the given subject common name.
+func generateTestCertPEM(t *testing.T, commonName string) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	keyBytes, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
+
+	return certPEM, keyPEM
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func sessionInfoTransport(info SessionInfo) http.RoundTripper {
+	return roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body, _ := json.Marshal(info)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(bytes.NewReader(body)),
+		}, nil
+	})
+}
+
+func TestLoginWithCertificateMatchesContainerName(t *testing.T) {
+	certPEM, keyPEM := generateTestCertPEM(t, "acme-tenant")
+
+	client, err := New(
+		WithAPIKeys("access", "secret"),
+		WithClientCertificate(certPEM, keyPEM),
+		WithHTTPTransport(sessionInfoTransport(SessionInfo{ContainerName: "acme-tenant"})),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	info, err := client.Session.LoginWithCertificate(context.Background())
+	if err != nil {
+		t.Fatalf("LoginWithCertificate() error = %v", err)
+	}
+	if info.ContainerName != "acme-tenant" {
+		t.Errorf("ContainerName = %q, want acme-tenant", info.ContainerName)
+	}
+}
+
+func TestLoginWithCertificateMismatchReturnsError(t *testing.T) {
+	certPEM, keyPEM := generateTestCertPEM(t, "acme-tenant")
+
+	client, err := New(
+		WithAPIKeys("access", "secret"),
+		WithClientCertificate(certPEM, keyPEM),
+		WithHTTPTransport(sessionInfoTransport(SessionInfo{ContainerName: "other-tenant"})),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := client.Session.LoginWithCertificate(context.Background()); err == nil {
+		t.Error("LoginWithCertificate() error = nil, want mismatch error")
+	}
+}
+
+func TestLoginWithCertificateRequiresCertificate(t *testing.T) {
+	client, err := New(
+		WithAPIKeys("access", "secret"),
+		WithHTTPTransport(sessionInfoTransport(SessionInfo{ContainerName: "acme-tenant"})),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := client.Session.LoginWithCertificate(context.Background()); err == nil {
+		t.Error("LoginWithCertificate() error = nil, want no-certificate error")
+	}
+}
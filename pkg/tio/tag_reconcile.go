@@ -0,0 +1,337 @@
+package tio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/base/filter"
+)
+
+// TagReconcileSpec declares the desired tag assignments Reconcile should
+// bring assets in line with.
+type TagReconcileSpec struct {
+	// Assets maps an asset UUID to the complete set of tag value UUIDs it
+	// should have; Reconcile computes and applies whatever
+	// AssignTags/UnassignTags calls make that true, replacing the old
+	// GetAssetTags-then-diff-by-hand sequence.
+	Assets map[string][]string
+	// Filter, if set, additionally selects target assets dynamically via
+	// AssetsAPI.List (e.g. filter.Eq("operating_system", "Windows")),
+	// each reconciled to DesiredTags. An asset UUID present in both
+	// Assets and matched by Filter keeps its explicit Assets entry.
+	Filter filter.Expr
+	// DesiredTags is the tag value UUID set applied to every asset
+	// matched by Filter. Ignored if Filter is zero.
+	DesiredTags []string
+}
+
+// tagReconcileAction identifies whether a TagReconcileBatch adds or
+// removes tags.
+type tagReconcileAction string
+
+const (
+	tagReconcileAssign   tagReconcileAction = "assign"
+	tagReconcileUnassign tagReconcileAction = "unassign"
+)
+
+// AssetTagDiff is the add/remove set Reconcile computed for one asset.
+type AssetTagDiff struct {
+	AssetUUID string
+	// Add is the tag value UUIDs present in the desired set but not the
+	// asset's current assignments.
+	Add []string
+	// Remove is the tag value UUIDs present in the asset's current
+	// assignments but not the desired set.
+	Remove []string
+}
+
+// TagReconcileBatch is a single AssignTags or UnassignTags call Reconcile
+// made (or, under DryRun, would have made), covering every asset sharing
+// the exact same add-set or remove-set.
+type TagReconcileBatch struct {
+	Action     tagReconcileAction
+	AssetUUIDs []string
+	TagUUIDs   []string
+	// Err is set if this batch's API call failed. Always nil under
+	// DryRun, since no call is made.
+	Err error
+}
+
+// TagReconcileReport summarizes a Reconcile run: the per-asset diff
+// Reconcile computed, and — unless DryRun was set — the coalesced
+// batches it applied and any errors encountered.
+type TagReconcileReport struct {
+	Diffs   []AssetTagDiff
+	Applied []TagReconcileBatch
+	Errors  []error
+}
+
+// Added returns how many asset/tag assignments Diffs would add in total.
+func (r *TagReconcileReport) Added() int {
+	n := 0
+	for _, d := range r.Diffs {
+		n += len(d.Add)
+	}
+	return n
+}
+
+// Removed returns how many asset/tag assignments Diffs would remove in
+// total.
+func (r *TagReconcileReport) Removed() int {
+	n := 0
+	for _, d := range r.Diffs {
+		n += len(d.Remove)
+	}
+	return n
+}
+
+// Failed returns the applied batches that errored.
+func (r *TagReconcileReport) Failed() []TagReconcileBatch {
+	var failed []TagReconcileBatch
+	for _, b := range r.Applied {
+		if b.Err != nil {
+			failed = append(failed, b)
+		}
+	}
+	return failed
+}
+
+// TagReconcileOptions configures Reconcile.
+type TagReconcileOptions struct {
+	// Concurrency bounds how many assets' current tag assignments are
+	// fetched at once (TagsAPI has no bulk "get tags for many assets"
+	// endpoint, so this is always a worker pool over per-asset
+	// GetAssetTags calls). Defaults to 8.
+	Concurrency int
+	// DryRun, when true, only populates TagReconcileReport.Diffs; no
+	// AssignTags/UnassignTags calls are made.
+	DryRun bool
+}
+
+func (o TagReconcileOptions) withDefaults() TagReconcileOptions {
+	if o.Concurrency <= 0 {
+		o.Concurrency = 8
+	}
+	return o
+}
+
+// Reconcile brings every asset named in spec.Assets, plus every asset
+// matched by spec.Filter, in line with its desired tag value set: it
+// fetches each asset's current assignments, computes the add/remove diff
+// against the desired set, coalesces identical diffs across assets into
+// the fewest possible AssignTags/UnassignTags calls, and applies them.
+// Setting opts.DryRun computes and returns the diff without applying it.
+// Context cancellation is honored both while fetching current
+// assignments and between applying batches. A cancellation during the
+// fetch returns ctx's error and no report; one between batches returns
+// the TagReconcileReport, with every batch not yet applied carrying
+// ctx's error in Err and in Errors.
+func (t *TagsAPI) Reconcile(ctx context.Context, spec TagReconcileSpec, opts TagReconcileOptions) (*TagReconcileReport, error) {
+	opts = opts.withDefaults()
+
+	targets, err := t.resolveReconcileTargets(ctx, spec)
+	if err != nil {
+		return nil, fmt.Errorf("tags reconcile: %w", err)
+	}
+
+	diffs, err := t.diffAssetTags(ctx, targets, opts)
+	if err != nil {
+		return nil, fmt.Errorf("tags reconcile: %w", err)
+	}
+
+	report := &TagReconcileReport{Diffs: diffs}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	for _, batch := range coalesceTagBatches(diffs) {
+		if err := ctx.Err(); err != nil {
+			batch.Err = err
+			report.Applied = append(report.Applied, batch)
+			report.Errors = append(report.Errors, err)
+			continue
+		}
+
+		switch batch.Action {
+		case tagReconcileAssign:
+			batch.Err = t.AssignTags(ctx, batch.AssetUUIDs, batch.TagUUIDs)
+		case tagReconcileUnassign:
+			batch.Err = t.UnassignTags(ctx, batch.AssetUUIDs, batch.TagUUIDs)
+		}
+		report.Applied = append(report.Applied, batch)
+		if batch.Err != nil {
+			report.Errors = append(report.Errors, batch.Err)
+		}
+	}
+
+	return report, nil
+}
+
+// resolveReconcileTargets merges spec.Assets with every asset matched by
+// spec.Filter (assigned spec.DesiredTags, unless it already has an
+// explicit Assets entry) into one assetUUID -> desired-tags map.
+func (t *TagsAPI) resolveReconcileTargets(ctx context.Context, spec TagReconcileSpec) (map[string][]string, error) {
+	targets := make(map[string][]string, len(spec.Assets))
+	for assetUUID, tags := range spec.Assets {
+		targets[assetUUID] = tags
+	}
+
+	if spec.Filter.IsZero() {
+		return targets, nil
+	}
+
+	it := t.client.Assets.List(ctx, &AssetListOptions{FilterExpr: spec.Filter})
+	for it.Next() {
+		asset := it.Item()
+		if _, ok := targets[asset.ID]; !ok {
+			targets[asset.ID] = spec.DesiredTags
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("list assets matching filter: %w", err)
+	}
+	return targets, nil
+}
+
+// diffAssetTags fetches each target asset's current tag assignments
+// concurrently across a worker pool bounded by opts.Concurrency and
+// returns the add/remove diff against its desired set, sorted by asset
+// UUID for deterministic output.
+func (t *TagsAPI) diffAssetTags(ctx context.Context, targets map[string][]string, opts TagReconcileOptions) ([]AssetTagDiff, error) {
+	type job struct {
+		assetUUID string
+		desired   []string
+	}
+	jobs := make(chan job)
+	go func() {
+		defer close(jobs)
+		for assetUUID, desired := range targets {
+			select {
+			case jobs <- job{assetUUID: assetUUID, desired: desired}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	type result struct {
+		diff AssetTagDiff
+		err  error
+	}
+	results := make(chan result)
+	var wg sync.WaitGroup
+	wg.Add(opts.Concurrency)
+	for i := 0; i < opts.Concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				current, err := t.GetAssetTags(ctx, j.assetUUID)
+				if err != nil {
+					results <- result{err: fmt.Errorf("get current tags for asset %s: %w", j.assetUUID, err)}
+					continue
+				}
+				results <- result{diff: diffTagSets(j.assetUUID, current, j.desired)}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var diffs []AssetTagDiff
+	var firstErr error
+	for r := range results {
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = r.err
+			}
+			continue
+		}
+		diffs = append(diffs, r.diff)
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].AssetUUID < diffs[j].AssetUUID })
+	return diffs, nil
+}
+
+// diffTagSets computes the add/remove diff between an asset's current
+// tag assignments and its desired tag value UUID set.
+func diffTagSets(assetUUID string, current []TagValue, desired []string) AssetTagDiff {
+	currentSet := make(map[string]bool, len(current))
+	for _, tv := range current {
+		currentSet[tv.UUID] = true
+	}
+	desiredSet := make(map[string]bool, len(desired))
+	for _, uuid := range desired {
+		desiredSet[uuid] = true
+	}
+
+	diff := AssetTagDiff{AssetUUID: assetUUID}
+	for uuid := range desiredSet {
+		if !currentSet[uuid] {
+			diff.Add = append(diff.Add, uuid)
+		}
+	}
+	for uuid := range currentSet {
+		if !desiredSet[uuid] {
+			diff.Remove = append(diff.Remove, uuid)
+		}
+	}
+	sort.Strings(diff.Add)
+	sort.Strings(diff.Remove)
+	return diff
+}
+
+// coalesceTagBatches groups diffs sharing an identical add-set (or
+// remove-set) into one TagReconcileBatch each, so N assets needing the
+// same tag change become one AssignTags/UnassignTags call instead of N.
+func coalesceTagBatches(diffs []AssetTagDiff) []TagReconcileBatch {
+	type group struct {
+		tagUUIDs   []string
+		assetUUIDs []string
+	}
+
+	addGroups := make(map[string]*group)
+	removeGroups := make(map[string]*group)
+	var addKeys, removeKeys []string // first-seen order, for deterministic output
+
+	for _, d := range diffs {
+		if len(d.Add) > 0 {
+			key := strings.Join(d.Add, ",")
+			if _, ok := addGroups[key]; !ok {
+				addGroups[key] = &group{tagUUIDs: d.Add}
+				addKeys = append(addKeys, key)
+			}
+			addGroups[key].assetUUIDs = append(addGroups[key].assetUUIDs, d.AssetUUID)
+		}
+		if len(d.Remove) > 0 {
+			key := strings.Join(d.Remove, ",")
+			if _, ok := removeGroups[key]; !ok {
+				removeGroups[key] = &group{tagUUIDs: d.Remove}
+				removeKeys = append(removeKeys, key)
+			}
+			removeGroups[key].assetUUIDs = append(removeGroups[key].assetUUIDs, d.AssetUUID)
+		}
+	}
+
+	batches := make([]TagReconcileBatch, 0, len(addKeys)+len(removeKeys))
+	for _, key := range addKeys {
+		g := addGroups[key]
+		batches = append(batches, TagReconcileBatch{Action: tagReconcileAssign, AssetUUIDs: g.assetUUIDs, TagUUIDs: g.tagUUIDs})
+	}
+	for _, key := range removeKeys {
+		g := removeGroups[key]
+		batches = append(batches, TagReconcileBatch{Action: tagReconcileUnassign, AssetUUIDs: g.assetUUIDs, TagUUIDs: g.tagUUIDs})
+	}
+	return batches
+}
@@ -0,0 +1,175 @@
+package tio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// reconcileTagsFixtureTransport serves current tag assignments from
+// current (asset UUID -> tag value UUIDs) and records every
+// tags/assets/assignments POST it sees.
+type reconcileTagsFixtureTransport struct {
+	current map[string][]string
+
+	mu    sync.Mutex
+	posts []map[string]interface{}
+}
+
+func (f *reconcileTagsFixtureTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/tags/assets/") && strings.HasSuffix(r.URL.Path, "/assignments"):
+		assetUUID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/tags/assets/"), "/assignments")
+		var tags []TagValue
+		for _, uuid := range f.current[assetUUID] {
+			tags = append(tags, TagValue{UUID: uuid})
+		}
+		return jsonResponse(map[string]interface{}{"tags": tags})
+
+	case r.Method == http.MethodPost && r.URL.Path == "/tags/assets/assignments":
+		var payload map[string]interface{}
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &payload)
+		f.mu.Lock()
+		f.posts = append(f.posts, payload)
+		f.mu.Unlock()
+		return jsonResponse(map[string]interface{}{})
+
+	default:
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewReader(nil)), Header: http.Header{}}, nil
+	}
+}
+
+func jsonResponse(body interface{}) (*http.Response, error) {
+	encoded, _ := json.Marshal(body)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(encoded)),
+	}, nil
+}
+
+func reconcileTagsTestClient(t *testing.T, transport *reconcileTagsFixtureTransport) *Client {
+	t.Helper()
+	client, err := New(WithAPIKeys("access", "secret"), WithHTTPTransport(transport))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return client
+}
+
+func TestDiffTagSetsComputesAddAndRemove(t *testing.T) {
+	diff := diffTagSets("asset-1", []TagValue{{UUID: "t1"}, {UUID: "t2"}}, []string{"t2", "t3"})
+	if !reflectStringsEqual(diff.Add, []string{"t3"}) {
+		t.Errorf("Add = %v, want [t3]", diff.Add)
+	}
+	if !reflectStringsEqual(diff.Remove, []string{"t1"}) {
+		t.Errorf("Remove = %v, want [t1]", diff.Remove)
+	}
+}
+
+func TestCoalesceTagBatchesGroupsIdenticalDiffs(t *testing.T) {
+	diffs := []AssetTagDiff{
+		{AssetUUID: "asset-1", Add: []string{"t3"}, Remove: []string{"t1"}},
+		{AssetUUID: "asset-2", Remove: []string{"t1"}},
+		{AssetUUID: "asset-3", Remove: []string{"t9"}},
+	}
+
+	batches := coalesceTagBatches(diffs)
+
+	var unassignT1, unassignT9 *TagReconcileBatch
+	for i := range batches {
+		b := &batches[i]
+		if b.Action == tagReconcileUnassign && reflectStringsEqual(b.TagUUIDs, []string{"t1"}) {
+			unassignT1 = b
+		}
+		if b.Action == tagReconcileUnassign && reflectStringsEqual(b.TagUUIDs, []string{"t9"}) {
+			unassignT9 = b
+		}
+	}
+	if unassignT1 == nil {
+		t.Fatalf("no coalesced unassign batch for t1 in %+v", batches)
+	}
+	sort.Strings(unassignT1.AssetUUIDs)
+	if !reflectStringsEqual(unassignT1.AssetUUIDs, []string{"asset-1", "asset-2"}) {
+		t.Errorf("unassign t1 assets = %v, want [asset-1 asset-2] (asset-1 and asset-2 share an identical remove-set)", unassignT1.AssetUUIDs)
+	}
+	if unassignT9 == nil || !reflectStringsEqual(unassignT9.AssetUUIDs, []string{"asset-3"}) {
+		t.Errorf("unassign t9 batch missing or wrong assets: %+v", unassignT9)
+	}
+}
+
+func TestReconcileAppliesCoalescedBatches(t *testing.T) {
+	transport := &reconcileTagsFixtureTransport{current: map[string][]string{
+		"asset-1": {"t1"},
+		"asset-2": {"t1"},
+	}}
+	client := reconcileTagsTestClient(t, transport)
+
+	spec := TagReconcileSpec{Assets: map[string][]string{
+		"asset-1": {"t2"},
+		"asset-2": {"t2"},
+	}}
+
+	report, err := client.Tags.Reconcile(context.Background(), spec, TagReconcileOptions{})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if got := report.Added(); got != 2 {
+		t.Errorf("Added() = %d, want 2", got)
+	}
+	if got := report.Removed(); got != 2 {
+		t.Errorf("Removed() = %d, want 2", got)
+	}
+	if failed := report.Failed(); len(failed) != 0 {
+		t.Errorf("Failed() = %+v, want none", failed)
+	}
+
+	transport.mu.Lock()
+	defer transport.mu.Unlock()
+	if len(transport.posts) != 2 {
+		t.Fatalf("posts = %d, want 2 (one coalesced assign, one coalesced unassign, both assets share identical diffs)", len(transport.posts))
+	}
+}
+
+func TestReconcileDryRunAppliesNothing(t *testing.T) {
+	transport := &reconcileTagsFixtureTransport{current: map[string][]string{"asset-1": {"t1"}}}
+	client := reconcileTagsTestClient(t, transport)
+
+	spec := TagReconcileSpec{Assets: map[string][]string{"asset-1": {"t2"}}}
+
+	report, err := client.Tags.Reconcile(context.Background(), spec, TagReconcileOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(report.Diffs) != 1 || report.Added() != 1 || report.Removed() != 1 {
+		t.Errorf("Diffs = %+v, want one asset with one add and one remove", report.Diffs)
+	}
+	if report.Applied != nil {
+		t.Errorf("Applied = %+v, want none under DryRun", report.Applied)
+	}
+
+	transport.mu.Lock()
+	defer transport.mu.Unlock()
+	if len(transport.posts) != 0 {
+		t.Errorf("posts = %d, want 0 under DryRun", len(transport.posts))
+	}
+}
+
+func reflectStringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -7,7 +7,8 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/tenable/gotenable/pkg/base"
+	"github.com/biggeezerdevelopment/gotenable/pkg/base"
+	"github.com/biggeezerdevelopment/gotenable/pkg/base/filter"
 )
 
 // TagsAPI handles tag operations.
@@ -114,17 +115,60 @@ func (t *TagsAPI) CreateCategory(ctx context.Context, name, description string)
 	return &result, nil
 }
 
-// GetCategory retrieves a specific tag category.
+// GetCategory retrieves a specific tag category. If WithTagCache was
+// configured, a live cache entry is returned without a request.
 func (t *TagsAPI) GetCategory(ctx context.Context, categoryUUID string) (*TagCategory, error) {
+	if c := t.client.tagCategoryCache; c != nil {
+		if cached, ok := c.Get(categoryUUID); ok {
+			return &cached, nil
+		}
+	}
+
 	var result TagCategory
 	_, err := t.client.Get(ctx, fmt.Sprintf("tags/categories/%s", categoryUUID), &result)
 	if err != nil {
 		return nil, err
 	}
+
+	if c := t.client.tagCategoryCache; c != nil {
+		c.Put(categoryUUID, result)
+	}
 	return &result, nil
 }
 
-// UpdateCategory updates a tag category.
+// LookupCategoryByName finds the tag category named name, returning
+// (nil, nil) if none matches. There's no name-indexed endpoint to call
+// directly, so this pages through every category via ListCategories; if
+// WithTagCache was configured, a live cache entry (keyed by name) avoids
+// that scan.
+func (t *TagsAPI) LookupCategoryByName(ctx context.Context, name string) (*TagCategory, error) {
+	if c := t.client.tagCategoryNameCache; c != nil {
+		if cached, ok := c.Get(name); ok {
+			return &cached, nil
+		}
+	}
+
+	it := t.ListCategories(ctx)
+	for it.Next() {
+		cat := it.Item()
+		if cat.Name != name {
+			continue
+		}
+		if c := t.client.tagCategoryNameCache; c != nil {
+			c.Put(name, cat)
+		}
+		return &cat, nil
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// UpdateCategory updates a tag category. The category's UUID-keyed cache
+// entry is invalidated; if the update changed its name, a previously
+// cached LookupCategoryByName result for the old name is left to expire
+// via WithTagCache's TTL, since the old name isn't available here.
 func (t *TagsAPI) UpdateCategory(ctx context.Context, categoryUUID, name, description string) (*TagCategory, error) {
 	payload := map[string]string{
 		"name":        name,
@@ -136,28 +180,57 @@ func (t *TagsAPI) UpdateCategory(ctx context.Context, categoryUUID, name, descri
 	if err != nil {
 		return nil, err
 	}
+
+	if c := t.client.tagCategoryCache; c != nil {
+		c.Put(categoryUUID, result)
+	}
 	return &result, nil
 }
 
 // DeleteCategory deletes a tag category.
 func (t *TagsAPI) DeleteCategory(ctx context.Context, categoryUUID string) error {
 	_, err := t.client.Delete(ctx, fmt.Sprintf("tags/categories/%s", categoryUUID))
-	return err
+	if err != nil {
+		return err
+	}
+
+	if c := t.client.tagCategoryCache; c != nil {
+		c.Delete(categoryUUID)
+	}
+	return nil
 }
 
-// ListValues retrieves all tag values.
-func (t *TagsAPI) ListValues(ctx context.Context, filters []TagFilter) *base.Iterator[TagValue] {
+// ListValues retrieves all tag values. An optional filter.Expr can be
+// given instead of, or alongside, filters — it's rendered via
+// filter.Expr.Params and appended as further numbered f.N params, so
+// both sources end up ANDed together (as filters always are in this
+// query format). expr must be a flat conjunction (no Or/Not); see
+// filter.Expr.Params.
+func (t *TagsAPI) ListValues(ctx context.Context, filters []TagFilter, expr ...filter.Expr) *base.Iterator[TagValue] {
 	fetcher := func(ctx context.Context, offset, limit int) (json.RawMessage, *base.PaginationInfo, error) {
 		params := map[string]string{
 			"limit":  strconv.Itoa(limit),
 			"offset": strconv.Itoa(offset),
 		}
 
-		// Add filters
-		for i, f := range filters {
-			params[fmt.Sprintf("f.%d.field", i)] = f.Field
-			params[fmt.Sprintf("f.%d.operator", i)] = f.Operator
-			params[fmt.Sprintf("f.%d.value", i)] = f.Value
+		n := 0
+		for _, f := range filters {
+			params[fmt.Sprintf("f.%d.field", n)] = f.Field
+			params[fmt.Sprintf("f.%d.operator", n)] = f.Operator
+			params[fmt.Sprintf("f.%d.value", n)] = f.Value
+			n++
+		}
+		if len(expr) > 0 && !expr[0].IsZero() {
+			exprParams, err := expr[0].Params()
+			if err != nil {
+				return nil, nil, fmt.Errorf("tags: %w", err)
+			}
+			for _, p := range exprParams {
+				params[fmt.Sprintf("f.%d.field", n)] = p.Field
+				params[fmt.Sprintf("f.%d.operator", n)] = p.Operator
+				params[fmt.Sprintf("f.%d.value", n)] = p.Value
+				n++
+			}
 		}
 
 		var result struct {
@@ -207,17 +280,62 @@ func (t *TagsAPI) CreateValue(ctx context.Context, categoryUUID, value, descript
 	return &result, nil
 }
 
-// GetValue retrieves a specific tag value.
+// GetValue retrieves a specific tag value. If WithTagCache was
+// configured, a live cache entry is returned without a request.
 func (t *TagsAPI) GetValue(ctx context.Context, valueUUID string) (*TagValue, error) {
+	if c := t.client.tagValueCache; c != nil {
+		if cached, ok := c.Get(valueUUID); ok {
+			return &cached, nil
+		}
+	}
+
 	var result TagValue
 	_, err := t.client.Get(ctx, fmt.Sprintf("tags/values/%s", valueUUID), &result)
 	if err != nil {
 		return nil, err
 	}
+
+	if c := t.client.tagValueCache; c != nil {
+		c.Put(valueUUID, result)
+	}
 	return &result, nil
 }
 
-// UpdateValue updates a tag value.
+// LookupValueByCategoryAndValue finds the tag value string value under
+// categoryUUID, returning (nil, nil) if none matches. If WithTagCache was
+// configured, a live cache entry (keyed by categoryUUID and value) avoids
+// the ListValues call.
+func (t *TagsAPI) LookupValueByCategoryAndValue(ctx context.Context, categoryUUID, value string) (*TagValue, error) {
+	cacheKey := categoryUUID + "/" + value
+	if c := t.client.tagValueLookupCache; c != nil {
+		if cached, ok := c.Get(cacheKey); ok {
+			return &cached, nil
+		}
+	}
+
+	filters := []TagFilter{
+		{Field: "category_uuid", Operator: "eq", Value: categoryUUID},
+		{Field: "value", Operator: "eq", Value: value},
+	}
+	it := t.ListValues(ctx, filters)
+	for it.Next() {
+		tv := it.Item()
+		if c := t.client.tagValueLookupCache; c != nil {
+			c.Put(cacheKey, tv)
+		}
+		return &tv, nil
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// UpdateValue updates a tag value. The value's UUID-keyed cache entry is
+// invalidated; if the update changed its value string, a previously
+// cached LookupValueByCategoryAndValue result for the old string is left
+// to expire via WithTagCache's TTL, since the old value isn't available
+// here.
 func (t *TagsAPI) UpdateValue(ctx context.Context, valueUUID, value, description string) (*TagValue, error) {
 	payload := map[string]string{
 		"value":       value,
@@ -229,13 +347,24 @@ func (t *TagsAPI) UpdateValue(ctx context.Context, valueUUID, value, description
 	if err != nil {
 		return nil, err
 	}
+
+	if c := t.client.tagValueCache; c != nil {
+		c.Put(valueUUID, result)
+	}
 	return &result, nil
 }
 
 // DeleteValue deletes a tag value.
 func (t *TagsAPI) DeleteValue(ctx context.Context, valueUUID string) error {
 	_, err := t.client.Delete(ctx, fmt.Sprintf("tags/values/%s", valueUUID))
-	return err
+	if err != nil {
+		return err
+	}
+
+	if c := t.client.tagValueCache; c != nil {
+		c.Delete(valueUUID)
+	}
+	return nil
 }
 
 // TagAssignment represents a tag assignment to an asset.
@@ -0,0 +1,46 @@
+package tio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/base/filter"
+)
+
+func TestTagsListValuesAppendsFilterExprParams(t *testing.T) {
+	var gotQuery map[string][]string
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		gotQuery = map[string][]string(r.URL.Query())
+		body, _ := json.Marshal(map[string]interface{}{"values": []TagValue{}})
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(bytes.NewReader(body)),
+		}, nil
+	})
+
+	client, err := New(WithAPIKeys("access", "secret"), WithHTTPTransport(transport))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	existing := []TagFilter{{Field: "category_name", Operator: "eq", Value: "env"}}
+	expr := filter.Eq("value", "prod")
+
+	it := client.Tags.ListValues(context.Background(), existing, expr)
+	it.Next()
+
+	if got := gotQuery["f.0.field"]; len(got) != 1 || got[0] != "category_name" {
+		t.Errorf("f.0.field = %v, want [category_name]", got)
+	}
+	if got := gotQuery["f.1.field"]; len(got) != 1 || got[0] != "value" {
+		t.Errorf("f.1.field = %v, want [value] (filter.Expr params should follow TagFilter ones)", got)
+	}
+	if got := gotQuery["f.1.value"]; len(got) != 1 || got[0] != "prod" {
+		t.Errorf("f.1.value = %v, want [prod]", got)
+	}
+}
@@ -0,0 +1,81 @@
+package tio
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret20 is the 20-byte ASCII seed from RFC 6238 Appendix B,
+// base32 encoded: base32.StdEncoding.EncodeToString([]byte("12345678901234567890")).
+const rfc6238Secret20 = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateTOTPCodeMatchesRFC6238Vector(t *testing.T) {
+	// RFC 6238 Appendix B, T = 59 seconds, SHA1: full 8-digit truncation is
+	// 94287082. GenerateTOTPCode truncates to 6 digits, i.e. the low-order
+	// 6 of that value.
+	at := time.Unix(59, 0).UTC()
+
+	got, err := GenerateTOTPCode(rfc6238Secret20, at)
+	if err != nil {
+		t.Fatalf("GenerateTOTPCode returned error: %v", err)
+	}
+	if want := "287082"; got != want {
+		t.Errorf("GenerateTOTPCode(at=59s) = %q, want %q", got, want)
+	}
+}
+
+func TestGenerateTOTPCodeChangesEachPeriod(t *testing.T) {
+	first, err := GenerateTOTPCode(rfc6238Secret20, time.Unix(59, 0).UTC())
+	if err != nil {
+		t.Fatalf("GenerateTOTPCode returned error: %v", err)
+	}
+	second, err := GenerateTOTPCode(rfc6238Secret20, time.Unix(59+int64(totpPeriod.Seconds()), 0).UTC())
+	if err != nil {
+		t.Fatalf("GenerateTOTPCode returned error: %v", err)
+	}
+	if first == second {
+		t.Errorf("expected codes in different periods to differ, both = %q", first)
+	}
+}
+
+func TestValidateTOTPCodeAllowsOneStepDrift(t *testing.T) {
+	base := time.Unix(59, 0).UTC()
+	code, err := GenerateTOTPCode(rfc6238Secret20, base)
+	if err != nil {
+		t.Fatalf("GenerateTOTPCode returned error: %v", err)
+	}
+
+	oneStepLater := base.Add(totpPeriod)
+	if !ValidateTOTPCode(rfc6238Secret20, code, oneStepLater) {
+		t.Errorf("ValidateTOTPCode should accept a code from one period of drift")
+	}
+
+	twoStepsLater := base.Add(2 * totpPeriod)
+	if ValidateTOTPCode(rfc6238Secret20, code, twoStepsLater) {
+		t.Errorf("ValidateTOTPCode should reject a code outside the one-step drift window")
+	}
+}
+
+func TestValidateTOTPCodeRejectsWrongCode(t *testing.T) {
+	if ValidateTOTPCode(rfc6238Secret20, "000000", time.Unix(59, 0).UTC()) {
+		t.Errorf("ValidateTOTPCode should reject an incorrect code")
+	}
+}
+
+func TestTOTPURIContainsExpectedParameters(t *testing.T) {
+	uri := totpURI(rfc6238Secret20, "alice@example.com", "Tenable.io")
+
+	for _, want := range []string{
+		"otpauth://totp/",
+		"secret=" + rfc6238Secret20,
+		"issuer=Tenable.io",
+		"algorithm=SHA1",
+		"digits=6",
+		"period=30",
+	} {
+		if !strings.Contains(uri, want) {
+			t.Errorf("totpURI() = %q, want substring %q", uri, want)
+		}
+	}
+}
@@ -57,22 +57,18 @@ type UserEditRequest struct {
 
 // List retrieves all users.
 func (u *UsersAPI) List(ctx context.Context) ([]User, error) {
-	var result struct {
-		Users []User `json:"users"`
-	}
-
-	_, err := u.client.Get(ctx, "users", &result)
+	var result []User
+	_, err := u.client.NewRequest().Method("GET").Path("users").Envelope("users").Do(ctx, &result)
 	if err != nil {
 		return nil, err
 	}
-
-	return result.Users, nil
+	return result, nil
 }
 
 // Create creates a new user.
 func (u *UsersAPI) Create(ctx context.Context, req *UserCreateRequest) (*User, error) {
 	var result User
-	_, err := u.client.Post(ctx, "users", req, &result)
+	_, err := u.client.NewRequest().Method("POST").Path("users").Body(req).Do(ctx, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +78,7 @@ func (u *UsersAPI) Create(ctx context.Context, req *UserCreateRequest) (*User, e
 // Get retrieves a specific user.
 func (u *UsersAPI) Get(ctx context.Context, userID int) (*User, error) {
 	var result User
-	_, err := u.client.Get(ctx, fmt.Sprintf("users/%d", userID), &result)
+	_, err := u.client.NewRequest().Method("GET").Path("users/%d", userID).Do(ctx, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -92,7 +88,7 @@ func (u *UsersAPI) Get(ctx context.Context, userID int) (*User, error) {
 // Edit updates a user.
 func (u *UsersAPI) Edit(ctx context.Context, userID int, req *UserEditRequest) (*User, error) {
 	var result User
-	_, err := u.client.Put(ctx, fmt.Sprintf("users/%d", userID), req, &result)
+	_, err := u.client.NewRequest().Method("PUT").Path("users/%d", userID).Body(req).Do(ctx, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +97,7 @@ func (u *UsersAPI) Edit(ctx context.Context, userID int, req *UserEditRequest) (
 
 // Delete removes a user.
 func (u *UsersAPI) Delete(ctx context.Context, userID int) error {
-	_, err := u.client.Delete(ctx, fmt.Sprintf("users/%d", userID))
+	_, err := u.client.NewRequest().Method("DELETE").Path("users/%d", userID).Do(ctx, nil)
 	return err
 }
 
@@ -123,14 +119,14 @@ func (u *UsersAPI) ChangePassword(ctx context.Context, userID int, currentPasswo
 		"current_password": currentPassword,
 		"password":         newPassword,
 	}
-	_, err := u.client.Put(ctx, fmt.Sprintf("users/%d/chpasswd", userID), payload, nil)
+	_, err := u.client.NewRequest().Method("PUT").Path("users/%d/chpasswd", userID).Body(payload).Do(ctx, nil)
 	return err
 }
 
 // GenerateAPIKeys generates new API keys for a user.
 func (u *UsersAPI) GenerateAPIKeys(ctx context.Context, userID int) (*APIKeys, error) {
 	var result APIKeys
-	_, err := u.client.Put(ctx, fmt.Sprintf("users/%d/keys", userID), nil, &result)
+	_, err := u.client.NewRequest().Method("PUT").Path("users/%d/keys", userID).Do(ctx, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -146,7 +142,7 @@ type APIKeys struct {
 // GetAPIKeys retrieves a user's API keys.
 func (u *UsersAPI) GetAPIKeys(ctx context.Context, userID int) (*APIKeys, error) {
 	var result APIKeys
-	_, err := u.client.Get(ctx, fmt.Sprintf("users/%d/keys", userID), &result)
+	_, err := u.client.NewRequest().Method("GET").Path("users/%d/keys", userID).Do(ctx, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -155,7 +151,7 @@ func (u *UsersAPI) GetAPIKeys(ctx context.Context, userID int) (*APIKeys, error)
 
 // DeleteAPIKeys deletes a user's API keys.
 func (u *UsersAPI) DeleteAPIKeys(ctx context.Context, userID int) error {
-	_, err := u.client.Delete(ctx, fmt.Sprintf("users/%d/keys", userID))
+	_, err := u.client.NewRequest().Method("DELETE").Path("users/%d/keys", userID).Do(ctx, nil)
 	return err
 }
 
@@ -169,13 +165,55 @@ func (u *UsersAPI) Impersonate(ctx context.Context, userID int) (string, error)
 	var result struct {
 		Token string `json:"token"`
 	}
-	_, err := u.client.Post(ctx, "users/impersonate", &ImpersonateRequest{UserID: userID}, &result)
+	_, err := u.client.NewRequest().Method("POST").Path("users/impersonate").Body(&ImpersonateRequest{UserID: userID}).Do(ctx, &result)
 	if err != nil {
 		return "", err
 	}
 	return result.Token, nil
 }
 
+// ImpersonateClient returns a *Client scoped to act on behalf of the
+// user identified by userID: every outbound request it makes
+// automatically carries the X-Impersonate header, so callers can't
+// forget to set it per-request. The returned client shares u's parent
+// Client's underlying transport, retry policy, rate limiter, and auth
+// provider (via base.Client.Clone) but is otherwise independent,
+// exposing the same API endpoint fields (Scans, Assets, Users, ...).
+// Call StopImpersonating on it when done.
+//
+// This uses the X-Impersonate header rather than the bearer token
+// Impersonate returns, since a token set as a client-level Authorization
+// header would be overridden per-request by a configured
+// base.AuthProvider's Apply.
+func (u *UsersAPI) ImpersonateClient(ctx context.Context, userID int) (*Client, error) {
+	user, err := u.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &Client{
+		Client:               u.client.Client.Clone(),
+		secretResolvers:      u.client.secretResolvers,
+		tagCategoryCache:     u.client.tagCategoryCache,
+		tagCategoryNameCache: u.client.tagCategoryNameCache,
+		tagValueCache:        u.client.tagValueCache,
+		tagValueLookupCache:  u.client.tagValueLookupCache,
+		assetCache:           u.client.assetCache,
+		assetInfoCache:       u.client.assetInfoCache,
+	}
+	sub.wireEndpoints()
+	sub.SetHeader("X-Impersonate", fmt.Sprintf("user_uuid=%s", user.UUID))
+
+	return sub, nil
+}
+
+// StopImpersonating removes the X-Impersonate header installed by
+// ImpersonateClient, reverting c to issuing requests under its own
+// identity again.
+func (c *Client) StopImpersonating() {
+	c.DeleteHeader("X-Impersonate")
+}
+
 // GroupsAPI handles user group operations.
 type GroupsAPI struct {
 	client *Client
@@ -193,23 +231,19 @@ type Group struct {
 
 // List retrieves all groups.
 func (g *GroupsAPI) List(ctx context.Context) ([]Group, error) {
-	var result struct {
-		Groups []Group `json:"groups"`
-	}
-
-	_, err := g.client.Get(ctx, "groups", &result)
+	var result []Group
+	_, err := g.client.NewRequest().Method("GET").Path("groups").Envelope("groups").Do(ctx, &result)
 	if err != nil {
 		return nil, err
 	}
-
-	return result.Groups, nil
+	return result, nil
 }
 
 // Create creates a new group.
 func (g *GroupsAPI) Create(ctx context.Context, name string) (*Group, error) {
 	payload := map[string]string{"name": name}
 	var result Group
-	_, err := g.client.Post(ctx, "groups", payload, &result)
+	_, err := g.client.NewRequest().Method("POST").Path("groups").Body(payload).Do(ctx, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -218,7 +252,7 @@ func (g *GroupsAPI) Create(ctx context.Context, name string) (*Group, error) {
 
 // Delete removes a group.
 func (g *GroupsAPI) Delete(ctx context.Context, groupID int) error {
-	_, err := g.client.Delete(ctx, fmt.Sprintf("groups/%d", groupID))
+	_, err := g.client.NewRequest().Method("DELETE").Path("groups/%d", groupID).Do(ctx, nil)
 	return err
 }
 
@@ -226,7 +260,7 @@ func (g *GroupsAPI) Delete(ctx context.Context, groupID int) error {
 func (g *GroupsAPI) Edit(ctx context.Context, groupID int, name string) (*Group, error) {
 	payload := map[string]string{"name": name}
 	var result Group
-	_, err := g.client.Put(ctx, fmt.Sprintf("groups/%d", groupID), payload, &result)
+	_, err := g.client.NewRequest().Method("PUT").Path("groups/%d", groupID).Body(payload).Do(ctx, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -235,24 +269,22 @@ func (g *GroupsAPI) Edit(ctx context.Context, groupID int, name string) (*Group,
 
 // AddUser adds a user to a group.
 func (g *GroupsAPI) AddUser(ctx context.Context, groupID, userID int) error {
-	_, err := g.client.Post(ctx, fmt.Sprintf("groups/%d/users/%d", groupID, userID), nil, nil)
+	_, err := g.client.NewRequest().Method("POST").Path("groups/%d/users/%d", groupID, userID).Do(ctx, nil)
 	return err
 }
 
 // RemoveUser removes a user from a group.
 func (g *GroupsAPI) RemoveUser(ctx context.Context, groupID, userID int) error {
-	_, err := g.client.Delete(ctx, fmt.Sprintf("groups/%d/users/%d", groupID, userID))
+	_, err := g.client.NewRequest().Method("DELETE").Path("groups/%d/users/%d", groupID, userID).Do(ctx, nil)
 	return err
 }
 
 // ListUsers lists users in a group.
 func (g *GroupsAPI) ListUsers(ctx context.Context, groupID int) ([]User, error) {
-	var result struct {
-		Users []User `json:"users"`
-	}
-	_, err := g.client.Get(ctx, fmt.Sprintf("groups/%d/users", groupID), &result)
+	var result []User
+	_, err := g.client.NewRequest().Method("GET").Path("groups/%d/users", groupID).Envelope("users").Do(ctx, &result)
 	if err != nil {
 		return nil, err
 	}
-	return result.Users, nil
+	return result, nil
 }
@@ -0,0 +1,75 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio"
+)
+
+// Emitter polls a ScansAPI for a fixed set of scan IDs and fires the
+// same Callbacks a Handler would dispatch from a pushed webhook, for
+// deployments that poll instead of (or in addition to) receiving
+// Tenable's callbacks.
+type Emitter struct {
+	scans     *tio.ScansAPI
+	scanIDs   []int
+	callbacks Callbacks
+	seen      map[int]string
+}
+
+// NewEmitter creates an Emitter over scanIDs, using scans to poll status
+// and callbacks to dispatch events.
+func NewEmitter(scans *tio.ScansAPI, scanIDs []int, callbacks Callbacks) *Emitter {
+	return &Emitter{
+		scans:     scans,
+		scanIDs:   scanIDs,
+		callbacks: callbacks,
+		seen:      make(map[int]string, len(scanIDs)),
+	}
+}
+
+// Poll checks each scan's status once, firing the corresponding
+// callback for any scan whose status has changed since the previous
+// Poll call (or, on the first call, for any scan that's already
+// terminal). Callers typically run Poll on a ticker.
+func (e *Emitter) Poll(ctx context.Context) error {
+	for _, scanID := range e.scanIDs {
+		status, err := e.scans.Status(ctx, scanID)
+		if err != nil {
+			return fmt.Errorf("webhooks: poll scan %d: %w", scanID, err)
+		}
+		if e.seen[scanID] == status {
+			continue
+		}
+		e.seen[scanID] = status
+		e.fire(ctx, scanID, status)
+	}
+	return nil
+}
+
+// fire looks up scanID's details and dispatches status's callback, if
+// any is registered and status warrants one. Fetch failures are
+// swallowed: a transient Details error shouldn't abort the rest of the
+// poll loop, and the next Poll call will simply see the same status
+// again (it's only re-dispatched on a change).
+func (e *Emitter) fire(ctx context.Context, scanID int, status string) {
+	var cb func(*tio.Scan, *tio.ScanDetails)
+	switch status {
+	case "completed":
+		cb = e.callbacks.OnScanCompleted
+	case "aborted", "canceled":
+		cb = e.callbacks.OnScanFailed
+	default:
+		return
+	}
+	if cb == nil {
+		return
+	}
+
+	details, err := e.scans.Details(ctx, scanID)
+	if err != nil {
+		return
+	}
+	cb(&tio.Scan{ID: scanID, Status: status}, details)
+}
@@ -0,0 +1,101 @@
+package webhooks
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/biggeezerdevelopment/gotenable/pkg/tio"
+)
+
+func signedRequest(authToken string, ts time.Time, body []byte) *http.Request {
+	timestamp := strconv.FormatInt(ts.Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/scans", bytes.NewReader(body))
+	req.Header.Set("X-Tenable-Signature", Sign(authToken, timestamp, body))
+	req.Header.Set("X-Tenable-Timestamp", timestamp)
+	return req
+}
+
+func TestHandlerDispatchesOnValidSignature(t *testing.T) {
+	const authToken = "shared-secret"
+	body := []byte(`{"status":"completed","scan":{"id":7}}`)
+
+	var gotScan *tio.Scan
+	h := NewHandler(HandlerOptions{AuthToken: authToken}, Callbacks{
+		OnScanCompleted: func(scan *tio.Scan, _ *tio.ScanDetails) { gotScan = scan },
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, signedRequest(authToken, time.Now(), body))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if gotScan == nil || gotScan.ID != 7 {
+		t.Fatalf("OnScanCompleted scan = %+v, want ID 7", gotScan)
+	}
+}
+
+func TestHandlerRejectsBadSignature(t *testing.T) {
+	body := []byte(`{"status":"completed","scan":{"id":7}}`)
+
+	called := false
+	h := NewHandler(HandlerOptions{AuthToken: "shared-secret"}, Callbacks{
+		OnScanCompleted: func(*tio.Scan, *tio.ScanDetails) { called = true },
+	})
+
+	req := signedRequest("wrong-secret", time.Now(), body)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", w.Code)
+	}
+	if called {
+		t.Error("OnScanCompleted fired despite invalid signature")
+	}
+}
+
+func TestHandlerRejectsStaleTimestamp(t *testing.T) {
+	const authToken = "shared-secret"
+	body := []byte(`{"status":"completed","scan":{"id":7}}`)
+
+	called := false
+	h := NewHandler(HandlerOptions{AuthToken: authToken, ReplayWindow: time.Minute}, Callbacks{
+		OnScanCompleted: func(*tio.Scan, *tio.ScanDetails) { called = true },
+	})
+
+	req := signedRequest(authToken, time.Now().Add(-time.Hour), body)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", w.Code)
+	}
+	if called {
+		t.Error("OnScanCompleted fired despite stale timestamp")
+	}
+}
+
+func TestHandlerIgnoresUncallbackedStatus(t *testing.T) {
+	const authToken = "shared-secret"
+	body := []byte(`{"status":"running","scan":{"id":7}}`)
+
+	called := false
+	h := NewHandler(HandlerOptions{AuthToken: authToken}, Callbacks{
+		OnScanCompleted: func(*tio.Scan, *tio.ScanDetails) { called = true },
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, signedRequest(authToken, time.Now(), body))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if called {
+		t.Error("OnScanCompleted fired for a non-terminal status")
+	}
+}